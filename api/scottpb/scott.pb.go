@@ -1,20 +1,20 @@
 // Protocol buffers related to the Scott Adams adventure games.
 //
 // To rebuild:
-//   protoc -I=. --go_out=. ./scott.proto
+//   protoc -I=. --go_out=. --go-grpc_out=. ./scott.proto
 //
-// This will yield an updated copy of scott.pb.go in this directory.
+// This will yield updated copies of scott.pb.go and scott_grpc.pb.go in this
+// directory.
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.24.0-devel
-// 	protoc        v3.12.3
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
 // source: scott.proto
 
 package scottpb
 
 import (
-	proto "github.com/golang/protobuf/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -28,10 +28,6 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// This is a compile-time assertion that a sufficiently up-to-date version
-// of the legacy proto package is being used.
-const _ = proto.ProtoPackageIsVersion4
-
 type ConditionType int32
 
 const (
@@ -589,6 +585,64 @@ func (ActionType) EnumDescriptor() ([]byte, []int) {
 	return file_scott_proto_rawDescGZIP(), []int{1}
 }
 
+type EventType int32
+
+const (
+	EventType_EVENT_TEXT         EventType = 0 // some text to print, exactly as given
+	EventType_EVENT_LOOK         EventType = 1 // the room should be described
+	EventType_EVENT_CLEAR_SCREEN EventType = 2 // the screen should be cleared
+	EventType_EVENT_DELAY        EventType = 3 // the game wants a pause of a couple of seconds
+	EventType_EVENT_SAVE         EventType = 4 // the game wants to be saved
+	EventType_EVENT_GAME_OVER    EventType = 5 // the game has ended
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TEXT",
+		1: "EVENT_LOOK",
+		2: "EVENT_CLEAR_SCREEN",
+		3: "EVENT_DELAY",
+		4: "EVENT_SAVE",
+		5: "EVENT_GAME_OVER",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TEXT":         0,
+		"EVENT_LOOK":         1,
+		"EVENT_CLEAR_SCREEN": 2,
+		"EVENT_DELAY":        3,
+		"EVENT_SAVE":         4,
+		"EVENT_GAME_OVER":    5,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_scott_proto_enumTypes[2].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_scott_proto_enumTypes[2]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{2}
+}
+
 type Header struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1115,9 +1169,15 @@ type State struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Location int32   `protobuf:"varint,1,opt,name=location,proto3" json:"location,omitempty"`        // current location of the player
-	Flags    []bool  `protobuf:"varint,2,rep,packed,name=flags,proto3" json:"flags,omitempty"`       // the current flag values
-	Counters []int32 `protobuf:"varint,3,rep,packed,name=counters,proto3" json:"counters,omitempty"` // the current counter values
+	Location      int32   `protobuf:"varint,1,opt,name=location,proto3" json:"location,omitempty"`                                       // current location of the player
+	Flags         []bool  `protobuf:"varint,2,rep,packed,name=flags,proto3" json:"flags,omitempty"`                                      // the current flag values
+	Counters      []int32 `protobuf:"varint,3,rep,packed,name=counters,proto3" json:"counters,omitempty"`                                // the current counter values
+	Counter       int32   `protobuf:"varint,4,opt,name=counter,proto3" json:"counter,omitempty"`                                         // the current counter (swapped with counters by SELECT_COUNTER)
+	SavedRoom     int32   `protobuf:"varint,5,opt,name=saved_room,json=savedRoom,proto3" json:"saved_room,omitempty"`                    // the location-swap register used by SWAP_LOCATION
+	SavedRooms    []int32 `protobuf:"varint,6,rep,packed,name=saved_rooms,json=savedRooms,proto3" json:"saved_rooms,omitempty"`          // the location-swap registers used by SWAP_LOCATION_N
+	LightTime     int32   `protobuf:"varint,7,opt,name=light_time,json=lightTime,proto3" json:"light_time,omitempty"`                    // number of turns of light remaining, or -1 for eternal
+	GameOver      bool    `protobuf:"varint,8,opt,name=game_over,json=gameOver,proto3" json:"game_over,omitempty"`                       // set once the game has ended
+	ItemLocations []int32 `protobuf:"varint,9,rep,packed,name=item_locations,json=itemLocations,proto3" json:"item_locations,omitempty"` // the location of each item, with -1 for the inventory
 }
 
 func (x *State) Reset() {
@@ -1173,6 +1233,95 @@ func (x *State) GetCounters() []int32 {
 	return nil
 }
 
+func (x *State) GetCounter() int32 {
+	if x != nil {
+		return x.Counter
+	}
+	return 0
+}
+
+func (x *State) GetSavedRoom() int32 {
+	if x != nil {
+		return x.SavedRoom
+	}
+	return 0
+}
+
+func (x *State) GetSavedRooms() []int32 {
+	if x != nil {
+		return x.SavedRooms
+	}
+	return nil
+}
+
+func (x *State) GetLightTime() int32 {
+	if x != nil {
+		return x.LightTime
+	}
+	return 0
+}
+
+func (x *State) GetGameOver() bool {
+	if x != nil {
+		return x.GameOver
+	}
+	return false
+}
+
+func (x *State) GetItemLocations() []int32 {
+	if x != nil {
+		return x.ItemLocations
+	}
+	return nil
+}
+
+type Layout struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ItemLocationOnOwnLine bool `protobuf:"varint,1,opt,name=item_location_on_own_line,json=itemLocationOnOwnLine,proto3" json:"item_location_on_own_line,omitempty"` // item locations follow a newline rather than a space
+}
+
+func (x *Layout) Reset() {
+	*x = Layout{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Layout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Layout) ProtoMessage() {}
+
+func (x *Layout) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Layout.ProtoReflect.Descriptor instead.
+func (*Layout) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Layout) GetItemLocationOnOwnLine() bool {
+	if x != nil {
+		return x.ItemLocationOnOwnLine
+	}
+	return false
+}
+
 type Game struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1187,14 +1336,15 @@ type Game struct {
 	Messages []string  `protobuf:"bytes,6,rep,name=messages,proto3" json:"messages,omitempty"`
 	Items    []*Item   `protobuf:"bytes,7,rep,name=items,proto3" json:"items,omitempty"`
 	Footer   *Footer   `protobuf:"bytes,8,opt,name=footer,proto3" json:"footer,omitempty"`
-	// These fields are additional state that exists in-game.
-	State *State `protobuf:"bytes,9,opt,name=state,proto3" json:"state,omitempty"`
+	// This field records details of how the game file was laid out, so that it
+	// can be written back out byte for byte.
+	Layout *Layout `protobuf:"bytes,10,opt,name=layout,proto3" json:"layout,omitempty"`
 }
 
 func (x *Game) Reset() {
 	*x = Game{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_scott_proto_msgTypes[8]
+		mi := &file_scott_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1207,7 +1357,7 @@ func (x *Game) String() string {
 func (*Game) ProtoMessage() {}
 
 func (x *Game) ProtoReflect() protoreflect.Message {
-	mi := &file_scott_proto_msgTypes[8]
+	mi := &file_scott_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1220,7 +1370,7 @@ func (x *Game) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Game.ProtoReflect.Descriptor instead.
 func (*Game) Descriptor() ([]byte, []int) {
-	return file_scott_proto_rawDescGZIP(), []int{8}
+	return file_scott_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Game) GetHeader() *Header {
@@ -1279,347 +1429,1493 @@ func (x *Game) GetFooter() *Footer {
 	return nil
 }
 
-func (x *Game) GetState() *State {
+func (x *Game) GetLayout() *Layout {
 	if x != nil {
-		return x.State
+		return x.Layout
 	}
 	return nil
 }
 
-var File_scott_proto protoreflect.FileDescriptor
+type LookData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_scott_proto_rawDesc = []byte{
-	0x0a, 0x0b, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x73,
-	0x63, 0x6f, 0x74, 0x74, 0x22, 0x9b, 0x03, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
-	0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x30, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x08, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x30, 0x12, 0x1b, 0x0a, 0x09, 0x6e,
-	0x75, 0x6d, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
-	0x6e, 0x75, 0x6d, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x5f,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6e,
-	0x75, 0x6d, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d,
-	0x5f, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x75,
-	0x6d, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x6f,
-	0x6f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x52, 0x6f,
-	0x6f, 0x6d, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x6e, 0x76, 0x65, 0x6e,
-	0x74, 0x6f, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x49,
-	0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72,
-	0x74, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x23, 0x0a,
-	0x0d, 0x6e, 0x75, 0x6d, 0x5f, 0x74, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x73, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6e, 0x75, 0x6d, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72,
-	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74,
-	0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x64, 0x4c, 0x65, 0x6e,
-	0x67, 0x74, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x64, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6c, 0x69, 0x67,
-	0x68, 0x74, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x75,
-	0x6d, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x23, 0x0a,
-	0x0d, 0x74, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x0c,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x74, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x52, 0x6f,
-	0x6f, 0x6d, 0x22, 0x4b, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e,
-	0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
-	0xbf, 0x01, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x65,
-	0x72, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
-	0x76, 0x65, 0x72, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x75,
-	0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6e,
-	0x6f, 0x75, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x30, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x73,
-	0x63, 0x6f, 0x74, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
-	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x07, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x73, 0x63,
-	0x6f, 0x74, 0x74, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
-	0x74, 0x22, 0x34, 0x0a, 0x04, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x18, 0x0a,
-	0x07, 0x73, 0x79, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
-	0x73, 0x79, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x22, 0x58, 0x0a, 0x04, 0x52, 0x6f, 0x6f, 0x6d, 0x12,
-	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x07, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x65,
-	0x78, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x65, 0x78, 0x69, 0x74,
-	0x73, 0x22, 0x81, 0x01, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
-	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
-	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x74,
-	0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69,
-	0x73, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x75, 0x74,
-	0x6f, 0x67, 0x72, 0x61, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x74,
-	0x6f, 0x67, 0x72, 0x61, 0x62, 0x22, 0x56, 0x0a, 0x06, 0x46, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x12,
-	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x76,
-	0x65, 0x6e, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x61, 0x64,
-	0x76, 0x65, 0x6e, 0x74, 0x75, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x67, 0x69, 0x63,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6d, 0x61, 0x67, 0x69, 0x63, 0x22, 0x55, 0x0a,
-	0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x08, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x65, 0x72, 0x73, 0x22, 0xc9, 0x02, 0x0a, 0x04, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a,
-	0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x41, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x21, 0x0a,
-	0x05, 0x76, 0x65, 0x72, 0x62, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x73,
-	0x63, 0x6f, 0x74, 0x74, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x76, 0x65, 0x72, 0x62, 0x73,
-	0x12, 0x21, 0x0a, 0x05, 0x6e, 0x6f, 0x75, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x0b, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x6e, 0x6f,
-	0x75, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x52,
-	0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0b, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05,
-	0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x25, 0x0a, 0x06, 0x66, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x46, 0x6f,
-	0x6f, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x05,
-	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x73, 0x63,
-	0x6f, 0x74, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x2a, 0x88, 0x03, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x41, 0x52, 0x41, 0x4d, 0x45, 0x54, 0x45, 0x52, 0x10,
-	0x00, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x43, 0x41, 0x52, 0x52, 0x49, 0x45,
-	0x44, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x49, 0x4e, 0x5f, 0x52,
-	0x4f, 0x4f, 0x4d, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x50, 0x52,
-	0x45, 0x53, 0x45, 0x4e, 0x54, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x4c, 0x41, 0x59, 0x45,
-	0x52, 0x5f, 0x49, 0x4e, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x49,
-	0x54, 0x45, 0x4d, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x10,
-	0x05, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x43, 0x41,
-	0x52, 0x52, 0x49, 0x45, 0x44, 0x10, 0x06, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x4c, 0x41, 0x59, 0x45,
-	0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x07, 0x12,
-	0x0b, 0x0a, 0x07, 0x42, 0x49, 0x54, 0x5f, 0x53, 0x45, 0x54, 0x10, 0x08, 0x12, 0x0d, 0x0a, 0x09,
-	0x42, 0x49, 0x54, 0x5f, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x10, 0x09, 0x12, 0x17, 0x0a, 0x13, 0x49,
-	0x4e, 0x56, 0x45, 0x4e, 0x54, 0x4f, 0x52, 0x59, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x4d, 0x50,
-	0x54, 0x59, 0x10, 0x0a, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x4e, 0x56, 0x45, 0x4e, 0x54, 0x4f, 0x52,
-	0x59, 0x5f, 0x45, 0x4d, 0x50, 0x54, 0x59, 0x10, 0x0b, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x54, 0x45,
-	0x4d, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x50, 0x52, 0x45, 0x53, 0x45, 0x4e, 0x54, 0x10, 0x0c, 0x12,
-	0x10, 0x0a, 0x0c, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x49, 0x4e, 0x5f, 0x47, 0x41, 0x4d, 0x45, 0x10,
-	0x0d, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e,
-	0x5f, 0x47, 0x41, 0x4d, 0x45, 0x10, 0x0e, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4f, 0x55, 0x4e, 0x54,
-	0x45, 0x52, 0x5f, 0x4c, 0x45, 0x10, 0x0f, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4f, 0x55, 0x4e, 0x54,
-	0x45, 0x52, 0x5f, 0x47, 0x45, 0x10, 0x10, 0x12, 0x0e, 0x0a, 0x0a, 0x49, 0x54, 0x45, 0x4d, 0x5f,
-	0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x11, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x54, 0x45, 0x4d, 0x5f,
-	0x4e, 0x4f, 0x54, 0x5f, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x12, 0x12, 0x0e, 0x0a, 0x0a, 0x43,
-	0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x5f, 0x45, 0x51, 0x10, 0x13, 0x2a, 0xe5, 0x11, 0x0a, 0x0a,
-	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x4f,
-	0x54, 0x48, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41,
-	0x47, 0x45, 0x5f, 0x30, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47,
-	0x45, 0x5f, 0x31, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45,
-	0x5f, 0x32, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f,
-	0x33, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x34,
-	0x10, 0x05, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x10,
-	0x06, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x36, 0x10, 0x07,
-	0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x10, 0x08, 0x12,
-	0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x10, 0x09, 0x12, 0x0d,
-	0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x10, 0x0a, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x30, 0x10, 0x0b, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x31, 0x10, 0x0c, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x32, 0x10, 0x0d, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x33, 0x10, 0x0e, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x34, 0x10, 0x0f, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x35, 0x10, 0x10, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x36, 0x10, 0x11, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x37, 0x10, 0x12, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x38, 0x10, 0x13, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x39, 0x10, 0x14, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x30, 0x10, 0x15, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x31, 0x10, 0x16, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x32, 0x10, 0x17, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x33, 0x10, 0x18, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x34, 0x10, 0x19, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x35, 0x10, 0x1a, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x36, 0x10, 0x1b, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x37, 0x10, 0x1c, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x38, 0x10, 0x1d, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x39, 0x10, 0x1e, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x30, 0x10, 0x1f, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x31, 0x10, 0x20, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x32, 0x10, 0x21, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x33, 0x10, 0x22, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x34, 0x10, 0x23, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x35, 0x10, 0x24, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x36, 0x10, 0x25, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x37, 0x10, 0x26, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x38, 0x10, 0x27, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x39, 0x10, 0x28, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x30, 0x10, 0x29, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x31, 0x10, 0x2a, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x32, 0x10, 0x2b, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x33, 0x10, 0x2c, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x34, 0x10, 0x2d, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x35, 0x10, 0x2e, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x36, 0x10, 0x2f, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x37, 0x10, 0x30, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x38, 0x10, 0x31, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x39, 0x10, 0x32, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x30, 0x10, 0x33, 0x12, 0x0c, 0x0a,
-	0x08, 0x47, 0x45, 0x54, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x10, 0x34, 0x12, 0x0d, 0x0a, 0x09, 0x44,
-	0x52, 0x4f, 0x50, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x10, 0x35, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x4f,
-	0x56, 0x45, 0x5f, 0x50, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x10, 0x36, 0x12, 0x0f, 0x0a, 0x0b, 0x52,
-	0x45, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x10, 0x37, 0x12, 0x10, 0x0a, 0x0c,
-	0x53, 0x45, 0x54, 0x5f, 0x44, 0x41, 0x52, 0x4b, 0x4e, 0x45, 0x53, 0x53, 0x10, 0x38, 0x12, 0x12,
-	0x0a, 0x0e, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x5f, 0x44, 0x41, 0x52, 0x4b, 0x4e, 0x45, 0x53, 0x53,
-	0x10, 0x39, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x45, 0x54, 0x5f, 0x42, 0x49, 0x54, 0x10, 0x3a, 0x12,
-	0x10, 0x0a, 0x0c, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x32, 0x10,
-	0x3b, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x5f, 0x42, 0x49, 0x54, 0x10, 0x3c,
-	0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x41, 0x54, 0x48, 0x10, 0x3d, 0x12, 0x0c, 0x0a, 0x08, 0x50,
-	0x55, 0x54, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x10, 0x3e, 0x12, 0x0d, 0x0a, 0x09, 0x47, 0x41, 0x4d,
-	0x45, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x10, 0x3f, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x45, 0x53, 0x43,
-	0x52, 0x49, 0x42, 0x45, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x40, 0x12, 0x09, 0x0a, 0x05, 0x53,
-	0x43, 0x4f, 0x52, 0x45, 0x10, 0x41, 0x12, 0x0d, 0x0a, 0x09, 0x49, 0x4e, 0x56, 0x45, 0x4e, 0x54,
-	0x4f, 0x52, 0x59, 0x10, 0x42, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x45, 0x54, 0x5f, 0x42, 0x49, 0x54,
-	0x5f, 0x30, 0x10, 0x43, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x5f, 0x42, 0x49,
-	0x54, 0x5f, 0x30, 0x10, 0x44, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x45, 0x46, 0x49, 0x4c, 0x4c, 0x5f,
-	0x4c, 0x49, 0x47, 0x48, 0x54, 0x10, 0x45, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x4c, 0x45, 0x41, 0x52,
-	0x5f, 0x53, 0x43, 0x52, 0x45, 0x45, 0x4e, 0x10, 0x46, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x41, 0x56,
-	0x45, 0x5f, 0x47, 0x41, 0x4d, 0x45, 0x10, 0x47, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x57, 0x41, 0x50,
-	0x5f, 0x49, 0x54, 0x45, 0x4d, 0x53, 0x10, 0x48, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4e, 0x54,
-	0x49, 0x4e, 0x55, 0x45, 0x10, 0x49, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x41, 0x4b, 0x45, 0x5f, 0x49,
-	0x54, 0x45, 0x4d, 0x10, 0x4a, 0x12, 0x15, 0x0a, 0x11, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x49, 0x54,
-	0x45, 0x4d, 0x5f, 0x54, 0x4f, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x10, 0x4b, 0x12, 0x12, 0x0a, 0x0e,
-	0x44, 0x45, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x32, 0x10, 0x4c,
-	0x12, 0x15, 0x0a, 0x11, 0x44, 0x45, 0x43, 0x52, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x4f,
-	0x55, 0x4e, 0x54, 0x45, 0x52, 0x10, 0x4d, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x52, 0x49, 0x4e, 0x54,
-	0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x10, 0x4e, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x45,
-	0x54, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x10, 0x4f, 0x12, 0x11, 0x0a, 0x0d, 0x53,
-	0x57, 0x41, 0x50, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x50, 0x12, 0x12,
-	0x0a, 0x0e, 0x53, 0x45, 0x4c, 0x45, 0x43, 0x54, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52,
-	0x10, 0x51, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x44, 0x44, 0x5f, 0x54, 0x4f, 0x5f, 0x43, 0x4f, 0x55,
-	0x4e, 0x54, 0x45, 0x52, 0x10, 0x52, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x55, 0x42, 0x5f, 0x46, 0x52,
-	0x4f, 0x4d, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x10, 0x53, 0x12, 0x0d, 0x0a, 0x09,
-	0x45, 0x43, 0x48, 0x4f, 0x5f, 0x4e, 0x4f, 0x55, 0x4e, 0x10, 0x54, 0x12, 0x10, 0x0a, 0x0c, 0x45,
-	0x43, 0x48, 0x4f, 0x5f, 0x4e, 0x4f, 0x55, 0x4e, 0x5f, 0x43, 0x52, 0x10, 0x55, 0x12, 0x0b, 0x0a,
-	0x07, 0x45, 0x43, 0x48, 0x4f, 0x5f, 0x43, 0x52, 0x10, 0x56, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x57,
-	0x41, 0x50, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x10, 0x57, 0x12,
-	0x09, 0x0a, 0x05, 0x44, 0x45, 0x4c, 0x41, 0x59, 0x10, 0x58, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x52,
-	0x41, 0x57, 0x5f, 0x50, 0x49, 0x43, 0x54, 0x55, 0x52, 0x45, 0x10, 0x59, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x31, 0x10, 0x66, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x32, 0x10, 0x67, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x33, 0x10, 0x68, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x34, 0x10, 0x69, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x35, 0x10, 0x6a, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x36, 0x10, 0x6b, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x37, 0x10, 0x6c, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x38, 0x10, 0x6d, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x39, 0x10, 0x6e, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x36, 0x30, 0x10, 0x6f, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x36, 0x31, 0x10, 0x70, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x36, 0x32, 0x10, 0x71, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x36, 0x33, 0x10, 0x72, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x36, 0x34, 0x10, 0x73, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x36, 0x35, 0x10, 0x74, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x36, 0x36, 0x10, 0x75, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x36, 0x37, 0x10, 0x76, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x36, 0x38, 0x10, 0x77, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x36, 0x39, 0x10, 0x78, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x30, 0x10, 0x79, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x31, 0x10, 0x7a, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x32, 0x10, 0x7b, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x33, 0x10, 0x7c, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x34, 0x10, 0x7d, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x35, 0x10, 0x7e, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x36, 0x10, 0x7f, 0x12, 0x0f, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x37, 0x10, 0x80, 0x01, 0x12, 0x0f, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x38, 0x10, 0x81, 0x01, 0x12, 0x0f,
-	0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x39, 0x10, 0x82, 0x01, 0x12,
-	0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x30, 0x10, 0x83, 0x01,
-	0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x31, 0x10, 0x84,
-	0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x32, 0x10,
-	0x85, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x33,
-	0x10, 0x86, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38,
-	0x34, 0x10, 0x87, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f,
-	0x38, 0x35, 0x10, 0x88, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45,
-	0x5f, 0x38, 0x36, 0x10, 0x89, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47,
-	0x45, 0x5f, 0x38, 0x37, 0x10, 0x8a, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41,
-	0x47, 0x45, 0x5f, 0x38, 0x38, 0x10, 0x8b, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
-	0x41, 0x47, 0x45, 0x5f, 0x38, 0x39, 0x10, 0x8c, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
-	0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x30, 0x10, 0x8d, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45,
-	0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x31, 0x10, 0x8e, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d,
-	0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x32, 0x10, 0x8f, 0x01, 0x12, 0x0f, 0x0a, 0x0a,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x33, 0x10, 0x90, 0x01, 0x12, 0x0f, 0x0a,
-	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x34, 0x10, 0x91, 0x01, 0x12, 0x0f,
-	0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x35, 0x10, 0x92, 0x01, 0x12,
-	0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x36, 0x10, 0x93, 0x01,
-	0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x37, 0x10, 0x94,
-	0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x38, 0x10,
-	0x95, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x39,
-	0x10, 0x96, 0x01, 0x42, 0x0b, 0x5a, 0x09, 0x2e, 0x3b, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x70, 0x62,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	IsDark          bool     `protobuf:"varint,1,opt,name=is_dark,json=isDark,proto3" json:"is_dark,omitempty"`                           // true if it's too dark to see
+	RoomDescription string   `protobuf:"bytes,2,opt,name=room_description,json=roomDescription,proto3" json:"room_description,omitempty"` // the room description, made into a sentence
+	Exits           []string `protobuf:"bytes,3,rep,name=exits,proto3" json:"exits,omitempty"`                                            // ordered list of obvious exits
+	Items           []string `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`                                            // ordered list of items in the room
 }
 
-var (
-	file_scott_proto_rawDescOnce sync.Once
-	file_scott_proto_rawDescData = file_scott_proto_rawDesc
-)
+func (x *LookData) Reset() {
+	*x = LookData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_scott_proto_rawDescGZIP() []byte {
-	file_scott_proto_rawDescOnce.Do(func() {
-		file_scott_proto_rawDescData = protoimpl.X.CompressGZIP(file_scott_proto_rawDescData)
-	})
-	return file_scott_proto_rawDescData
+func (x *LookData) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_scott_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_scott_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
-var file_scott_proto_goTypes = []interface{}{
-	(ConditionType)(0), // 0: scott.ConditionType
-	(ActionType)(0),    // 1: scott.ActionType
-	(*Header)(nil),     // 2: scott.Header
-	(*Condition)(nil),  // 3: scott.Condition
-	(*Action)(nil),     // 4: scott.Action
-	(*Word)(nil),       // 5: scott.Word
-	(*Room)(nil),       // 6: scott.Room
-	(*Item)(nil),       // 7: scott.Item
-	(*Footer)(nil),     // 8: scott.Footer
-	(*State)(nil),      // 9: scott.State
-	(*Game)(nil),       // 10: scott.Game
+func (*LookData) ProtoMessage() {}
+
+func (x *LookData) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_scott_proto_depIdxs = []int32{
-	0,  // 0: scott.Condition.type:type_name -> scott.ConditionType
-	3,  // 1: scott.Action.conditions:type_name -> scott.Condition
-	1,  // 2: scott.Action.actions:type_name -> scott.ActionType
-	2,  // 3: scott.Game.header:type_name -> scott.Header
-	4,  // 4: scott.Game.actions:type_name -> scott.Action
-	5,  // 5: scott.Game.verbs:type_name -> scott.Word
-	5,  // 6: scott.Game.nouns:type_name -> scott.Word
-	6,  // 7: scott.Game.rooms:type_name -> scott.Room
-	7,  // 8: scott.Game.items:type_name -> scott.Item
-	8,  // 9: scott.Game.footer:type_name -> scott.Footer
-	9,  // 10: scott.Game.state:type_name -> scott.State
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+
+// Deprecated: Use LookData.ProtoReflect.Descriptor instead.
+func (*LookData) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{10}
 }
 
-func init() { file_scott_proto_init() }
-func file_scott_proto_init() {
-	if File_scott_proto != nil {
-		return
+func (x *LookData) GetIsDark() bool {
+	if x != nil {
+		return x.IsDark
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_scott_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Header); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return false
+}
+
+func (x *LookData) GetRoomDescription() string {
+	if x != nil {
+		return x.RoomDescription
+	}
+	return ""
+}
+
+func (x *LookData) GetExits() []string {
+	if x != nil {
+		return x.Exits
+	}
+	return nil
+}
+
+func (x *LookData) GetItems() []string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type EventType `protobuf:"varint,1,opt,name=type,proto3,enum=scott.EventType" json:"type,omitempty"` // the kind of event
+	Text string    `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`                       // the text to print, for EVENT_TEXT
+	Look *LookData `protobuf:"bytes,3,opt,name=look,proto3" json:"look,omitempty"`                       // the room description, for EVENT_LOOK
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_scott_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Condition); i {
-			case 0:
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Event) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TEXT
+}
+
+func (x *Event) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Event) GetLook() *LookData {
+	if x != nil {
+		return x.Look
+	}
+	return nil
+}
+
+// The ScottFree options in effect for a game, as given by game.Options.
+type Options struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	YouAre          bool `protobuf:"varint,1,opt,name=you_are,json=youAre,proto3" json:"you_are,omitempty"`                            // "You are" narration instead of "I'm"
+	ScottLight      bool `protobuf:"varint,2,opt,name=scott_light,json=scottLight,proto3" json:"scott_light,omitempty"`                // the original Scott Adams light messages
+	Trs80           bool `protobuf:"varint,3,opt,name=trs80,proto3" json:"trs80,omitempty"`                                            // the TRS-80 display style
+	PrehistoricLamp bool `protobuf:"varint,4,opt,name=prehistoric_lamp,json=prehistoricLamp,proto3" json:"prehistoric_lamp,omitempty"` // the light source is destroyed when it runs out
+	Debug           bool `protobuf:"varint,5,opt,name=debug,proto3" json:"debug,omitempty"`                                            // report the size of the game when it starts
+}
+
+func (x *Options) Reset() {
+	*x = Options{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Options) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Options) ProtoMessage() {}
+
+func (x *Options) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Options.ProtoReflect.Descriptor instead.
+func (*Options) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Options) GetYouAre() bool {
+	if x != nil {
+		return x.YouAre
+	}
+	return false
+}
+
+func (x *Options) GetScottLight() bool {
+	if x != nil {
+		return x.ScottLight
+	}
+	return false
+}
+
+func (x *Options) GetTrs80() bool {
+	if x != nil {
+		return x.Trs80
+	}
+	return false
+}
+
+func (x *Options) GetPrehistoricLamp() bool {
+	if x != nil {
+		return x.PrehistoricLamp
+	}
+	return false
+}
+
+func (x *Options) GetDebug() bool {
+	if x != nil {
+		return x.Debug
+	}
+	return false
+}
+
+// A single turn of a recorded session.
+type Turn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Input     string   `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`                           // the line typed by the player ("" for the start of the game)
+	ElapsedMs int64    `protobuf:"varint,2,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"` // when the line was entered, in milliseconds since the recording began
+	Events    []*Event `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`                         // the output produced by the turn
+}
+
+func (x *Turn) Reset() {
+	*x = Turn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Turn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Turn) ProtoMessage() {}
+
+func (x *Turn) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Turn.ProtoReflect.Descriptor instead.
+func (*Turn) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Turn) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *Turn) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *Turn) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// A recorded session, with everything needed to play it back exactly.
+type Recording struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameFile   string   `protobuf:"bytes,1,opt,name=game_file,json=gameFile,proto3" json:"game_file,omitempty"`       // the path of the game file, for reference
+	GameSha256 []byte   `protobuf:"bytes,2,opt,name=game_sha256,json=gameSha256,proto3" json:"game_sha256,omitempty"` // the SHA-256 hash of the game file
+	Seed       int64    `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`                              // the seed for the random events
+	Options    *Options `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`                         // the options in effect
+	Started    int64    `protobuf:"varint,5,opt,name=started,proto3" json:"started,omitempty"`                        // when the recording began, in Unix milliseconds
+	Turns      []*Turn  `protobuf:"bytes,6,rep,name=turns,proto3" json:"turns,omitempty"`                             // the turns, starting with the start of the game
+	Wizard     bool     `protobuf:"varint,7,opt,name=wizard,proto3" json:"wizard,omitempty"`                          // if set, input starting with "#" was taken as wizard commands
+}
+
+func (x *Recording) Reset() {
+	*x = Recording{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Recording) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recording) ProtoMessage() {}
+
+func (x *Recording) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recording.ProtoReflect.Descriptor instead.
+func (*Recording) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Recording) GetGameFile() string {
+	if x != nil {
+		return x.GameFile
+	}
+	return ""
+}
+
+func (x *Recording) GetGameSha256() []byte {
+	if x != nil {
+		return x.GameSha256
+	}
+	return nil
+}
+
+func (x *Recording) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *Recording) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Recording) GetStarted() int64 {
+	if x != nil {
+		return x.Started
+	}
+	return 0
+}
+
+func (x *Recording) GetTurns() []*Turn {
+	if x != nil {
+		return x.Turns
+	}
+	return nil
+}
+
+func (x *Recording) GetWizard() bool {
+	if x != nil {
+		return x.Wizard
+	}
+	return false
+}
+
+type NewSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Game string `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`  // name of the game file, within the server's game directory
+	Seed int64  `protobuf:"varint,2,opt,name=seed,proto3" json:"seed,omitempty"` // seed for the random events, or 0 for a random seed
+}
+
+func (x *NewSessionRequest) Reset() {
+	*x = NewSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewSessionRequest) ProtoMessage() {}
+
+func (x *NewSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewSessionRequest.ProtoReflect.Descriptor instead.
+func (*NewSessionRequest) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *NewSessionRequest) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *NewSessionRequest) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+type NewSessionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // identifies the session in later requests
+	Events    []*Event `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`                        // the output from starting the game
+}
+
+func (x *NewSessionResponse) Reset() {
+	*x = NewSessionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewSessionResponse) ProtoMessage() {}
+
+func (x *NewSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewSessionResponse.ProtoReflect.Descriptor instead.
+func (*NewSessionResponse) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *NewSessionResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *NewSessionResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type CommandRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // the session
+	Input     string `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`                          // a line of input from the player
+}
+
+func (x *CommandRequest) Reset() {
+	*x = CommandRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandRequest) ProtoMessage() {}
+
+func (x *CommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandRequest.ProtoReflect.Descriptor instead.
+func (*CommandRequest) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CommandRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *CommandRequest) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+type CommandResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events   []*Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`                      // the output from the command
+	GameOver bool     `protobuf:"varint,2,opt,name=game_over,json=gameOver,proto3" json:"game_over,omitempty"` // set once the game has ended
+}
+
+func (x *CommandResponse) Reset() {
+	*x = CommandResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandResponse) ProtoMessage() {}
+
+func (x *CommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandResponse.ProtoReflect.Descriptor instead.
+func (*CommandResponse) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CommandResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *CommandResponse) GetGameOver() bool {
+	if x != nil {
+		return x.GameOver
+	}
+	return false
+}
+
+type LookRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // the session
+}
+
+func (x *LookRequest) Reset() {
+	*x = LookRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookRequest) ProtoMessage() {}
+
+func (x *LookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookRequest.ProtoReflect.Descriptor instead.
+func (*LookRequest) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LookRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type LookResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Look *LookData `protobuf:"bytes,1,opt,name=look,proto3" json:"look,omitempty"` // the description of the current room
+}
+
+func (x *LookResponse) Reset() {
+	*x = LookResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookResponse) ProtoMessage() {}
+
+func (x *LookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookResponse.ProtoReflect.Descriptor instead.
+func (*LookResponse) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LookResponse) GetLook() *LookData {
+	if x != nil {
+		return x.Look
+	}
+	return nil
+}
+
+type SaveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // the session
+}
+
+func (x *SaveRequest) Reset() {
+	*x = SaveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveRequest) ProtoMessage() {}
+
+func (x *SaveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveRequest.ProtoReflect.Descriptor instead.
+func (*SaveRequest) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SaveRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type SaveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State *State `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"` // the saved state, to be passed to Restore later
+}
+
+func (x *SaveResponse) Reset() {
+	*x = SaveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveResponse) ProtoMessage() {}
+
+func (x *SaveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveResponse.ProtoReflect.Descriptor instead.
+func (*SaveResponse) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SaveResponse) GetState() *State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type RestoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // the session
+	State     *State `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`                          // a state returned by Save for the same game
+}
+
+func (x *RestoreRequest) Reset() {
+	*x = RestoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreRequest) ProtoMessage() {}
+
+func (x *RestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreRequest.ProtoReflect.Descriptor instead.
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RestoreRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *RestoreRequest) GetState() *State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type RestoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Look *LookData `protobuf:"bytes,1,opt,name=look,proto3" json:"look,omitempty"` // the description of the restored room
+}
+
+func (x *RestoreResponse) Reset() {
+	*x = RestoreResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreResponse) ProtoMessage() {}
+
+func (x *RestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreResponse.ProtoReflect.Descriptor instead.
+func (*RestoreResponse) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RestoreResponse) GetLook() *LookData {
+	if x != nil {
+		return x.Look
+	}
+	return nil
+}
+
+type CloseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // the session
+}
+
+func (x *CloseRequest) Reset() {
+	*x = CloseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseRequest) ProtoMessage() {}
+
+func (x *CloseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseRequest.ProtoReflect.Descriptor instead.
+func (*CloseRequest) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CloseRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type CloseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CloseResponse) Reset() {
+	*x = CloseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scott_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseResponse) ProtoMessage() {}
+
+func (x *CloseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scott_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseResponse.ProtoReflect.Descriptor instead.
+func (*CloseResponse) Descriptor() ([]byte, []int) {
+	return file_scott_proto_rawDescGZIP(), []int{26}
+}
+
+var File_scott_proto protoreflect.FileDescriptor
+
+var file_scott_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x73,
+	0x63, 0x6f, 0x74, 0x74, 0x22, 0x9b, 0x03, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x30, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x30, 0x12, 0x1b, 0x0a, 0x09, 0x6e,
+	0x75, 0x6d, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x6e, 0x75, 0x6d, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x5f,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6e,
+	0x75, 0x6d, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d,
+	0x5f, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x75,
+	0x6d, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x6f,
+	0x6f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x52, 0x6f,
+	0x6f, 0x6d, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x6e, 0x76, 0x65, 0x6e,
+	0x74, 0x6f, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x49,
+	0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x23, 0x0a,
+	0x0d, 0x6e, 0x75, 0x6d, 0x5f, 0x74, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6e, 0x75, 0x6d, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72,
+	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x64, 0x4c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6c, 0x69, 0x67,
+	0x68, 0x74, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x75,
+	0x6d, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x74, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x74, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x52, 0x6f,
+	0x6f, 0x6d, 0x22, 0x4b, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e,
+	0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0xbf, 0x01, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x65,
+	0x72, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x76, 0x65, 0x72, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x75,
+	0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6e,
+	0x6f, 0x75, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x30, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x73,
+	0x63, 0x6f, 0x74, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
+	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x07, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x73, 0x63,
+	0x6f, 0x74, 0x74, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x22, 0x34, 0x0a, 0x04, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x79, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x73, 0x79, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x22, 0x58, 0x0a, 0x04, 0x52, 0x6f, 0x6f, 0x6d, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x78, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x65, 0x78, 0x69, 0x74,
+	0x73, 0x22, 0x81, 0x01, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x74,
+	0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69,
+	0x73, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x75, 0x74,
+	0x6f, 0x67, 0x72, 0x61, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x74,
+	0x6f, 0x67, 0x72, 0x61, 0x62, 0x22, 0x56, 0x0a, 0x06, 0x46, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x76,
+	0x65, 0x6e, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x61, 0x64,
+	0x76, 0x65, 0x6e, 0x74, 0x75, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x67, 0x69, 0x63,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6d, 0x61, 0x67, 0x69, 0x63, 0x22, 0x92, 0x02,
+	0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x08, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x61, 0x76, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x61, 0x76, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x61, 0x76, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x61, 0x76, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x69,
+	0x74, 0x65, 0x6d, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x0d, 0x69, 0x74, 0x65, 0x6d, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x42, 0x0a, 0x06, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x38, 0x0a, 0x19,
+	0x69, 0x74, 0x65, 0x6d, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x6e,
+	0x5f, 0x6f, 0x77, 0x6e, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x15, 0x69, 0x74, 0x65, 0x6d, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x6e, 0x4f,
+	0x77, 0x6e, 0x4c, 0x69, 0x6e, 0x65, 0x22, 0xd9, 0x02, 0x0a, 0x04, 0x47, 0x61, 0x6d, 0x65, 0x12,
+	0x25, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x21, 0x0a, 0x05, 0x76, 0x65, 0x72, 0x62, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x76, 0x65, 0x72,
+	0x62, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x6e, 0x6f, 0x75, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x05,
+	0x6e, 0x6f, 0x75, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x52, 0x6f, 0x6f,
+	0x6d, 0x52, 0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x49, 0x74, 0x65, 0x6d,
+	0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x25, 0x0a, 0x06, 0x66, 0x6f, 0x6f, 0x74, 0x65,
+	0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e,
+	0x46, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x12, 0x25,
+	0x0a, 0x06, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x52, 0x06, 0x6c,
+	0x61, 0x79, 0x6f, 0x75, 0x74, 0x4a, 0x04, 0x08, 0x09, 0x10, 0x0a, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x22, 0x7a, 0x0a, 0x08, 0x4c, 0x6f, 0x6f, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x17,
+	0x0a, 0x07, 0x69, 0x73, 0x5f, 0x64, 0x61, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x69, 0x73, 0x44, 0x61, 0x72, 0x6b, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x6f, 0x6f, 0x6d, 0x5f,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x72, 0x6f, 0x6f, 0x6d, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x78, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x78, 0x69, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x66,
+	0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x23, 0x0a, 0x04, 0x6c, 0x6f, 0x6f, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x04, 0x6c, 0x6f, 0x6f, 0x6b, 0x22, 0x9a, 0x01, 0x0a, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x79, 0x6f, 0x75, 0x5f, 0x61, 0x72, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x79, 0x6f, 0x75, 0x41, 0x72, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x63, 0x6f, 0x74, 0x74, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x72, 0x73, 0x38, 0x30, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x74, 0x72, 0x73,
+	0x38, 0x30, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69,
+	0x63, 0x5f, 0x6c, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x70, 0x72,
+	0x65, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x4c, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a,
+	0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x64, 0x65,
+	0x62, 0x75, 0x67, 0x22, 0x61, 0x0a, 0x04, 0x54, 0x75, 0x72, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x4d, 0x73,
+	0x12, 0x24, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xdc, 0x01, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x68, 0x61, 0x32,
+	0x35, 0x36, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x05, 0x74, 0x75,
+	0x72, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x73, 0x63, 0x6f, 0x74,
+	0x74, 0x2e, 0x54, 0x75, 0x72, 0x6e, 0x52, 0x05, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x77,
+	0x69, 0x7a, 0x61, 0x72, 0x64, 0x22, 0x3b, 0x0a, 0x11, 0x4e, 0x65, 0x77, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65,
+	0x65, 0x64, 0x22, 0x59, 0x0a, 0x12, 0x4e, 0x65, 0x77, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x45, 0x0a,
+	0x0e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x22, 0x54, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x22, 0x2c, 0x0a, 0x0b, 0x4c, 0x6f,
+	0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x33, 0x0a, 0x0c, 0x4c, 0x6f, 0x6f, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x6c, 0x6f, 0x6f, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x4c,
+	0x6f, 0x6f, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x6c, 0x6f, 0x6f, 0x6b, 0x22, 0x2c, 0x0a,
+	0x0b, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x0c, 0x53,
+	0x61, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x73, 0x63, 0x6f,
+	0x74, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22,
+	0x53, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x22, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x22, 0x36, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x6c, 0x6f, 0x6f, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x4c, 0x6f,
+	0x6f, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x6c, 0x6f, 0x6f, 0x6b, 0x22, 0x2d, 0x0a, 0x0c,
+	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x0f, 0x0a, 0x0d, 0x43,
+	0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x88, 0x03, 0x0a,
+	0x0d, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0d,
+	0x0a, 0x09, 0x50, 0x41, 0x52, 0x41, 0x4d, 0x45, 0x54, 0x45, 0x52, 0x10, 0x00, 0x12, 0x10, 0x0a,
+	0x0c, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x43, 0x41, 0x52, 0x52, 0x49, 0x45, 0x44, 0x10, 0x01, 0x12,
+	0x10, 0x0a, 0x0c, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x49, 0x4e, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x10,
+	0x02, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x50, 0x52, 0x45, 0x53, 0x45, 0x4e,
+	0x54, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x5f, 0x49, 0x4e,
+	0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x54, 0x45, 0x4d, 0x5f,
+	0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x05, 0x12, 0x14, 0x0a,
+	0x10, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x43, 0x41, 0x52, 0x52, 0x49, 0x45,
+	0x44, 0x10, 0x06, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x5f, 0x4e, 0x4f,
+	0x54, 0x5f, 0x49, 0x4e, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x07, 0x12, 0x0b, 0x0a, 0x07, 0x42,
+	0x49, 0x54, 0x5f, 0x53, 0x45, 0x54, 0x10, 0x08, 0x12, 0x0d, 0x0a, 0x09, 0x42, 0x49, 0x54, 0x5f,
+	0x43, 0x4c, 0x45, 0x41, 0x52, 0x10, 0x09, 0x12, 0x17, 0x0a, 0x13, 0x49, 0x4e, 0x56, 0x45, 0x4e,
+	0x54, 0x4f, 0x52, 0x59, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x4d, 0x50, 0x54, 0x59, 0x10, 0x0a,
+	0x12, 0x13, 0x0a, 0x0f, 0x49, 0x4e, 0x56, 0x45, 0x4e, 0x54, 0x4f, 0x52, 0x59, 0x5f, 0x45, 0x4d,
+	0x50, 0x54, 0x59, 0x10, 0x0b, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x4e, 0x4f,
+	0x54, 0x5f, 0x50, 0x52, 0x45, 0x53, 0x45, 0x4e, 0x54, 0x10, 0x0c, 0x12, 0x10, 0x0a, 0x0c, 0x49,
+	0x54, 0x45, 0x4d, 0x5f, 0x49, 0x4e, 0x5f, 0x47, 0x41, 0x4d, 0x45, 0x10, 0x0d, 0x12, 0x14, 0x0a,
+	0x10, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x5f, 0x47, 0x41, 0x4d,
+	0x45, 0x10, 0x0e, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x5f, 0x4c,
+	0x45, 0x10, 0x0f, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x5f, 0x47,
+	0x45, 0x10, 0x10, 0x12, 0x0e, 0x0a, 0x0a, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x4d, 0x4f, 0x56, 0x45,
+	0x44, 0x10, 0x11, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x4e, 0x4f, 0x54, 0x5f,
+	0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x12, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4f, 0x55, 0x4e, 0x54,
+	0x45, 0x52, 0x5f, 0x45, 0x51, 0x10, 0x13, 0x2a, 0xe5, 0x11, 0x0a, 0x0a, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x4f, 0x54, 0x48, 0x49, 0x4e,
+	0x47, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x30,
+	0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x10,
+	0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x10, 0x03,
+	0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x10, 0x04, 0x12,
+	0x0d, 0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x10, 0x05, 0x12, 0x0d,
+	0x0a, 0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x10, 0x06, 0x12, 0x0d, 0x0a,
+	0x09, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x36, 0x10, 0x07, 0x12, 0x0d, 0x0a, 0x09,
+	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x10, 0x08, 0x12, 0x0d, 0x0a, 0x09, 0x4d,
+	0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x10, 0x09, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x45,
+	0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x10, 0x0a, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x30, 0x10, 0x0b, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x31, 0x10, 0x0c, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x32, 0x10, 0x0d, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x33, 0x10, 0x0e, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x34, 0x10, 0x0f, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x35, 0x10, 0x10, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x36, 0x10, 0x11, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x37, 0x10, 0x12, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x38, 0x10, 0x13, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x31, 0x39, 0x10, 0x14, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x30, 0x10, 0x15, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x31, 0x10, 0x16, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x32, 0x10, 0x17, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x33, 0x10, 0x18, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x34, 0x10, 0x19, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x35, 0x10, 0x1a, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x36, 0x10, 0x1b, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x37, 0x10, 0x1c, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x38, 0x10, 0x1d, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x32, 0x39, 0x10, 0x1e, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x30, 0x10, 0x1f, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x31, 0x10, 0x20, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x32, 0x10, 0x21, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x33, 0x10, 0x22, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x34, 0x10, 0x23, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x35, 0x10, 0x24, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x36, 0x10, 0x25, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x37, 0x10, 0x26, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x38, 0x10, 0x27, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x33, 0x39, 0x10, 0x28, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x30, 0x10, 0x29, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x31, 0x10, 0x2a, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x32, 0x10, 0x2b, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x33, 0x10, 0x2c, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x34, 0x10, 0x2d, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x35, 0x10, 0x2e, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x36, 0x10, 0x2f, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x37, 0x10, 0x30, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x38, 0x10, 0x31, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x34, 0x39, 0x10, 0x32, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x35, 0x30, 0x10, 0x33, 0x12, 0x0c, 0x0a, 0x08, 0x47, 0x45, 0x54,
+	0x5f, 0x49, 0x54, 0x45, 0x4d, 0x10, 0x34, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x52, 0x4f, 0x50, 0x5f,
+	0x49, 0x54, 0x45, 0x4d, 0x10, 0x35, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x50,
+	0x4c, 0x41, 0x59, 0x45, 0x52, 0x10, 0x36, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x45, 0x4d, 0x4f, 0x56,
+	0x45, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x10, 0x37, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x45, 0x54, 0x5f,
+	0x44, 0x41, 0x52, 0x4b, 0x4e, 0x45, 0x53, 0x53, 0x10, 0x38, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4c,
+	0x45, 0x41, 0x52, 0x5f, 0x44, 0x41, 0x52, 0x4b, 0x4e, 0x45, 0x53, 0x53, 0x10, 0x39, 0x12, 0x0b,
+	0x0a, 0x07, 0x53, 0x45, 0x54, 0x5f, 0x42, 0x49, 0x54, 0x10, 0x3a, 0x12, 0x10, 0x0a, 0x0c, 0x52,
+	0x45, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x32, 0x10, 0x3b, 0x12, 0x0d, 0x0a,
+	0x09, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x5f, 0x42, 0x49, 0x54, 0x10, 0x3c, 0x12, 0x09, 0x0a, 0x05,
+	0x44, 0x45, 0x41, 0x54, 0x48, 0x10, 0x3d, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x55, 0x54, 0x5f, 0x49,
+	0x54, 0x45, 0x4d, 0x10, 0x3e, 0x12, 0x0d, 0x0a, 0x09, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x4f, 0x56,
+	0x45, 0x52, 0x10, 0x3f, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x45, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45,
+	0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x40, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x43, 0x4f, 0x52, 0x45,
+	0x10, 0x41, 0x12, 0x0d, 0x0a, 0x09, 0x49, 0x4e, 0x56, 0x45, 0x4e, 0x54, 0x4f, 0x52, 0x59, 0x10,
+	0x42, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x45, 0x54, 0x5f, 0x42, 0x49, 0x54, 0x5f, 0x30, 0x10, 0x43,
+	0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x5f, 0x42, 0x49, 0x54, 0x5f, 0x30, 0x10,
+	0x44, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x45, 0x46, 0x49, 0x4c, 0x4c, 0x5f, 0x4c, 0x49, 0x47, 0x48,
+	0x54, 0x10, 0x45, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x5f, 0x53, 0x43, 0x52,
+	0x45, 0x45, 0x4e, 0x10, 0x46, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x41, 0x56, 0x45, 0x5f, 0x47, 0x41,
+	0x4d, 0x45, 0x10, 0x47, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x49, 0x54, 0x45,
+	0x4d, 0x53, 0x10, 0x48, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4e, 0x54, 0x49, 0x4e, 0x55, 0x45,
+	0x10, 0x49, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x41, 0x4b, 0x45, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x10,
+	0x4a, 0x12, 0x15, 0x0a, 0x11, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x54,
+	0x4f, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x10, 0x4b, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x45, 0x53, 0x43,
+	0x52, 0x49, 0x42, 0x45, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x32, 0x10, 0x4c, 0x12, 0x15, 0x0a, 0x11,
+	0x44, 0x45, 0x43, 0x52, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45,
+	0x52, 0x10, 0x4d, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x52, 0x49, 0x4e, 0x54, 0x5f, 0x43, 0x4f, 0x55,
+	0x4e, 0x54, 0x45, 0x52, 0x10, 0x4e, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x45, 0x54, 0x5f, 0x43, 0x4f,
+	0x55, 0x4e, 0x54, 0x45, 0x52, 0x10, 0x4f, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x57, 0x41, 0x50, 0x5f,
+	0x4c, 0x4f, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x50, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45,
+	0x4c, 0x45, 0x43, 0x54, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x10, 0x51, 0x12, 0x12,
+	0x0a, 0x0e, 0x41, 0x44, 0x44, 0x5f, 0x54, 0x4f, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52,
+	0x10, 0x52, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x55, 0x42, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x43,
+	0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x10, 0x53, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x43, 0x48, 0x4f,
+	0x5f, 0x4e, 0x4f, 0x55, 0x4e, 0x10, 0x54, 0x12, 0x10, 0x0a, 0x0c, 0x45, 0x43, 0x48, 0x4f, 0x5f,
+	0x4e, 0x4f, 0x55, 0x4e, 0x5f, 0x43, 0x52, 0x10, 0x55, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x43, 0x48,
+	0x4f, 0x5f, 0x43, 0x52, 0x10, 0x56, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x4c,
+	0x4f, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x10, 0x57, 0x12, 0x09, 0x0a, 0x05, 0x44,
+	0x45, 0x4c, 0x41, 0x59, 0x10, 0x58, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x52, 0x41, 0x57, 0x5f, 0x50,
+	0x49, 0x43, 0x54, 0x55, 0x52, 0x45, 0x10, 0x59, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x35, 0x31, 0x10, 0x66, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x35, 0x32, 0x10, 0x67, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x35, 0x33, 0x10, 0x68, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x35, 0x34, 0x10, 0x69, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x35, 0x35, 0x10, 0x6a, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x35, 0x36, 0x10, 0x6b, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x35, 0x37, 0x10, 0x6c, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x35, 0x38, 0x10, 0x6d, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x35, 0x39, 0x10, 0x6e, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x36, 0x30, 0x10, 0x6f, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x36, 0x31, 0x10, 0x70, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x36, 0x32, 0x10, 0x71, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x36, 0x33, 0x10, 0x72, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x36, 0x34, 0x10, 0x73, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x36, 0x35, 0x10, 0x74, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x36, 0x36, 0x10, 0x75, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x36, 0x37, 0x10, 0x76, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x36, 0x38, 0x10, 0x77, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x36, 0x39, 0x10, 0x78, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x37, 0x30, 0x10, 0x79, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x37, 0x31, 0x10, 0x7a, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x37, 0x32, 0x10, 0x7b, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x37, 0x33, 0x10, 0x7c, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x37, 0x34, 0x10, 0x7d, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x37, 0x35, 0x10, 0x7e, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x37, 0x36, 0x10, 0x7f, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x37, 0x37, 0x10, 0x80, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x38, 0x10, 0x81, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45,
+	0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x37, 0x39, 0x10, 0x82, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d,
+	0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x30, 0x10, 0x83, 0x01, 0x12, 0x0f, 0x0a, 0x0a,
+	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x31, 0x10, 0x84, 0x01, 0x12, 0x0f, 0x0a,
+	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x32, 0x10, 0x85, 0x01, 0x12, 0x0f,
+	0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x33, 0x10, 0x86, 0x01, 0x12,
+	0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x34, 0x10, 0x87, 0x01,
+	0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x35, 0x10, 0x88,
+	0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x36, 0x10,
+	0x89, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38, 0x37,
+	0x10, 0x8a, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x38,
+	0x38, 0x10, 0x8b, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f,
+	0x38, 0x39, 0x10, 0x8c, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45,
+	0x5f, 0x39, 0x30, 0x10, 0x8d, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47,
+	0x45, 0x5f, 0x39, 0x31, 0x10, 0x8e, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41,
+	0x47, 0x45, 0x5f, 0x39, 0x32, 0x10, 0x8f, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x39, 0x33, 0x10, 0x90, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45, 0x53,
+	0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x34, 0x10, 0x91, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d, 0x45,
+	0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x35, 0x10, 0x92, 0x01, 0x12, 0x0f, 0x0a, 0x0a, 0x4d,
+	0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x36, 0x10, 0x93, 0x01, 0x12, 0x0f, 0x0a, 0x0a,
+	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x37, 0x10, 0x94, 0x01, 0x12, 0x0f, 0x0a,
+	0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x38, 0x10, 0x95, 0x01, 0x12, 0x0f,
+	0x0a, 0x0a, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x39, 0x39, 0x10, 0x96, 0x01, 0x2a,
+	0x79, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x0a,
+	0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a,
+	0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4c, 0x4f, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12,
+	0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x5f, 0x53, 0x43, 0x52, 0x45,
+	0x45, 0x4e, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x44, 0x45,
+	0x4c, 0x41, 0x59, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x53,
+	0x41, 0x56, 0x45, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x47,
+	0x41, 0x4d, 0x45, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x10, 0x05, 0x32, 0xdb, 0x02, 0x0a, 0x0c, 0x53,
+	0x63, 0x6f, 0x74, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x4e,
+	0x65, 0x77, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x2e, 0x73, 0x63, 0x6f, 0x74,
+	0x74, 0x2e, 0x4e, 0x65, 0x77, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x4e, 0x65, 0x77, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38,
+	0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x15, 0x2e, 0x73, 0x63, 0x6f, 0x74,
+	0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x4c, 0x6f, 0x6f, 0x6b,
+	0x12, 0x12, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x4c, 0x6f, 0x6f,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x53, 0x61, 0x76,
+	0x65, 0x12, 0x12, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x53, 0x61,
+	0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x15, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x73,
+	0x63, 0x6f, 0x74, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x13, 0x2e,
+	0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x73, 0x63, 0x6f, 0x74, 0x74, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0b, 0x5a, 0x09, 0x2e, 0x3b, 0x73, 0x63,
+	0x6f, 0x74, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_scott_proto_rawDescOnce sync.Once
+	file_scott_proto_rawDescData = file_scott_proto_rawDesc
+)
+
+func file_scott_proto_rawDescGZIP() []byte {
+	file_scott_proto_rawDescOnce.Do(func() {
+		file_scott_proto_rawDescData = protoimpl.X.CompressGZIP(file_scott_proto_rawDescData)
+	})
+	return file_scott_proto_rawDescData
+}
+
+var file_scott_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_scott_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_scott_proto_goTypes = []interface{}{
+	(ConditionType)(0),         // 0: scott.ConditionType
+	(ActionType)(0),            // 1: scott.ActionType
+	(EventType)(0),             // 2: scott.EventType
+	(*Header)(nil),             // 3: scott.Header
+	(*Condition)(nil),          // 4: scott.Condition
+	(*Action)(nil),             // 5: scott.Action
+	(*Word)(nil),               // 6: scott.Word
+	(*Room)(nil),               // 7: scott.Room
+	(*Item)(nil),               // 8: scott.Item
+	(*Footer)(nil),             // 9: scott.Footer
+	(*State)(nil),              // 10: scott.State
+	(*Layout)(nil),             // 11: scott.Layout
+	(*Game)(nil),               // 12: scott.Game
+	(*LookData)(nil),           // 13: scott.LookData
+	(*Event)(nil),              // 14: scott.Event
+	(*Options)(nil),            // 15: scott.Options
+	(*Turn)(nil),               // 16: scott.Turn
+	(*Recording)(nil),          // 17: scott.Recording
+	(*NewSessionRequest)(nil),  // 18: scott.NewSessionRequest
+	(*NewSessionResponse)(nil), // 19: scott.NewSessionResponse
+	(*CommandRequest)(nil),     // 20: scott.CommandRequest
+	(*CommandResponse)(nil),    // 21: scott.CommandResponse
+	(*LookRequest)(nil),        // 22: scott.LookRequest
+	(*LookResponse)(nil),       // 23: scott.LookResponse
+	(*SaveRequest)(nil),        // 24: scott.SaveRequest
+	(*SaveResponse)(nil),       // 25: scott.SaveResponse
+	(*RestoreRequest)(nil),     // 26: scott.RestoreRequest
+	(*RestoreResponse)(nil),    // 27: scott.RestoreResponse
+	(*CloseRequest)(nil),       // 28: scott.CloseRequest
+	(*CloseResponse)(nil),      // 29: scott.CloseResponse
+}
+var file_scott_proto_depIdxs = []int32{
+	0,  // 0: scott.Condition.type:type_name -> scott.ConditionType
+	4,  // 1: scott.Action.conditions:type_name -> scott.Condition
+	1,  // 2: scott.Action.actions:type_name -> scott.ActionType
+	3,  // 3: scott.Game.header:type_name -> scott.Header
+	5,  // 4: scott.Game.actions:type_name -> scott.Action
+	6,  // 5: scott.Game.verbs:type_name -> scott.Word
+	6,  // 6: scott.Game.nouns:type_name -> scott.Word
+	7,  // 7: scott.Game.rooms:type_name -> scott.Room
+	8,  // 8: scott.Game.items:type_name -> scott.Item
+	9,  // 9: scott.Game.footer:type_name -> scott.Footer
+	11, // 10: scott.Game.layout:type_name -> scott.Layout
+	2,  // 11: scott.Event.type:type_name -> scott.EventType
+	13, // 12: scott.Event.look:type_name -> scott.LookData
+	14, // 13: scott.Turn.events:type_name -> scott.Event
+	15, // 14: scott.Recording.options:type_name -> scott.Options
+	16, // 15: scott.Recording.turns:type_name -> scott.Turn
+	14, // 16: scott.NewSessionResponse.events:type_name -> scott.Event
+	14, // 17: scott.CommandResponse.events:type_name -> scott.Event
+	13, // 18: scott.LookResponse.look:type_name -> scott.LookData
+	10, // 19: scott.SaveResponse.state:type_name -> scott.State
+	10, // 20: scott.RestoreRequest.state:type_name -> scott.State
+	13, // 21: scott.RestoreResponse.look:type_name -> scott.LookData
+	18, // 22: scott.ScottService.NewSession:input_type -> scott.NewSessionRequest
+	20, // 23: scott.ScottService.Command:input_type -> scott.CommandRequest
+	22, // 24: scott.ScottService.Look:input_type -> scott.LookRequest
+	24, // 25: scott.ScottService.Save:input_type -> scott.SaveRequest
+	26, // 26: scott.ScottService.Restore:input_type -> scott.RestoreRequest
+	28, // 27: scott.ScottService.Close:input_type -> scott.CloseRequest
+	19, // 28: scott.ScottService.NewSession:output_type -> scott.NewSessionResponse
+	21, // 29: scott.ScottService.Command:output_type -> scott.CommandResponse
+	23, // 30: scott.ScottService.Look:output_type -> scott.LookResponse
+	25, // 31: scott.ScottService.Save:output_type -> scott.SaveResponse
+	27, // 32: scott.ScottService.Restore:output_type -> scott.RestoreResponse
+	29, // 33: scott.ScottService.Close:output_type -> scott.CloseResponse
+	28, // [28:34] is the sub-list for method output_type
+	22, // [22:28] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_scott_proto_init() }
+func file_scott_proto_init() {
+	if File_scott_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_scott_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Header); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Condition); i {
+			case 0:
 				return &v.state
 			case 1:
 				return &v.sizeCache
@@ -1702,6 +2998,18 @@ func file_scott_proto_init() {
 			}
 		}
 		file_scott_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Layout); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Game); i {
 			case 0:
 				return &v.state
@@ -1713,16 +3021,220 @@ func file_scott_proto_init() {
 				return nil
 			}
 		}
+		file_scott_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Options); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Turn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Recording); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewSessionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scott_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_scott_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   9,
+			NumEnums:      3,
+			NumMessages:   27,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_scott_proto_goTypes,
 		DependencyIndexes: file_scott_proto_depIdxs,
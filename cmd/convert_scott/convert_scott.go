@@ -22,7 +22,7 @@ func main() {
 	flag.Parse()
 	g := game.MustLoadFromFile(*inPath)
 
-	wire, err := proto.Marshal(g.Initial)
+	wire, err := proto.Marshal(g.DB.Game)
 	if err != nil {
 		log.Fatalf("Could not marshal proto: %v", err)
 	}
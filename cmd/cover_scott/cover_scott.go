@@ -0,0 +1,156 @@
+// cover_scott is a utility for measuring how well a set of walkthroughs
+// exercises a Scott Adams adventure file in the TRS-80 format supported by the
+// ScottFree interpreter.  Each script given as an argument (a text file with
+// one command per line) is played through from the start of the game while the
+// engine records which actions are tried and performed, and how their
+// conditions turn out.  The coverage of all the scripts is merged, along with
+// any earlier results given with -merge, and the whole action table is printed
+// with the counts for each action and condition.  Actions that were never
+// performed are marked with ">>".
+//
+// In the scripts, blank lines and lines starting with "#" are ignored.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/decompile"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+var (
+	gamePath  = flag.String("game", "", "Path to the game file in ScottFree (TRS-80) format.")
+	seed      = flag.Int64("seed", 1, "Seed for the random events in the game.")
+	merge     = flag.String("merge", "", "Comma-separated list of coverage files (from -out) to merge in.")
+	outPath   = flag.String("out", "", "If set, write the merged coverage to this file as JSON.")
+	uncovered = flag.Bool("uncovered", false, "If set, only list the actions that were never performed.")
+)
+
+func main() {
+	flag.Parse()
+
+	g := game.MustLoadFromFile(*gamePath)
+	cov := game.NewCoverage(g.DB.Game)
+
+	if *merge != "" {
+		for _, path := range strings.Split(*merge, ",") {
+			if err := mergeFile(cov, path); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	for _, path := range flag.Args() {
+		if err := run(g, cov, path); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *outPath != "" {
+		b, err := json.Marshal(cov)
+		if err != nil {
+			log.Fatalf("Could not encode coverage: %v", err)
+		}
+		if err := ioutil.WriteFile(*outPath, b, 0644); err != nil {
+			log.Fatalf("Could not write %q: %v", *outPath, err)
+		}
+	}
+
+	report(g.DB.Game, cov)
+}
+
+// run plays through a single script, recording its coverage.
+func run(g *game.Game, cov *game.Coverage, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("Could not read script: %v", err)
+	}
+	defer f.Close()
+
+	g.Restart()
+	g.Seed(*seed)
+	g.Coverage = cov
+	defer func() {
+		g.Coverage = nil
+	}()
+
+	g.Start()
+	g.Events()
+	s := bufio.NewScanner(f)
+	for s.Scan() && !g.IsOver() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		g.Command(line)
+		g.Events()
+	}
+	if err := s.Err(); err != nil {
+		return fmt.Errorf("Could not read %q: %v", path, err)
+	}
+	return nil
+}
+
+// mergeFile merges in the coverage saved in a file by -out.
+func mergeFile(cov *game.Coverage, path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Could not read coverage: %v", err)
+	}
+	c := &game.Coverage{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return fmt.Errorf("Could not decode %q: %v", path, err)
+	}
+	if err := cov.Merge(c); err != nil {
+		return fmt.Errorf("Could not merge %q: %v", path, err)
+	}
+	return nil
+}
+
+// report prints the action table with the coverage counts.
+func report(pb *scottpb.Game, cov *game.Coverage) {
+	for i, a := range pb.Actions {
+		ac := cov.Actions[i]
+		if *uncovered && ac.Fired > 0 {
+			continue
+		}
+
+		mark := "  "
+		if ac.Fired == 0 {
+			mark = ">>"
+		}
+		s := decompile.Words(pb, a)
+		if a.Comment != "" {
+			s += " -- " + a.Comment
+		}
+		fmt.Printf("%s %4d  tried %d, fired %d: %s\n", mark, i, ac.Tried, ac.Fired, s)
+
+		word := "IF"
+		for j, c := range a.Conditions {
+			if c.Type == scottpb.ConditionType_PARAMETER {
+				continue
+			}
+			fmt.Printf("          %s %s  [true %d, false %d]\n", word, decompile.Condition(pb, c), ac.True[j], ac.False[j])
+			word = "AND"
+		}
+		word = "THEN"
+		for _, cmd := range decompile.Commands(pb, a) {
+			fmt.Printf("          %s %s\n", word, cmd)
+			word = "AND"
+		}
+	}
+
+	n := len(pb.Actions)
+	pct := 100.0
+	if n > 0 {
+		pct = 100 * float64(cov.Fired()) / float64(n)
+	}
+	fmt.Printf("\n%d of %d actions performed (%.1f%%).\n", cov.Fired(), n, pct)
+}
@@ -0,0 +1,173 @@
+// grpcclient_scott plays a Scott Adams adventure game hosted by
+// grpcserver_scott.  It behaves much like play_scott, except that the game runs
+// on the server, and saved games are stored locally in text proto format.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+var (
+	addr     = flag.String("addr", "127.0.0.1:5050", "Address of the server.")
+	gameName = flag.String("game", "adv01.dat", "Name of the game file on the server.")
+	seed     = flag.Int64("seed", 0, "Seed for the random events, or 0 for a random seed.")
+	restore  = flag.String("restore", "", "Path to a saved game to restore at the start.")
+	timeout  = flag.Duration("timeout", 10*time.Second, "Timeout for each request to the server.")
+)
+
+// A client plays a single session on the server.
+type client struct {
+	c  scottpb.ScottServiceClient
+	id string
+	in *bufio.Scanner
+}
+
+func main() {
+	flag.Parse()
+
+	conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Could not connect to %s: %v", *addr, err)
+	}
+	defer conn.Close()
+
+	cl := &client{
+		c:  scottpb.NewScottServiceClient(conn),
+		in: bufio.NewScanner(os.Stdin),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	resp, err := cl.c.NewSession(ctx, &scottpb.NewSessionRequest{Game: *gameName, Seed: *seed})
+	cancel()
+	if err != nil {
+		log.Fatalf("Could not start game: %v", err)
+	}
+	cl.id = resp.SessionId
+	defer cl.close()
+
+	cl.render(resp.Events)
+	if *restore != "" {
+		cl.restore(*restore)
+	}
+
+	for {
+		os.Stdout.Write([]byte("\nTell me what to do ? "))
+		if !cl.in.Scan() {
+			fmt.Println()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		resp, err := cl.c.Command(ctx, &scottpb.CommandRequest{SessionId: cl.id, Input: cl.in.Text()})
+		cancel()
+		if err != nil {
+			log.Printf("Command failed: %v", err)
+			return
+		}
+		cl.render(resp.Events)
+		if resp.GameOver {
+			return
+		}
+	}
+}
+
+// render prints out the events sent back by the server.
+func (cl *client) render(evs []*scottpb.Event) {
+	for _, ev := range evs {
+		switch ev.Type {
+		case scottpb.EventType_EVENT_TEXT:
+			fmt.Print(ev.Text)
+		case scottpb.EventType_EVENT_LOOK:
+			fmt.Println()
+			look(ev.Look)
+		case scottpb.EventType_EVENT_CLEAR_SCREEN:
+			fmt.Print("\n\n")
+		case scottpb.EventType_EVENT_DELAY:
+			time.Sleep(2 * time.Second)
+		case scottpb.EventType_EVENT_SAVE:
+			cl.save()
+		}
+	}
+}
+
+// save asks for a filename and saves the state of the game there.
+func (cl *client) save() {
+	fmt.Print("Filename: ")
+	if !cl.in.Scan() || strings.TrimSpace(cl.in.Text()) == "" {
+		return
+	}
+	path := strings.TrimSpace(cl.in.Text())
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	resp, err := cl.c.Save(ctx, &scottpb.SaveRequest{SessionId: cl.id})
+	if err != nil {
+		fmt.Printf("Unable to save: %v\n", err)
+		return
+	}
+	if err := ioutil.WriteFile(path, []byte(prototext.Format(resp.State)), 0644); err != nil {
+		fmt.Printf("Unable to create save file: %v\n", err)
+		return
+	}
+	fmt.Println("Saved.")
+}
+
+// restore loads a saved game from the given file and sends it to the server.
+func (cl *client) restore(path string) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatalf("Could not read saved game: %v", err)
+	}
+	st := &scottpb.State{}
+	if err := prototext.Unmarshal(data, st); err != nil {
+		log.Fatalf("Could not parse saved game: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	resp, err := cl.c.Restore(ctx, &scottpb.RestoreRequest{SessionId: cl.id, State: st})
+	if err != nil {
+		log.Fatalf("Could not restore saved game: %v", err)
+	}
+	fmt.Println()
+	look(resp.Look)
+}
+
+// close ends the session on the server.
+func (cl *client) close() {
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	cl.c.Close(ctx, &scottpb.CloseRequest{SessionId: cl.id})
+}
+
+// look prints out a room description.
+func look(ld *scottpb.LookData) {
+	fmt.Println(ld.RoomDescription)
+
+	fmt.Printf("Obvious exits: ")
+	if len(ld.Exits) > 0 {
+		fmt.Printf("%s\n", strings.Join(ld.Exits, ", "))
+	} else {
+		fmt.Println("None")
+	}
+
+	if len(ld.Items) > 0 {
+		fmt.Printf("\nI can also see: %s\n", strings.Join(ld.Items, " - "))
+	}
+
+	fmt.Println()
+}
@@ -0,0 +1,49 @@
+// grpcserver_scott serves Scott Adams adventure games over gRPC, using the
+// ScottService defined in scott.proto.  Each client starts its own session
+// with any of the games in the game directory, so that games can be driven
+// from any language with gRPC support.  Sessions that go unused for too long
+// are ended automatically.
+//
+// By default the server only listens on the loopback interface.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/service"
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+)
+
+var (
+	addr        = flag.String("addr", "127.0.0.1:5050", "Address to listen on.")
+	gamesDir    = flag.String("games", "games", "Directory holding the game files in ScottFree (TRS-80) format.")
+	idleTimeout = flag.Duration("idle", 30*time.Minute, "How long a session may go unused before it is ended, or 0 for forever.")
+)
+
+func main() {
+	flag.Parse()
+
+	m := session.NewManager(*gamesDir)
+	m.IdleTimeout = *idleTimeout
+	if *idleTimeout > 0 {
+		go m.Reap(time.Minute, nil)
+	}
+
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("Could not listen on %s: %v", *addr, err)
+	}
+
+	s := grpc.NewServer()
+	scottpb.RegisterScottServiceServer(s, service.New(m))
+	log.Printf("Serving games from %s on %s.", *gamesDir, l.Addr())
+	if err := s.Serve(l); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,63 @@
+// lint_scott is a utility for statically checking a Scott Adams adventure file
+// in the TRS-80 format supported by the ScottFree interpreter.  It reports
+// problems the parser can't detect, such as exits into nonexistent rooms or
+// actions that print nonexistent messages, as well as unreachable rooms and
+// unused messages and vocabulary.  With -lenient, damaged files are loaded as
+// best they can be, and the damage is reported along with everything else.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+	"github.com/chaosotter/golang-adventures/internal/scott/parser"
+	"github.com/chaosotter/golang-adventures/internal/scott/stream"
+	"github.com/chaosotter/golang-adventures/internal/scott/validator"
+)
+
+var (
+	gamePath = flag.String("game", "", "Path to the game file in ScottFree (TRS-80) format.")
+	errsOnly = flag.Bool("errors_only", false, "If set, only report errors and not warnings.")
+	lenient  = flag.Bool("lenient", false, "If set, recover from damaged game files and report the damage as warnings.")
+)
+
+func main() {
+	flag.Parse()
+
+	var pb *scottpb.Game
+	if *lenient {
+		data, err := ioutil.ReadFile(*gamePath)
+		if err != nil {
+			log.Fatalf("Could not read %q: %v", *gamePath, err)
+		}
+		var warnings []*stream.Error
+		pb, warnings, err = parser.ParseLenient(data)
+		if err != nil {
+			log.Fatalf("Could not parse %q: %s:%v", *gamePath, *gamePath, err)
+		}
+		if !*errsOnly {
+			for _, w := range warnings {
+				fmt.Printf("%s:%v\n", *gamePath, w)
+			}
+		}
+	} else {
+		pb = game.MustLoadFromFile(*gamePath).DB.Game
+	}
+
+	diags := validator.Validate(pb)
+	for _, d := range diags {
+		if *errsOnly && d.Severity != validator.Error {
+			continue
+		}
+		fmt.Printf("%s: %s\n", *gamePath, d)
+	}
+
+	if validator.HasErrors(diags) {
+		os.Exit(1)
+	}
+}
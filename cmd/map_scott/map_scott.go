@@ -0,0 +1,57 @@
+// map_scott is a utility for drawing a map of a Scott Adams adventure file in
+// the TRS-80 format supported by the ScottFree interpreter.  The map can be
+// written either in the Graphviz DOT language, for rendering with "dot", or
+// directly as an SVG image.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+	"github.com/chaosotter/golang-adventures/internal/scott/writer"
+)
+
+var (
+	gamePath = flag.String("game", "", "Path to the game file in ScottFree (TRS-80) format.")
+	format   = flag.String("format", "dot", "Output format, either \"dot\" or \"svg\".")
+	outPath  = flag.String("out", "", "Path to the output file, or empty for standard output.")
+)
+
+// writers gives the function that writes the map in each output format.
+var writers = map[string]func(out io.Writer, pb *scottpb.Game){
+	"dot": writer.WriteDOT,
+	"svg": writer.WriteSVG,
+}
+
+func main() {
+	flag.Parse()
+	// The format is checked first, so that a typo doesn't clobber the output
+	// file.
+	write, ok := writers[*format]
+	if !ok {
+		log.Fatalf("Unknown format %q", *format)
+	}
+	g := game.MustLoadFromFile(*gamePath)
+
+	f := os.Stdout
+	if *outPath != "" {
+		var err error
+		if f, err = os.Create(*outPath); err != nil {
+			log.Fatalf("Could not create %q: %v", *outPath, err)
+		}
+	}
+	out := bufio.NewWriter(f)
+	write(out, g.DB.Game)
+
+	if err := out.Flush(); err != nil {
+		log.Fatalf("Could not write map: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("Could not write map: %v", err)
+	}
+}
@@ -1,24 +1,123 @@
 // play_scott is an actual (single-player) game driver for Scott Adams adventure
 // games.  Credit is owed to the ScottFree driver for understanding of the
 // underlying file format and semantics.
+//
+// The -y, -i, -s, -t, -p and -d options work as they do in ScottFree; see
+// game.Options for what they do.  With -trace, the actions tried by the engine
+// are traced on standard error, along with their comments.  With -wizard, the
+// "#" commands of the wizard package can be used to change the state of the
+// game.
+//
+// Games are saved in our own format (the State proto, as text) or, with
+// -save_format=scottfree, in ScottFree's format.  With -restore, a game saved
+// in either format is restored before play begins.
+//
+// With -record, the session is recorded to the given file: the hash of the game
+// file, the seed, the options, and every line typed along with the output it
+// produced.  With -playback, such a recording is played back instead of
+// reading from the player, and the first turn where the output differs is
+// reported; the exit status is nonzero if there is one.  Wizard commands are
+// played back as such only if -wizard was given when the session was recorded.  See the record package
+// for the details.
+//
+// With -autosave, the game is saved in the given directory every
+// -autosave_every, when play_scott exits, and when it is interrupted or hung
+// up on.  If there is already an autosave for the game, the player is offered
+// the chance to resume it.  Autosaves are keyed by the contents of the game
+// file, and removed when the game is over.
+//
+// With -list, it lists the games in the -games directory instead, either as a
+// table or, with -json as well, as JSON.
 package main
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
 
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/autosave"
+	"github.com/chaosotter/golang-adventures/internal/scott/catalog"
 	"github.com/chaosotter/golang-adventures/internal/scott/game"
+	"github.com/chaosotter/golang-adventures/internal/scott/record"
+	"github.com/chaosotter/golang-adventures/internal/scott/stream"
+	"github.com/chaosotter/golang-adventures/internal/scott/term"
+	"github.com/chaosotter/golang-adventures/internal/scott/wizard"
+)
+
+var (
+	gamePath    = flag.String("game", "", "Path to the game file in ScottFree (TRS-80) format.")
+	charsetName = flag.String("charset", "raw", "Character set of the game text: raw, latin1 or cp437.")
+	list        = flag.Bool("list", false, "If set, list the games in the -games directory instead of playing.")
+	gamesDir    = flag.String("games", "games", "Directory holding the game files, for -list.")
+	listJSON    = flag.Bool("json", false, "If set, -list prints JSON instead of a table.")
+
+	youAre          = flag.Bool("y", false, "Use \"You are\" narration instead of \"I'm\".")
+	firstPerson     = flag.Bool("i", false, "Use \"I'm\" narration (the default; overrides -y).")
+	scottLight      = flag.Bool("s", false, "Use the original Scott Adams light messages.")
+	trs80           = flag.Bool("t", false, "Use the TRS-80 display style, 64 columns wide.")
+	prehistoricLamp = flag.Bool("p", false, "Destroy the light source when it runs out.")
+	debug           = flag.Bool("d", false, "Report the size of the game when it starts.")
+	trace           = flag.Bool("trace", false, "If set, trace the actions tried by the engine on standard error.")
+	wizardMode      = flag.Bool("wizard", false, "If set, enable the \"#\" wizard commands for testing; see #help.")
+
+	seed         = flag.Int64("seed", 0, "Seed for the random events in the game, or 0 for a random seed.")
+	recordPath   = flag.String("record", "", "If set, record the session to this file.")
+	playbackPath = flag.String("playback", "", "If set, play back the session recorded in this file.")
+
+	saveFormat  = flag.String("save_format", "proto", "Format for saved games: proto or scottfree.")
+	restorePath = flag.String("restore", "", "If set, restore the game saved in this file, in either format.")
+
+	autosaveDir   = flag.String("autosave", "", "If set, keep an autosave of the game in this directory.")
+	autosaveEvery = flag.Duration("autosave_every", time.Minute, "How often to autosave the game, with -autosave.")
 )
 
-var gamePath = flag.String("game", "", "Path to the game file in ScottFree (TRS-80) format.")
+var (
+	charset stream.Charset // the character set of the game text, for conversion to UTF-8
+	in      *bufio.Scanner // the player's input
+	screen  *term.Terminal // the player's output
+)
 
 func main() {
 	flag.Parse()
+	if *list {
+		List()
+		return
+	}
+	var rec *scottpb.Recording
+	if *playbackPath != "" {
+		var err error
+		if rec, err = record.Load(*playbackPath); err != nil {
+			log.Fatal(err)
+		}
+		if *gamePath == "" {
+			*gamePath = rec.GameFile
+		}
+		if err := record.CheckGame(rec, *gamePath); err != nil {
+			log.Fatal(err)
+		}
+	}
 	g := game.MustLoadFromFile(*gamePath)
 
+	var err error
+	if charset, err = stream.ParseCharset(*charsetName); err != nil {
+		log.Fatalf("Bad -charset: %v", err)
+	}
+
 	fmt.Println("Welcome to the play_scott driver for Scott Adams adventures.")
 	fmt.Println("This is a single-player driver in Go based very loosely on the")
 	fmt.Println("C-language ScottFree interpreter.")
@@ -27,55 +126,253 @@ func main() {
 	fmt.Println("project is to provide multiplayer (MUD-like) support.")
 	fmt.Println()
 	fmt.Printf("Loaded Version %d.%02d of Adventure #%d.\n\n",
-		g.Initial.Footer.Version/100, g.Initial.Footer.Version%100, g.Initial.Footer.Adventure)
+		g.DB.Game.Footer.Version/100, g.DB.Game.Footer.Version%100, g.DB.Game.Footer.Adventure)
 
+	g.Options = game.Options{
+		YouAre:          *youAre && !*firstPerson,
+		ScottLight:      *scottLight,
+		TRS80:           *trs80,
+		PrehistoricLamp: *prehistoricLamp,
+		Debug:           *debug,
+	}
+	if *trace {
+		g.Tracer = &game.TextTracer{Out: os.Stderr, Game: g}
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
 	g.Restart()
-	in := bufio.NewScanner(os.Stdin)
+	g.Seed(*seed)
+	if *saveFormat != "proto" && *saveFormat != "scottfree" {
+		log.Fatalf("Bad -save_format: %q", *saveFormat)
+	}
+	if *autosaveDir != "" && (*recordPath != "" || rec != nil || *restorePath != "") {
+		log.Fatal("-autosave can't be used with -record, -playback or -restore.")
+	}
+	if *restorePath != "" {
+		if *recordPath != "" || rec != nil {
+			log.Fatal("A restored game can't be recorded or played back.")
+		}
+		if err := Restore(g, *restorePath); err != nil {
+			log.Fatal(err)
+		}
+	}
 
-	for {
-		fmt.Println()
-		Look(g.Look())
-
-		os.Stdout.Write([]byte("Tell me what to do ? "))
-		if in.Scan() {
-			pd := g.Parse(in.Text())
-			fmt.Printf("I got this: %q<%d> %q<%d>\n", pd.Verb, pd.VerbIndex, pd.Noun, pd.NounIndex)
-
-			switch g.Execute(pd) {
-			case game.Unknown:
-				fmt.Println("I don't understand your command.")
-			case game.NoDirection:
-				fmt.Println("Give me a direction too.")
-			case game.BadDirection:
-				fmt.Println("I can't go in that direction.")
-			case game.DangerousDark:
-				fmt.Println("Dangerous to move in the dark!")
-			case game.DeadDark:
-				fmt.Println("Dangerous to move in the dark!")
-				fmt.Println("I feel down and broke my neck.")
-			case game.Unsuccessful:
-				fmt.Println("I can't do that yet.")
+	in = bufio.NewScanner(os.Stdin)
+	var width func() int
+	if *trs80 {
+		width = func() int { return game.TRS80Width }
+	}
+	screen = term.New(os.Stdout, width, ReadLine)
+	screen.Charset = charset
+	screen.Save = func() { Save(g) }
+
+	// The autosaver saves the game from its own goroutine, so the game is only
+	// touched with |mu| held.  The output is rendered afterwards, since saving
+	// the game asks for a filename.
+	var mu sync.Mutex
+	run := func(f func(g *game.Game)) {
+		mu.Lock()
+		defer mu.Unlock()
+		f(g)
+	}
+
+	// Wizard commands are played back only if they were allowed when the
+	// session was recorded; otherwise they went to the game like any other
+	// input.
+	wizardOn := *wizardMode
+	if rec != nil {
+		wizardOn = rec.Wizard
+	}
+	console := &wizard.Console{Game: g}
+	turn := func(line string, start bool) []*game.Event {
+		mu.Lock()
+		var evs []*game.Event
+		switch {
+		case start:
+			g.Start()
+			evs = g.Events()
+		case wizardOn && wizard.IsCommand(line):
+			evs = []*game.Event{{Type: game.TextEvent, Text: console.Exec(line)}}
+		default:
+			g.Command(line)
+			evs = g.Events()
+		}
+		mu.Unlock()
+		screen.Render(evs)
+		return evs
+	}
+
+	if rec != nil {
+		Playback(g, rec, turn)
+		return
+	}
+
+	var recorder *record.Recorder
+	if *recordPath != "" {
+		if recorder, err = record.NewRecorder(*recordPath, *gamePath, *seed, g.Options, *wizardMode); err != nil {
+			log.Fatal(err)
+		}
+	}
+	play := func(line string, start bool) {
+		evs := turn(line, start)
+		if recorder != nil {
+			if err := recorder.Turn(line, evs); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	if *autosaveDir != "" {
+		a, err := Autosave(g.DB, run)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer func() {
+			if err := a.Stop(); err != nil {
+				log.Print(err)
 			}
+		}()
+	}
+
+	play("", true)
+	for !g.IsOver() {
+		line, err := screen.Input("Tell me what to do ? ")
+		if err != nil {
+			fmt.Println()
+			return
 		}
+		play(line, false)
+	}
+}
 
-		// TODO: Handle the light source ticking down.
+// Autosave starts autosaving the game, and offers to resume the autosave left
+// by an earlier run, if there is one.  The game is saved one last time if play_scott is
+// interrupted or hung up on.
+func Autosave(db *game.Database, run func(f func(g *game.Game))) (*autosave.Autosaver, error) {
+	id := autosave.GameID(db)
+	store := autosave.NewStore(*autosaveDir)
+	a, err := store.Start(id, "", *autosaveEvery, run)
+	if err != nil {
+		return nil, err
 	}
+	if err := screen.Resume(run, store, id, ""); err != nil {
+		a.Stop()
+		return nil, err
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	go func() {
+		<-sig
+		if err := a.Stop(); err != nil {
+			log.Print(err)
+		}
+		fmt.Println()
+		os.Exit(1)
+	}()
+	return a, nil
 }
 
-func Look(ld *game.LookData) {
-	fmt.Println(ld.RoomDescription)
+// Playback plays back a recorded session, showing the input and output as it
+// goes, and reports the first divergence from the recording.
+func Playback(g *game.Game, rec *scottpb.Recording, turn func(line string, start bool) []*game.Event) {
+	// The names of save files aren't recorded, so there is nothing to answer
+	// the prompt with.
+	screen.Save = nil
+	d := record.Playback(g, rec, func(line string, start bool) []*game.Event {
+		if !start {
+			screen.Print("Tell me what to do ? " + line + "\n")
+		}
+		return turn(line, start)
+	})
+	if d != nil {
+		fmt.Printf("\nPlayback diverged from the recording at %s\n", d)
+		os.Exit(1)
+	}
+	fmt.Printf("\nPlayback matched all %d turns of the recording.\n", len(rec.Turns))
+}
 
-	fmt.Printf("Obvious exits: ")
-	if len(ld.Exits) > 0 {
-		fmt.Printf("%s\n", strings.Join(ld.Exits, ", "))
-	} else {
-		fmt.Println("None")
+// ReadLine shows the prompt and reads a line of input.
+func ReadLine(prompt string) (string, error) {
+	fmt.Print(prompt)
+	if !in.Scan() {
+		return "", io.EOF
 	}
+	return in.Text(), nil
+}
 
-	// TODO: Handle line-wrapping.
-	if len(ld.Items) > 0 {
-		fmt.Printf("\nI can also see: %s\n", strings.Join(ld.Items, " - "))
+// List prints out the catalog of the games in the game directory.
+func List() {
+	entries, err := catalog.Scan(*gamesDir)
+	if err != nil {
+		log.Fatalf("Could not list games: %v", err)
 	}
 
-	fmt.Println()
+	if *listJSON {
+		if entries == nil {
+			entries = []*catalog.Entry{}
+		}
+		b, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(b))
+		return
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "FILE\tTITLE\tVERSION\tADVENTURE\tROOMS\tITEMS\tTREASURES\n")
+	for _, e := range entries {
+		if e.Error != "" {
+			fmt.Fprintf(tw, "%s\t%s\t(%s)\n", e.File, e.Title, e.Error)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\t%d\n", e.File, e.Title, e.Version, e.Adventure, e.Rooms, e.Items, e.Treasures)
+	}
+	tw.Flush()
+}
+
+// Save asks for a filename and saves the state of the game there.
+func Save(g *game.Game) {
+	line, err := screen.Input("Filename: ")
+	path := strings.TrimSpace(line)
+	if err != nil || path == "" {
+		return
+	}
+	var data []byte
+	if *saveFormat == "scottfree" {
+		b := &bytes.Buffer{}
+		g.WriteScottFreeSave(b)
+		data = b.Bytes()
+	} else {
+		data = []byte(prototext.Format(g.SaveState()))
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		screen.Printf("Unable to create save file: %v\n", err)
+		return
+	}
+	screen.Print("Saved.\n")
+}
+
+// Restore restores a saved game, in either our format or ScottFree's.  The
+// formats are told apart by their first character: ScottFree's files are all
+// numbers.
+func Restore(g *game.Game, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Could not read saved game: %v", err)
+	}
+	if t := bytes.TrimSpace(data); len(t) > 0 && (t[0] == '-' || t[0] >= '0' && t[0] <= '9') {
+		err = g.ReadScottFreeSave(bytes.NewReader(data))
+	} else {
+		st := &scottpb.State{}
+		if err = prototext.Unmarshal(data, st); err == nil {
+			err = g.RestoreState(st)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("Could not restore %q: %v", path, err)
+	}
+	return nil
 }
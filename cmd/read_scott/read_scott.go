@@ -17,5 +17,5 @@ func main() {
 	flag.Parse()
 	g := game.MustLoadFromFile(*gamePath)
 
-	fmt.Println(prototext.Format(g.Initial))
+	fmt.Println(prototext.Format(g.DB.Game))
 }
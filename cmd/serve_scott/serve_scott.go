@@ -0,0 +1,42 @@
+// serve_scott serves Scott Adams adventure games as an HTTP/JSON API, for the
+// benefit of web frontends.  Each client starts its own session with any of
+// the games in the game directory; see the rest package for the details of the
+// API.  Sessions that go unused for too long are ended automatically.
+//
+// By default the server only listens on the loopback interface.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/rest"
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+)
+
+var (
+	addr        = flag.String("addr", "127.0.0.1:8080", "Address to listen on.")
+	gamesDir    = flag.String("games", "games", "Directory holding the game files in ScottFree (TRS-80) format.")
+	idleTimeout = flag.Duration("idle", 30*time.Minute, "How long a session may go unused before it is ended, or 0 for forever.")
+)
+
+func main() {
+	flag.Parse()
+
+	m := session.NewManager(*gamesDir)
+	m.IdleTimeout = *idleTimeout
+	if *idleTimeout > 0 {
+		go m.Reap(time.Minute, nil)
+	}
+
+	srv := &http.Server{
+		Addr:         *addr,
+		Handler:      rest.New(m),
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+	log.Printf("Serving games from %s on http://%s/.", *gamesDir, *addr)
+	log.Fatal(srv.ListenAndServe())
+}
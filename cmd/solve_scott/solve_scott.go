@@ -0,0 +1,72 @@
+// solve_scott is a utility for finding walkthroughs of Scott Adams adventure
+// files in the TRS-80 format supported by the ScottFree interpreter.  It runs
+// a bounded best-first search through the game engine for a sequence of
+// commands that stores as many treasures as possible.
+//
+// Since it exercises a great deal of the engine, it doubles as a correctness
+// check for the interpreter: any number of games may be given as arguments, and
+// each walkthrough found is replayed from scratch to make sure it ends up in
+// the same place.  The exit status is nonzero if anything went wrong.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+	"github.com/chaosotter/golang-adventures/internal/scott/solver"
+)
+
+var (
+	gamePath  = flag.String("game", "", "Path to the game file in ScottFree (TRS-80) format.")
+	seed      = flag.Int64("seed", 1, "Seed for the random events in the game.")
+	maxDepth  = flag.Int("depth", 200, "Maximum number of commands in a walkthrough.")
+	maxStates = flag.Int("states", 50000, "Maximum number of game states to explore.")
+	quiet     = flag.Bool("quiet", false, "If set, only print the summary for each game.")
+)
+
+func main() {
+	flag.Parse()
+
+	paths := flag.Args()
+	if *gamePath != "" {
+		paths = append([]string{*gamePath}, paths...)
+	}
+
+	ok := true
+	for _, path := range paths {
+		if err := solve(path); err != nil {
+			fmt.Printf("%s: %v\n", path, err)
+			ok = false
+		}
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// solve finds and prints a walkthrough for a single game, then checks that it
+// can be replayed.  Panics from the engine aren't recovered, since they are
+// bugs to be fixed rather than problems with the game.
+func solve(path string) error {
+	g := game.MustLoadFromFile(path)
+	r := solver.Solve(g, solver.Options{
+		Seed:      *seed,
+		MaxDepth:  *maxDepth,
+		MaxStates: *maxStates,
+	})
+	fmt.Printf("%s: %s\n", path, r)
+	if !*quiet {
+		for _, cmd := range r.Commands {
+			fmt.Printf("  %s\n", cmd)
+		}
+	}
+
+	solver.Replay(g, *seed, r.Commands)
+	if g.StateHash() != r.Hash {
+		stored, _ := g.Score()
+		return fmt.Errorf("replay ended in a different state (stored %d treasures, not %d)", stored, r.Stored)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// ssh_scott serves Scott Adams adventure games over SSH, so that players can
+// simply run
+//
+//	ssh -p 2222 play@localhost
+//
+// and pick a game from a menu of those in the game directory.  Each SSH
+// session plays its own game, with the output word-wrapped to the width of the
+// player's terminal as it is resized.  Sessions without a terminal work too,
+// minus the line editing.
+//
+// Only the user given by -user may log in.  If -password or -authorized_keys
+// is given, the player must authenticate accordingly; otherwise anyone may
+// play.  The host key is generated on the first run and kept in the file
+// given by -hostkey.
+//
+// If -autosave is given, each game is saved in that directory every
+// -autosave_every and when the session ends, so that a player who comes back
+// to the same game is offered the chance to resume it, unless they are still
+// playing it in another session.  Players who log in with a key are told apart
+// by its fingerprint; anyone else is asked for a name and a password of their
+// own.
+//
+// By default the server only listens on the loopback interface.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"time"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/autosave"
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+	"github.com/chaosotter/golang-adventures/internal/scott/sshd"
+	"github.com/chaosotter/golang-adventures/internal/scott/stream"
+)
+
+var (
+	addr           = flag.String("addr", "127.0.0.1:2222", "Address to listen on.")
+	gamesDir       = flag.String("games", "games", "Directory holding the game files in ScottFree (TRS-80) format.")
+	hostKeyPath    = flag.String("hostkey", "ssh_host_ed25519_key", "Path to the host key, which is generated if it doesn't exist.")
+	user           = flag.String("user", "play", "The user name players log in with.")
+	password       = flag.String("password", "", "Password the players must give, if any.")
+	authorizedKeys = flag.String("authorized_keys", "", "Path to a file of public keys allowed to log in, if any.")
+	idleTimeout    = flag.Duration("idle", 30*time.Minute, "How long a connection may go without input before it is closed.")
+	charsetName    = flag.String("charset", "raw", "Character set of the game text: raw, latin1 or cp437.")
+
+	autosaveDir   = flag.String("autosave", "", "Directory to keep autosaves in, or empty to turn autosaving off.")
+	autosaveEvery = flag.Duration("autosave_every", time.Minute, "How often to autosave a game in progress.")
+)
+
+func main() {
+	flag.Parse()
+
+	charset, err := stream.ParseCharset(*charsetName)
+	if err != nil {
+		log.Fatalf("Bad -charset: %v", err)
+	}
+	hostKey, err := sshd.LoadHostKey(*hostKeyPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s := sshd.New(session.NewManager(*gamesDir), hostKey, *user)
+	s.Password = *password
+	s.IdleTimeout = *idleTimeout
+	s.Charset = charset
+	if *authorizedKeys != "" {
+		if s.AuthorizedKeys, err = sshd.LoadAuthorizedKeys(*authorizedKeys); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *autosaveDir != "" {
+		s.Autosaves = autosave.NewStore(*autosaveDir)
+		s.AutosaveEvery = *autosaveEvery
+	}
+
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("Could not listen on %s: %v", *addr, err)
+	}
+	log.Printf("Serving games from %s on %s.", *gamesDir, l.Addr())
+	log.Fatal(s.Serve(l))
+}
@@ -0,0 +1,165 @@
+// telnet_scott serves Scott Adams adventure games to telnet and MUD clients.
+// Each connection plays its own game, with the output word-wrapped to the
+// width of the client's window.  If -game isn't given, the player picks one of
+// the games in the game directory.  If -password is given, the player must
+// type it in before playing.
+//
+// If -autosave is given, each game is saved in that directory every
+// -autosave_every and when the player leaves, keyed by the game and the name
+// and password the player gives.  A player who comes back to the same game
+// with the same name and password is offered the chance to resume it, unless
+// they are still playing it on another connection.
+//
+// By default the server only listens on the loopback interface.
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"net"
+	"time"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/autosave"
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+	"github.com/chaosotter/golang-adventures/internal/scott/stream"
+	"github.com/chaosotter/golang-adventures/internal/scott/term"
+	"github.com/chaosotter/golang-adventures/internal/telnet"
+)
+
+var (
+	addr        = flag.String("addr", "127.0.0.1:2323", "Address to listen on.")
+	gamesDir    = flag.String("games", "games", "Directory holding the game files in ScottFree (TRS-80) format.")
+	gameName    = flag.String("game", "", "Name of the game to play, or empty to let the player choose.")
+	password    = flag.String("password", "", "Password the player must give before playing, if any.")
+	idleTimeout = flag.Duration("idle", 30*time.Minute, "How long a connection may go without input before it is closed.")
+	charsetName = flag.String("charset", "raw", "Character set of the game text: raw, latin1 or cp437.")
+
+	autosaveDir   = flag.String("autosave", "", "Directory to keep autosaves in, or empty to turn autosaving off.")
+	autosaveEvery = flag.Duration("autosave_every", time.Minute, "How often to autosave a game in progress.")
+)
+
+// charset is the character set of the game text, for conversion to UTF-8.
+var charset stream.Charset
+
+// store keeps the autosaves, if they are turned on.
+var store *autosave.Store
+
+func main() {
+	flag.Parse()
+
+	var err error
+	if charset, err = stream.ParseCharset(*charsetName); err != nil {
+		log.Fatalf("Bad -charset: %v", err)
+	}
+
+	if *autosaveDir != "" {
+		store = autosave.NewStore(*autosaveDir)
+	}
+
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("Could not listen on %s: %v", *addr, err)
+	}
+	log.Printf("Serving games from %s on %s.", *gamesDir, l.Addr())
+
+	m := session.NewManager(*gamesDir)
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			log.Fatal(err)
+		}
+		go serve(m, conn)
+	}
+}
+
+// A player is a single connected client.
+type player struct {
+	conn *telnet.Conn
+	net  net.Conn
+	t    *term.Terminal
+}
+
+// readLine shows the prompt and reads a line of input, giving up if the player
+// is idle for too long.
+func (p *player) readLine(prompt string) (string, error) {
+	io.WriteString(p.conn, prompt)
+	if *idleTimeout > 0 {
+		p.net.SetReadDeadline(time.Now().Add(*idleTimeout))
+	}
+	return p.conn.ReadLine()
+}
+
+// readSecret is like readLine, but turns off the echo while the player types.
+func (p *player) readSecret(prompt string) (string, error) {
+	p.conn.SetEcho(false)
+	line, err := p.readLine(prompt)
+	p.conn.SetEcho(true)
+	io.WriteString(p.conn, "\r\n")
+	return line, err
+}
+
+// serve plays a game with a single client.
+func serve(m *session.Manager, conn net.Conn) {
+	p := &player{conn: telnet.NewConn(conn), net: conn}
+	defer p.conn.Close()
+	p.t = term.New(p.conn, p.conn.Width, p.readLine)
+	p.t.ReadSecret = p.readSecret
+	p.t.Charset = charset
+
+	addr := conn.RemoteAddr()
+	log.Printf("%s: connected", addr)
+	defer log.Printf("%s: disconnected", addr)
+
+	if *password != "" && !p.login() {
+		return
+	}
+
+	name := *gameName
+	if name == "" {
+		games, err := m.Games()
+		if err != nil {
+			log.Printf("Could not list games: %v", err)
+		}
+		if name, err = p.t.ChooseGame(games); err != nil || name == "" {
+			return
+		}
+	}
+
+	s, err := m.Create(name, 0)
+	if err != nil {
+		p.t.Printf("Could not load the game: %v\n", err)
+		return
+	}
+	defer m.Close(s.ID)
+	log.Printf("%s: playing %s (terminal %q)", addr, s.Name, p.conn.TerminalType())
+	if store == nil {
+		p.t.Play(s)
+		return
+	}
+	who, err := p.t.AskPlayer()
+	if err != nil {
+		return
+	}
+	if err := p.t.PlayAutosaved(s, store, who, *autosaveEvery); err != nil {
+		log.Printf("%s: %v", addr, err)
+	}
+}
+
+// login asks for the password, with echo turned off, allowing three tries.
+func (p *player) login() bool {
+	for try := 0; try < 3; try++ {
+		p.conn.SetEcho(false)
+		line, err := p.t.Input("Password: ")
+		p.conn.SetEcho(true)
+		p.t.Print("\n")
+		if err != nil {
+			return false
+		}
+		if line == *password {
+			return true
+		}
+		p.t.Print("Wrong password.\n")
+	}
+	return false
+}
@@ -2,42 +2,217 @@
 // Scott Adams adventure files in the TRS-80 format supported by the ScottFree
 // interpreter.  It does this by loading the game, writing it back out in the
 // same format, and doing a diff on the results.
+//
+// Any number of files, directories and glob patterns may be given, either with
+// -game or as arguments.  The files are verified concurrently, and a table of
+// the results is printed at the end, showing the first differing line for any
+// file that fails.  With -proto and -json, the game is also put through the
+// binary proto and JSON encodings before being written back out.
 package main
 
 import (
 	"bytes"
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"text/tabwriter"
 
-	"github.com/kylelemons/godebug/pretty"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
-	"github.com/chaosotter/golang-adventures/internal/scott/game"
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/parser"
 	"github.com/chaosotter/golang-adventures/internal/scott/writer"
 )
 
-var gamePath = flag.String("game", "", "Path to the game file in ScottFree (TRS-80) format.")
+var (
+	gamePath  = flag.String("game", "", "Path to the game file in ScottFree (TRS-80) format, or a directory or glob pattern.")
+	viaProto  = flag.Bool("proto", false, "If set, also round-trip each game through the binary proto encoding.")
+	viaJSON   = flag.Bool("json", false, "If set, also round-trip each game through the JSON encoding.")
+	parallel  = flag.Int("parallel", runtime.NumCPU(), "Number of files to verify at once.")
+	extension = flag.String("ext", ".dat", "Extension of the game files to look for in directories.")
+)
+
+// A result is the outcome of verifying a single file.
+type result struct {
+	path   string
+	failed bool
+	detail string
+}
 
 func main() {
 	flag.Parse()
+	if *parallel < 1 {
+		log.Fatalf("-parallel must be at least 1, not %d.", *parallel)
+	}
 
-	data, err := ioutil.ReadFile(*gamePath)
+	args := flag.Args()
+	if *gamePath != "" {
+		args = append([]string{*gamePath}, args...)
+	}
+	paths, err := expand(args)
 	if err != nil {
-		log.Fatalf("Could not read %q: %v", *gamePath, err)
+		log.Fatal(err)
+	}
+	if len(paths) == 0 {
+		log.Fatal("No game files given.")
+	}
+
+	results := make([]*result, len(paths))
+	sem := make(chan bool, *parallel)
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		wg.Add(1)
+		sem <- true
+		go func(i int, path string) {
+			defer wg.Done()
+			results[i] = verify(path)
+			<-sem
+		}(i, path)
+	}
+	wg.Wait()
+
+	failures := 0
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "FILE\tRESULT\tDETAIL\n")
+	for _, r := range results {
+		status := "ok"
+		if r.failed {
+			status = "FAIL"
+			failures++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.path, status, r.detail)
+	}
+	tw.Flush()
+	fmt.Printf("\n%d of %d files verified.\n", len(results)-failures, len(results))
+
+	if failures > 0 {
+		os.Exit(1)
+	}
+}
+
+// expand turns the arguments into a sorted list of files.  Directories are
+// searched (not recursively) for files with the game extension, and glob
+// patterns are expanded.
+func expand(args []string) ([]string, error) {
+	seen := map[string]bool{}
+	var paths []string
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+
+	for _, arg := range args {
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("Bad pattern %q: %v", arg, err)
+		}
+		if len(matches) == 0 {
+			// Let verify report that the file is missing.
+			add(arg)
+			continue
+		}
+
+		for _, m := range matches {
+			info, err := os.Stat(m)
+			if err != nil || !info.IsDir() {
+				add(m)
+				continue
+			}
+			files, err := ioutil.ReadDir(m)
+			if err != nil {
+				return nil, fmt.Errorf("Could not read %q: %v", m, err)
+			}
+			for _, f := range files {
+				if !f.IsDir() && strings.EqualFold(filepath.Ext(f.Name()), *extension) {
+					add(filepath.Join(m, f.Name()))
+				}
+			}
+		}
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// verify checks that a single file survives the round trip.
+func verify(path string) *result {
+	fail := func(format string, args ...interface{}) *result {
+		return &result{path: path, failed: true, detail: fmt.Sprintf(format, args...)}
 	}
 
-	g, err := game.New(data)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fail("%v", err)
+	}
+	pb, err := parser.Parse(data)
 	if err != nil {
-		log.Fatalf("Could not parse %q: %v", *gamePath, err)
+		return fail("%v", err)
+	}
+
+	steps := []string{"TRS-80"}
+	if *viaProto {
+		b, err := proto.Marshal(pb)
+		if err != nil {
+			return fail("proto: %v", err)
+		}
+		pb = &scottpb.Game{}
+		if err := proto.Unmarshal(b, pb); err != nil {
+			return fail("proto: %v", err)
+		}
+		steps = append(steps, "proto")
+	}
+	if *viaJSON {
+		b, err := protojson.Marshal(pb)
+		if err != nil {
+			return fail("JSON: %v", err)
+		}
+		pb = &scottpb.Game{}
+		if err := protojson.Unmarshal(b, pb); err != nil {
+			return fail("JSON: %v", err)
+		}
+		steps = append(steps, "JSON")
 	}
 
 	b := &bytes.Buffer{}
-	writer.WriteTRS80(b, g.Initial)
+	if err := writer.WriteTRS80(b, pb); err != nil {
+		return fail("writing: %v", err)
+	}
+	if line, got, want := firstDiff(b.Bytes(), data); line > 0 {
+		return fail("line %d: got %q, want %q", line, got, want)
+	}
+	return &result{path: path, detail: "via " + strings.Join(steps, ", ")}
+}
+
+// firstDiff finds the first line that differs between the two inputs,
+// returning its number and the two versions of it, or 0 if they're the same.
+func firstDiff(got, want []byte) (int, string, string) {
+	if bytes.Equal(got, want) {
+		return 0, "", ""
+	}
 
-	got := strings.Split(b.String(), "\n")
-	want := strings.Split(string(data), "\n")
-	if diff := pretty.Compare(got, want); diff != "" {
-		log.Fatalf("Diff detected:\n%s", diff)
+	g := strings.Split(string(got), "\n")
+	w := strings.Split(string(want), "\n")
+	for i := 0; i < len(g) || i < len(w); i++ {
+		var gl, wl string
+		if i < len(g) {
+			gl = g[i]
+		}
+		if i < len(w) {
+			wl = w[i]
+		}
+		if gl != wl || i >= len(g) || i >= len(w) {
+			return i + 1, gl, wl
+		}
 	}
+	return len(w), "", ""
 }
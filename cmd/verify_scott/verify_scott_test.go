@@ -0,0 +1,76 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.dat", "b.DAT", "c.txt", "sub/d.dat"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	in := func(names ...string) []string {
+		var paths []string
+		for _, name := range names {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+		return paths
+	}
+
+	for _, tc := range []struct {
+		args []string
+		want []string
+	}{
+		// Directories give their game files, but aren't searched recursively.
+		{in(""), in("a.dat", "b.DAT")},
+		// Files are taken as they are, whatever their extension.
+		{in("c.txt", "sub/d.dat"), in("c.txt", "sub/d.dat")},
+		// Globs are expanded, and the results are sorted without repeats.
+		{in("sub/*", "*.txt", "a.dat", "a.dat"), in("a.dat", "c.txt", "sub/d.dat")},
+		// Missing files are kept, so that they're reported.
+		{in("missing.dat"), in("missing.dat")},
+	} {
+		got, err := expand(tc.args)
+		if err != nil {
+			t.Errorf("expand(%q) returned %v", tc.args, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("expand(%q) = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+
+	if _, err := expand([]string{"["}); err == nil {
+		t.Errorf("expand accepted a bad pattern")
+	}
+}
+
+func TestFirstDiff(t *testing.T) {
+	for _, tc := range []struct {
+		got, want string
+		line      int
+		gl, wl    string
+	}{
+		{"a\nb\n", "a\nb\n", 0, "", ""},
+		{"a\nx\nc\n", "a\nb\nc\n", 2, "x", "b"},
+		{"a\n", "a\nb\n", 2, "", "b"},
+		{"a\nb\n", "a\n", 2, "b", ""},
+		{"a\nb", "a\nb\n", 3, "", ""}, // missing final newline
+		{"", "a\n", 1, "", "a"},
+	} {
+		line, gl, wl := firstDiff([]byte(tc.got), []byte(tc.want))
+		if line != tc.line || gl != tc.gl || wl != tc.wl {
+			t.Errorf("firstDiff(%q, %q) = %d, %q, %q; want %d, %q, %q", tc.got, tc.want, line, gl, wl, tc.line, tc.gl, tc.wl)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// web_scott serves a browser client for Scott Adams adventure games, with the
+// games themselves running on the server.  Everything the client needs is
+// built into the binary, so it works without any network access beyond the
+// connection to the browser.
+//
+// By default the server only listens on the loopback interface.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+	"github.com/chaosotter/golang-adventures/internal/scott/web"
+)
+
+var (
+	addr     = flag.String("addr", "127.0.0.1:8080", "Address to listen on.")
+	gamesDir = flag.String("games", "games", "Directory holding the game files in ScottFree (TRS-80) format.")
+)
+
+func main() {
+	flag.Parse()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           web.New(session.NewManager(*gamesDir)),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	log.Printf("Serving games from %s on http://%s/.", *gamesDir, *addr)
+	log.Fatal(srv.ListenAndServe())
+}
@@ -0,0 +1,253 @@
+// Package autosave keeps copies of games in progress on disk, so that a player
+// whose connection drops, or whose terminal is closed, can pick up where they
+// left off.
+//
+// Autosaves are kept in a directory, one for each game and player.  Games are
+// identified by the SHA-256 hash of the game file rather than its name, so
+// that a save is never restored into a different version of a game.  Each save
+// is written to a temporary file and then renamed into place, so that a crash
+// while saving never leaves a damaged autosave behind.  The autosave is
+// removed once the game is over.
+//
+// Players must be told apart by something they can't simply claim: the
+// fingerprint of a key they authenticated with, or PlayerKey made from a name
+// and a password of their choosing.  A Store also refuses to autosave the same
+// game for the same player twice at once, so a second connection can't resume
+// a game that is still being played.
+//
+// Only the terminal servers and play_scott autosave.  The HTTP, WebSocket and
+// gRPC sessions belong to whichever client holds the random session ID, not to
+// a player, so there is nothing to key an autosave on without adding logins to
+// those servers; gRPC clients can already save and restore the state
+// themselves.
+package autosave
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// Extension is the extension of autosave files.
+const Extension = ".autosave"
+
+// GameID returns the identity of a game, for keying its autosaves.
+func GameID(db *game.Database) string {
+	return hex.EncodeToString(db.Hash[:])
+}
+
+// PlayerKey returns the player identity for the given name and password, for
+// keying their autosaves.  Someone who gives the same name with a different
+// password gets a different key, and so can't see or touch the autosaves.
+func PlayerKey(name, password string) string {
+	sum := sha256.Sum256([]byte(name + "\x00" + password))
+	return hex.EncodeToString(sum[:])
+}
+
+// ErrInUse is returned by Start for a game that is already being autosaved
+// for the same player.
+var ErrInUse = errors.New("That game is already being played under that name")
+
+// A Store keeps autosaves in a directory, which is created when needed.  A
+// server should share one Store between all of its players, since it keeps
+// track of the games being autosaved.
+type Store struct {
+	Dir string
+
+	mu     sync.Mutex
+	active map[string]bool // the autosaves in use, by path
+}
+
+// NewStore initializes a new Store for the given directory.
+func NewStore(dir string) *Store {
+	return &Store{Dir: dir, active: map[string]bool{}}
+}
+
+// Save saves the state of the game for the given game identity and player.
+func (s *Store) Save(id, player string, g *game.Game) error {
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return fmt.Errorf("Could not create autosave directory: %v", err)
+	}
+	data := []byte(prototext.MarshalOptions{Multiline: true}.Format(g.SaveState()))
+
+	path := s.path(id, player)
+	tmp, err := ioutil.TempFile(s.Dir, filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("Could not autosave: %v", err)
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("Could not autosave: %v", err)
+	}
+	return nil
+}
+
+// Load returns the autosaved state for the given game identity and player,
+// along with when it was saved.  The state is nil if there is no autosave.
+func (s *Store) Load(id, player string) (*scottpb.State, time.Time, error) {
+	path := s.path(id, player)
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, time.Time{}, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	st := &scottpb.State{}
+	if err := prototext.Unmarshal(data, st); err != nil {
+		return nil, time.Time{}, fmt.Errorf("Could not parse autosave %q: %v", path, err)
+	}
+	return st, info.ModTime(), nil
+}
+
+// Remove removes the autosave for the given game identity and player, if
+// there is one.
+func (s *Store) Remove(id, player string) error {
+	if err := os.Remove(s.path(id, player)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path returns the path of the autosave for the given game identity and
+// player.
+func (s *Store) path(id, player string) string {
+	name := id
+	if player != "" {
+		name += "-" + url.PathEscape(player)
+	}
+	return filepath.Join(s.Dir, name+Extension)
+}
+
+// An Autosaver saves a game every so often while it is being played, and once
+// more when it stops.  Nothing is written if the game hasn't changed since the
+// last save.
+type Autosaver struct {
+	store      *Store
+	id, player string
+	run        func(f func(g *game.Game))
+
+	stop     chan bool
+	done     chan bool
+	stopOnce sync.Once
+	last     [sha256.Size]byte // hash of the state last saved, or found at the start
+}
+
+// Start starts autosaving a game for the given game identity and player,
+// every |interval|.  Nothing is saved until the game changes, so an existing
+// autosave can still be offered for resuming (see Load) once autosaving has
+// started.  Since the game isn't safe for concurrent use, it is only ever
+// touched through |run|, which must call its argument with the game while
+// nothing else is using it (as session.Session.Run does).
+//
+// Start returns ErrInUse if the game is already being autosaved for the same
+// player, until that Autosaver is stopped.
+func (s *Store) Start(id, player string, interval time.Duration, run func(f func(g *game.Game))) (*Autosaver, error) {
+	path := s.path(id, player)
+	s.mu.Lock()
+	if s.active[path] {
+		s.mu.Unlock()
+		return nil, ErrInUse
+	}
+	if s.active == nil {
+		s.active = map[string]bool{}
+	}
+	s.active[path] = true
+	s.mu.Unlock()
+
+	a := &Autosaver{
+		store:  s,
+		id:     id,
+		player: player,
+		run:    run,
+		stop:   make(chan bool),
+		done:   make(chan bool),
+	}
+	run(func(g *game.Game) { a.last = g.StateHash() })
+	go a.loop(interval)
+	return a, nil
+}
+
+// release marks an autosave as no longer in use.
+func (s *Store) release(id, player string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.active, s.path(id, player))
+}
+
+// Save saves the game now.  If the game is over, the autosave is removed
+// instead, since there is nothing to resume.
+func (a *Autosaver) Save() error {
+	var err error
+	a.run(func(g *game.Game) {
+		if g.IsOver() {
+			err = a.store.Remove(a.id, a.player)
+			return
+		}
+		h := g.StateHash()
+		if h == a.last {
+			return
+		}
+		if err = a.store.Save(a.id, a.player, g); err == nil {
+			a.last = h
+		}
+	})
+	return err
+}
+
+// Stop stops the periodic saves, and saves the game one last time.  It may be
+// called more than once.
+func (a *Autosaver) Stop() error {
+	first := false
+	a.stopOnce.Do(func() {
+		close(a.stop)
+		first = true
+	})
+	<-a.done
+	err := a.Save()
+	if first {
+		a.store.release(a.id, a.player)
+	}
+	return err
+}
+
+// loop saves the game periodically until the Autosaver is stopped.
+func (a *Autosaver) loop(interval time.Duration) {
+	defer close(a.done)
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-a.stop:
+			return
+		case <-tick.C:
+			if err := a.Save(); err != nil {
+				log.Print(err)
+			}
+		}
+	}
+}
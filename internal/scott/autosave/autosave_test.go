@@ -0,0 +1,174 @@
+package autosave
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+const gamePath = "../../../games/adv01.dat"
+
+func TestStoreRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "autosave")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	g, err := game.LoadFromFile(gamePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := GameID(g.DB)
+	s := NewStore(filepath.Join(dir, "saves"))
+
+	if st, _, err := s.Load(id, "alice"); st != nil || err != nil {
+		t.Fatalf("Load with no autosave: got %v, %v", st, err)
+	}
+
+	g.Start()
+	g.Command("CLIMB TREE")
+	want := g.StateHash()
+	if err := s.Save(id, "alice/bob", g); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(id, "alice", g); err != nil {
+		t.Fatal(err)
+	}
+	files, _ := filepath.Glob(filepath.Join(s.Dir, "*"))
+	if len(files) != 2 {
+		t.Errorf("Store holds %v, want two autosaves and nothing else", files)
+	}
+
+	g.Restart()
+	st, _, err := s.Load(id, "alice")
+	if err != nil || st == nil {
+		t.Fatalf("Load: got %v, %v", st, err)
+	}
+	if err := g.RestoreState(st); err != nil {
+		t.Fatal(err)
+	}
+	if g.StateHash() != want {
+		t.Errorf("Restored state differs from the one saved")
+	}
+
+	if err := s.Remove(id, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if st, _, err := s.Load(id, "alice"); st != nil || err != nil {
+		t.Errorf("Load after Remove: got %v, %v", st, err)
+	}
+}
+
+func TestAutosaver(t *testing.T) {
+	dir, err := ioutil.TempDir("", "autosave")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	g, err := game.LoadFromFile(gamePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	run := func(f func(g *game.Game)) {
+		mu.Lock()
+		defer mu.Unlock()
+		f(g)
+	}
+	s := NewStore(dir)
+
+	a, err := s.Start("id", "", time.Hour, run)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run(func(g *game.Game) {
+		g.Start()
+		g.Command("CLIMB TREE")
+	})
+	if err := a.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Stop(); err != nil {
+		t.Fatalf("Second Stop: %v", err)
+	}
+	if st, _, err := s.Load("id", ""); st == nil || err != nil {
+		t.Fatalf("Load after Stop: got %v, %v", st, err)
+	}
+
+	if a, err = s.Start("id", "", time.Hour, run); err != nil {
+		t.Fatal(err)
+	}
+	run(func(g *game.Game) { g.Command("QUIT") })
+	if err := a.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if st, _, err := s.Load("id", ""); st != nil || err != nil {
+		t.Errorf("Load after the game ended: got %v, %v", st, err)
+	}
+}
+
+func TestStartRefusesSecondSession(t *testing.T) {
+	dir, err := ioutil.TempDir("", "autosave")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	g, err := game.LoadFromFile(gamePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Start()
+	g.Command("CLIMB TREE")
+	run := func(f func(g *game.Game)) { f(g) }
+	s := NewStore(dir)
+	if err := s.Save("id", "alice", g); err != nil {
+		t.Fatal(err)
+	}
+
+	// Starting doesn't save over the autosave before there's anything new.
+	g.Restart()
+	a, err := s.Start("id", "alice", time.Hour, run)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st, _, _ := s.Load("id", "alice"); st == nil || st.Location == g.State.Location {
+		t.Errorf("Start replaced the autosave")
+	}
+
+	if _, err := s.Start("id", "alice", time.Hour, run); err != ErrInUse {
+		t.Errorf("Second Start for the same player: got %v, want ErrInUse", err)
+	}
+	b, err := s.Start("id", "bob", time.Hour, run)
+	if err != nil {
+		t.Errorf("Start for another player: %v", err)
+	} else {
+		b.Stop()
+	}
+
+	a.Stop()
+	if a, err = s.Start("id", "alice", time.Hour, run); err != nil {
+		t.Errorf("Start after Stop: %v", err)
+	} else {
+		a.Stop()
+	}
+}
+
+func TestPlayerKey(t *testing.T) {
+	alice := PlayerKey("alice", "secret")
+	if PlayerKey("alice", "secret") != alice {
+		t.Errorf("PlayerKey isn't stable")
+	}
+	for _, other := range []string{PlayerKey("alice", "guess"), PlayerKey("alice", ""), PlayerKey("alicesecret", ""), PlayerKey("bob", "secret")} {
+		if other == alice {
+			t.Errorf("PlayerKey gives the same key for different players")
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package catalog identifies the games in a directory.  Most of what we know
+// about each game comes from the game file itself, but the titles and author
+// can only come from a readme file alongside the games, in the format of the
+// one in the Scott Adams Collection:
+//
+//	                      (c) Scott Adams, 1978-84
+//	...
+//	  ADV01.DAT    Adventureland                                      v4.16
+//
+// Games that aren't mentioned in the readme are named after their files.
+package catalog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/parser"
+)
+
+// Readme is the name of the readme file in the game directory.
+const Readme = "0readme.txt"
+
+// Extension is the extension of the game files.
+const Extension = ".dat"
+
+// An Entry describes a single game.
+type Entry struct {
+	File      string `json:"file"`              // name of the game file, within the directory
+	Title     string `json:"title"`             // title of the game
+	Author    string `json:"author,omitempty"`  // author of the game, if known
+	Version   string `json:"version,omitempty"` // version of the game, such as "4.16", if known
+	Adventure int    `json:"adventure"`         // adventure number
+	Rooms     int    `json:"rooms"`             // number of rooms
+	Items     int    `json:"items"`             // number of items
+	Treasures int    `json:"treasures"`         // number of treasures
+	Error     string `json:"error,omitempty"`   // why the game couldn't be read, if it couldn't
+}
+
+// String summarizes the entry on one line.
+func (e *Entry) String() string {
+	if e.Error != "" {
+		return fmt.Sprintf("%s: %s", e.File, e.Error)
+	}
+	s := fmt.Sprintf("%s: %s", e.File, e.Title)
+	if e.Version != "" {
+		s += " v" + e.Version
+	}
+	return s
+}
+
+// These pick apart the lines of the readme.
+var (
+	copyrightRE = regexp.MustCompile(`\(c\)\s+([^,]+)`)
+	titleRE     = regexp.MustCompile(`^\s+(\S+\.(?i:dat))\s+(.*?)(?:\s+v\d+\.\d+)?\s*$`)
+)
+
+// Scan identifies the games in the given directory, sorted by file name.  A
+// game file that can't be read still gets an entry, with the reason given in
+// Error.
+func Scan(dir string) ([]*Entry, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	titles, author, err := readReadme(filepath.Join(dir, Readme))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	for _, f := range files {
+		if f.IsDir() || !strings.EqualFold(filepath.Ext(f.Name()), Extension) {
+			continue
+		}
+		e := Identify(filepath.Join(dir, f.Name()))
+		if t, ok := titles[strings.ToLower(f.Name())]; ok {
+			e.Title, e.Author = t, author
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].File < entries[j].File })
+	return entries, nil
+}
+
+// Identify describes a single game file, using what can be learned from the
+// file alone.  The title is taken from the file name.
+func Identify(path string) *Entry {
+	name := filepath.Base(path)
+	e := &Entry{
+		File:  name,
+		Title: strings.ToUpper(strings.TrimSuffix(name, filepath.Ext(name))),
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		e.Error = err.Error()
+		return e
+	}
+	pb, err := parser.Parse(data)
+	if err != nil {
+		e.Error = err.Error()
+		return e
+	}
+
+	if v := pb.Footer.Version; v > 0 {
+		e.Version = fmt.Sprintf("%d.%02d", v/100, v%100)
+	}
+	e.Adventure = int(pb.Footer.Adventure)
+	e.Rooms = len(pb.Rooms)
+	e.Items = len(pb.Items)
+	e.Treasures = int(pb.Header.NumTreasures)
+	return e
+}
+
+// Find returns the entry for the given file name, or nil if there isn't one.
+func Find(entries []*Entry, file string) *Entry {
+	for _, e := range entries {
+		if e.File == file {
+			return e
+		}
+	}
+	return nil
+}
+
+// readReadme reads the titles of the games from the readme, keyed by the file
+// name in lower case, along with the author.  A missing readme isn't an error.
+func readReadme(path string) (map[string]string, string, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	titles := map[string]string{}
+	author := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if m := copyrightRE.FindStringSubmatch(line); m != nil && author == "" {
+			author = strings.TrimSpace(m[1])
+		}
+		if m := titleRE.FindStringSubmatch(line); m != nil {
+			name := strings.ToLower(m[1])
+			// Only the first mention counts, since the list of contents
+			// comes first.
+			if _, ok := titles[name]; !ok && m[2] != "" {
+				titles[name] = m[2]
+			}
+		}
+	}
+	return titles, author, nil
+}
@@ -0,0 +1,152 @@
+package catalog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// readme is a cut-down readme in the format of the Scott Adams Collection.
+const readme = "" +
+	"-----------------------------------------------------------------------\r\n" +
+	"                    THE SCOTT ADAMS COLLECTION 2.2\r\n" +
+	"                      (c) Scott Adams, 1978-84\r\n" +
+	"\r\n" +
+	"  ADV01.DAT    Adventureland                                      v4.16\r\n" +
+	"  ADV02.DAT    Pirate Adventure (a.k.a. Pirate's Cove)            v4.08\r\n" +
+	"  ADV14A.DAT   Return to Pirate's Isle\r\n" +
+	"  quest1.dat   The Hulk v1.27\r\n" +
+	"\r\n" +
+	"Some games were revised later (c) Someone Else, 1999:\r\n" +
+	"  ADV01.DAT    Adventureland, revised                             v4.17\r\n" +
+	"Not a title: ADV03.DAT is missing\r\n"
+
+// writeGames makes a game directory holding the readme, a copy of adv01.dat,
+// a copy under a name that isn't in the readme, and a file that isn't a game.
+func writeGames(t *testing.T) string {
+	dir := t.TempDir()
+	adv01, err := ioutil.ReadFile("../../../games/adv01.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string][]byte{
+		Readme:       []byte(readme),
+		"adv01.dat":  adv01,
+		"mine.DAT":   adv01,
+		"broken.dat": []byte("This is not a game.\n"),
+		"notes.txt":  []byte("Not a game either, but it's ignored.\n"),
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestReadReadme(t *testing.T) {
+	dir := writeGames(t)
+	titles, author, err := readReadme(filepath.Join(dir, Readme))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if author != "Scott Adams" {
+		t.Errorf("The author is %q, want %q", author, "Scott Adams")
+	}
+	want := map[string]string{
+		"adv01.dat":  "Adventureland",
+		"adv02.dat":  "Pirate Adventure (a.k.a. Pirate's Cove)",
+		"adv14a.dat": "Return to Pirate's Isle",
+		"quest1.dat": "The Hulk",
+	}
+	if !reflect.DeepEqual(titles, want) {
+		t.Errorf("The titles are %q, want %q", titles, want)
+	}
+
+	// A missing readme just means there are no titles.
+	titles, author, err = readReadme(filepath.Join(dir, "missing.txt"))
+	if err != nil || titles != nil || author != "" {
+		t.Errorf("For a missing readme, readReadme returned %q, %q, %v", titles, author, err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	entries, err := Scan(writeGames(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Scan returned %d entries, want 3: %v", len(entries), entries)
+	}
+
+	want := Entry{File: "adv01.dat", Title: "Adventureland", Author: "Scott Adams", Version: "4.16", Adventure: 1, Rooms: 34, Items: 66, Treasures: 13}
+	if e := entries[0]; *e != want {
+		t.Errorf("adv01.dat: got %+v, want %+v", e, want)
+	}
+
+	// A game that isn't in the readme is named after its file, and has no
+	// author.
+	want.File, want.Title, want.Author = "mine.DAT", "MINE", ""
+	if e := Find(entries, "mine.DAT"); e == nil || *e != want {
+		t.Errorf("mine.DAT: got %+v, want %+v", e, want)
+	}
+
+	if e := Find(entries, "broken.dat"); e == nil || e.Error == "" || e.Title != "BROKEN" || e.Rooms != 0 {
+		t.Errorf("broken.dat: got %+v", e)
+	}
+	if e := Find(entries, "notes.txt"); e != nil {
+		t.Errorf("notes.txt: got %+v", e)
+	}
+
+	if _, err := Scan(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("Scan of a missing directory succeeded")
+	}
+}
+
+func TestIdentifyMissing(t *testing.T) {
+	e := Identify(filepath.Join(t.TempDir(), "gone.dat"))
+	if e.File != "gone.dat" || e.Title != "GONE" || e.Error == "" {
+		t.Errorf("Identify returned %+v", e)
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, tc := range []struct {
+		e    Entry
+		want string
+	}{
+		{Entry{File: "adv01.dat", Title: "Adventureland", Version: "4.16"}, "adv01.dat: Adventureland v4.16"},
+		{Entry{File: "adv14a.dat", Title: "Return to Pirate's Isle"}, "adv14a.dat: Return to Pirate's Isle"},
+		{Entry{File: "broken.dat", Title: "BROKEN", Error: "Bad header"}, "broken.dat: Bad header"},
+	} {
+		if got := tc.e.String(); got != tc.want {
+			t.Errorf("String() = %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	for _, tc := range []struct {
+		e    Entry
+		want string
+	}{
+		{
+			Entry{File: "adv01.dat", Title: "Adventureland", Author: "Scott Adams", Version: "4.16", Adventure: 1, Rooms: 34, Items: 66, Treasures: 13},
+			`{"file":"adv01.dat","title":"Adventureland","author":"Scott Adams","version":"4.16","adventure":1,"rooms":34,"items":66,"treasures":13}`,
+		},
+		// The optional fields are left out when empty, but the counts aren't.
+		{
+			Entry{File: "broken.dat", Title: "BROKEN", Error: "Bad header"},
+			`{"file":"broken.dat","title":"BROKEN","adventure":0,"rooms":0,"items":0,"treasures":0,"error":"Bad header"}`,
+		},
+	} {
+		got, err := json.Marshal(&tc.e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("JSON for %s:\n got %s\nwant %s", tc.e.File, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Package decompile renders the entries of a Scott Adams action table as
+// readable text, naming the words, items, rooms and messages they refer to
+// rather than leaving them as bare numbers.
+//
+// An action comes out in the following form:
+//
+//	GET LAMP -- comment
+//	  IF ITEM_PRESENT 9 ("Old fashioned brass lamp")
+//	  AND BIT_CLEAR 5
+//	  THEN GET_ITEM 9 ("Old fashioned brass lamp")
+//	  AND MESSAGE_3 "OK"
+package decompile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// Action renders an entire action, in the form shown above.
+func Action(pb *scottpb.Game, a *scottpb.Action) string {
+	var b strings.Builder
+	b.WriteString(Words(pb, a))
+	if a.Comment != "" {
+		b.WriteString(" -- " + a.Comment)
+	}
+	b.WriteString("\n")
+
+	word := "IF"
+	for _, c := range a.Conditions {
+		if c.Type == scottpb.ConditionType_PARAMETER {
+			continue
+		}
+		fmt.Fprintf(&b, "  %s %s\n", word, Condition(pb, c))
+		word = "AND"
+	}
+	word = "THEN"
+	for _, cmd := range Commands(pb, a) {
+		fmt.Fprintf(&b, "  %s %s\n", word, cmd)
+		word = "AND"
+	}
+	return b.String()
+}
+
+// Words renders the words an action responds to: the verb and noun, "auto N%"
+// for an automatic action with an N% chance, or "continued" for a
+// continuation action.
+func Words(pb *scottpb.Game, a *scottpb.Action) string {
+	switch {
+	case a.VerbIndex == game.AutoVerb && a.NounIndex == 0:
+		return "continued"
+	case a.VerbIndex == game.AutoVerb:
+		return fmt.Sprintf("auto %d%%", a.NounIndex)
+	}
+	s := word(pb.Verbs, a.VerbIndex)
+	if a.NounIndex != 0 {
+		s += " " + word(pb.Nouns, a.NounIndex)
+	}
+	return s
+}
+
+// Condition renders a single condition, naming the item or room it refers to.
+func Condition(pb *scottpb.Game, c *scottpb.Condition) string {
+	s := fmt.Sprintf("%s %d", c.Type, c.Value)
+	switch c.Type {
+	case scottpb.ConditionType_ITEM_CARRIED,
+		scottpb.ConditionType_ITEM_IN_ROOM,
+		scottpb.ConditionType_ITEM_PRESENT,
+		scottpb.ConditionType_ITEM_NOT_IN_ROOM,
+		scottpb.ConditionType_ITEM_NOT_CARRIED,
+		scottpb.ConditionType_ITEM_NOT_PRESENT,
+		scottpb.ConditionType_ITEM_IN_GAME,
+		scottpb.ConditionType_ITEM_NOT_IN_GAME,
+		scottpb.ConditionType_ITEM_MOVED,
+		scottpb.ConditionType_ITEM_NOT_MOVED:
+		s += item(pb, c.Value)
+	case scottpb.ConditionType_PLAYER_IN_ROOM,
+		scottpb.ConditionType_PLAYER_NOT_IN_ROOM:
+		s += room(pb, c.Value)
+	case scottpb.ConditionType_INVENTORY_EMPTY,
+		scottpb.ConditionType_INVENTORY_NOT_EMPTY:
+		// The value is ignored.
+		s = c.Type.String()
+	}
+	return s
+}
+
+// Commands renders each of an action's commands in turn, with the parameters
+// they take from the action's PARAMETER conditions.  NOTHING is left out.
+func Commands(pb *scottpb.Game, a *scottpb.Action) []string {
+	var out []string
+	params := game.Params(a)
+	for _, t := range a.Actions {
+		n := game.NumParams(t)
+		if len(params) < n {
+			params = append(params, make([]int32, n-len(params))...)
+		}
+		if t != scottpb.ActionType_NOTHING {
+			out = append(out, Command(pb, t, params[0:n]))
+		}
+		params = params[n:]
+	}
+	return out
+}
+
+// Command renders a single command with its parameters, naming the items,
+// rooms and messages involved.
+func Command(pb *scottpb.Game, t scottpb.ActionType, params []int32) string {
+	s := t.String()
+	if m, ok := game.MessageIndex(t); ok {
+		if m < len(pb.Messages) {
+			s += fmt.Sprintf(" %q", strings.TrimSpace(pb.Messages[m]))
+		}
+		return s
+	}
+
+	for i, p := range params {
+		s += fmt.Sprintf(" %d", p)
+		switch t {
+		case scottpb.ActionType_GET_ITEM,
+			scottpb.ActionType_DROP_ITEM,
+			scottpb.ActionType_REMOVE_ITEM,
+			scottpb.ActionType_REMOVE_ITEM2,
+			scottpb.ActionType_TAKE_ITEM,
+			scottpb.ActionType_SWAP_ITEMS,
+			scottpb.ActionType_MOVE_ITEM_TO_ITEM:
+			s += item(pb, p)
+		case scottpb.ActionType_MOVE_PLAYER:
+			s += room(pb, p)
+		case scottpb.ActionType_PUT_ITEM:
+			if i == 0 {
+				s += item(pb, p)
+			} else {
+				s += room(pb, p)
+			}
+		}
+	}
+	return s
+}
+
+// item names an item, or returns "" if it's out of range.
+func item(pb *scottpb.Game, i int32) string {
+	if i < 0 || int(i) >= len(pb.Items) {
+		return ""
+	}
+	return fmt.Sprintf(" (%q)", pb.Items[i].Description)
+}
+
+// room names a room, or returns "" if it's out of range.
+func room(pb *scottpb.Game, i int32) string {
+	if i < 0 || int(i) >= len(pb.Rooms) {
+		return ""
+	}
+	return fmt.Sprintf(" (%q)", pb.Rooms[i].Description)
+}
+
+// word returns the text of a word, or its index if it's out of range.
+func word(ws []*scottpb.Word, i int32) string {
+	if i < 0 || int(i) >= len(ws) {
+		return fmt.Sprintf("#%d", i)
+	}
+	return ws[i].Word
+}
@@ -0,0 +1,263 @@
+package decompile
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// testGame is a small game with just enough in it to name things.
+var testGame = &scottpb.Game{
+	Rooms: []*scottpb.Room{
+		{Description: ""},
+		{Description: "forest"},
+		{Description: "hall"},
+	},
+	Items: []*scottpb.Item{
+		{Description: "Lamp", Location: 1},
+		{Description: "*Gold*", Location: 2, IsTreasure: true},
+	},
+	Messages: []string{"", "OK ", "Hello"},
+	Verbs:    []*scottpb.Word{{Word: "AUTO"}, {Word: "GO"}, {Word: "GET"}},
+	Nouns:    []*scottpb.Word{{Word: "ANY"}, {Word: "LAMP"}},
+}
+
+// cond builds a condition.
+func cond(t scottpb.ConditionType, v int32) *scottpb.Condition {
+	return &scottpb.Condition{Type: t, Value: v}
+}
+
+func TestCondition(t *testing.T) {
+	tcs := []struct {
+		c    *scottpb.Condition
+		want string
+	}{
+		{cond(scottpb.ConditionType_PARAMETER, 7), "PARAMETER 7"},
+		{cond(scottpb.ConditionType_ITEM_CARRIED, 0), `ITEM_CARRIED 0 ("Lamp")`},
+		{cond(scottpb.ConditionType_ITEM_IN_ROOM, 1), `ITEM_IN_ROOM 1 ("*Gold*")`},
+		{cond(scottpb.ConditionType_ITEM_PRESENT, 0), `ITEM_PRESENT 0 ("Lamp")`},
+		{cond(scottpb.ConditionType_PLAYER_IN_ROOM, 1), `PLAYER_IN_ROOM 1 ("forest")`},
+		{cond(scottpb.ConditionType_ITEM_NOT_IN_ROOM, 1), `ITEM_NOT_IN_ROOM 1 ("*Gold*")`},
+		{cond(scottpb.ConditionType_ITEM_NOT_CARRIED, 0), `ITEM_NOT_CARRIED 0 ("Lamp")`},
+		{cond(scottpb.ConditionType_PLAYER_NOT_IN_ROOM, 2), `PLAYER_NOT_IN_ROOM 2 ("hall")`},
+		{cond(scottpb.ConditionType_BIT_SET, 15), "BIT_SET 15"},
+		{cond(scottpb.ConditionType_BIT_CLEAR, 3), "BIT_CLEAR 3"},
+		{cond(scottpb.ConditionType_INVENTORY_NOT_EMPTY, 5), "INVENTORY_NOT_EMPTY"},
+		{cond(scottpb.ConditionType_INVENTORY_EMPTY, 0), "INVENTORY_EMPTY"},
+		{cond(scottpb.ConditionType_ITEM_NOT_PRESENT, 1), `ITEM_NOT_PRESENT 1 ("*Gold*")`},
+		{cond(scottpb.ConditionType_ITEM_IN_GAME, 0), `ITEM_IN_GAME 0 ("Lamp")`},
+		{cond(scottpb.ConditionType_ITEM_NOT_IN_GAME, 1), `ITEM_NOT_IN_GAME 1 ("*Gold*")`},
+		{cond(scottpb.ConditionType_COUNTER_LE, 10), "COUNTER_LE 10"},
+		{cond(scottpb.ConditionType_COUNTER_GE, 20), "COUNTER_GE 20"},
+		{cond(scottpb.ConditionType_ITEM_MOVED, 0), `ITEM_MOVED 0 ("Lamp")`},
+		{cond(scottpb.ConditionType_ITEM_NOT_MOVED, 1), `ITEM_NOT_MOVED 1 ("*Gold*")`},
+		{cond(scottpb.ConditionType_COUNTER_EQ, 0), "COUNTER_EQ 0"},
+
+		// Out of range items and rooms are left unnamed.
+		{cond(scottpb.ConditionType_ITEM_CARRIED, 2), "ITEM_CARRIED 2"},
+		{cond(scottpb.ConditionType_ITEM_CARRIED, -1), "ITEM_CARRIED -1"},
+		{cond(scottpb.ConditionType_PLAYER_IN_ROOM, 3), "PLAYER_IN_ROOM 3"},
+	}
+
+	seen := map[scottpb.ConditionType]bool{}
+	for _, tc := range tcs {
+		seen[tc.c.Type] = true
+		if got := Condition(testGame, tc.c); got != tc.want {
+			t.Errorf("Condition(%v) = %q, want %q", tc.c, got, tc.want)
+		}
+	}
+	for v, name := range scottpb.ConditionType_name {
+		if !seen[scottpb.ConditionType(v)] {
+			t.Errorf("There is no test for %s", name)
+		}
+	}
+}
+
+func TestCommand(t *testing.T) {
+	tcs := []struct {
+		t      scottpb.ActionType
+		params []int32
+		want   string
+	}{
+		{scottpb.ActionType_NOTHING, nil, "NOTHING"},
+		{scottpb.ActionType_GET_ITEM, []int32{0}, `GET_ITEM 0 ("Lamp")`},
+		{scottpb.ActionType_DROP_ITEM, []int32{1}, `DROP_ITEM 1 ("*Gold*")`},
+		{scottpb.ActionType_MOVE_PLAYER, []int32{2}, `MOVE_PLAYER 2 ("hall")`},
+		{scottpb.ActionType_REMOVE_ITEM, []int32{0}, `REMOVE_ITEM 0 ("Lamp")`},
+		{scottpb.ActionType_SET_DARKNESS, nil, "SET_DARKNESS"},
+		{scottpb.ActionType_CLEAR_DARKNESS, nil, "CLEAR_DARKNESS"},
+		{scottpb.ActionType_SET_BIT, []int32{5}, "SET_BIT 5"},
+		{scottpb.ActionType_REMOVE_ITEM2, []int32{1}, `REMOVE_ITEM2 1 ("*Gold*")`},
+		{scottpb.ActionType_CLEAR_BIT, []int32{5}, "CLEAR_BIT 5"},
+		{scottpb.ActionType_DEATH, nil, "DEATH"},
+		{scottpb.ActionType_PUT_ITEM, []int32{0, 2}, `PUT_ITEM 0 ("Lamp") 2 ("hall")`},
+		{scottpb.ActionType_GAME_OVER, nil, "GAME_OVER"},
+		{scottpb.ActionType_DESCRIBE_ROOM, nil, "DESCRIBE_ROOM"},
+		{scottpb.ActionType_SCORE, nil, "SCORE"},
+		{scottpb.ActionType_INVENTORY, nil, "INVENTORY"},
+		{scottpb.ActionType_SET_BIT_0, nil, "SET_BIT_0"},
+		{scottpb.ActionType_CLEAR_BIT_0, nil, "CLEAR_BIT_0"},
+		{scottpb.ActionType_REFILL_LIGHT, nil, "REFILL_LIGHT"},
+		{scottpb.ActionType_CLEAR_SCREEN, nil, "CLEAR_SCREEN"},
+		{scottpb.ActionType_SAVE_GAME, nil, "SAVE_GAME"},
+		{scottpb.ActionType_SWAP_ITEMS, []int32{0, 1}, `SWAP_ITEMS 0 ("Lamp") 1 ("*Gold*")`},
+		{scottpb.ActionType_CONTINUE, nil, "CONTINUE"},
+		{scottpb.ActionType_TAKE_ITEM, []int32{1}, `TAKE_ITEM 1 ("*Gold*")`},
+		{scottpb.ActionType_MOVE_ITEM_TO_ITEM, []int32{1, 0}, `MOVE_ITEM_TO_ITEM 1 ("*Gold*") 0 ("Lamp")`},
+		{scottpb.ActionType_DESCRIBE_ROOM2, nil, "DESCRIBE_ROOM2"},
+		{scottpb.ActionType_DECREMENT_COUNTER, nil, "DECREMENT_COUNTER"},
+		{scottpb.ActionType_PRINT_COUNTER, nil, "PRINT_COUNTER"},
+		{scottpb.ActionType_SET_COUNTER, []int32{30}, "SET_COUNTER 30"},
+		{scottpb.ActionType_SWAP_LOCATION, nil, "SWAP_LOCATION"},
+		{scottpb.ActionType_SELECT_COUNTER, []int32{2}, "SELECT_COUNTER 2"},
+		{scottpb.ActionType_ADD_TO_COUNTER, []int32{3}, "ADD_TO_COUNTER 3"},
+		{scottpb.ActionType_SUB_FROM_COUNTER, []int32{4}, "SUB_FROM_COUNTER 4"},
+		{scottpb.ActionType_ECHO_NOUN, nil, "ECHO_NOUN"},
+		{scottpb.ActionType_ECHO_NOUN_CR, nil, "ECHO_NOUN_CR"},
+		{scottpb.ActionType_ECHO_CR, nil, "ECHO_CR"},
+		{scottpb.ActionType_SWAP_LOCATION_N, []int32{1}, "SWAP_LOCATION_N 1"},
+		{scottpb.ActionType_DELAY, nil, "DELAY"},
+		{scottpb.ActionType_DRAW_PICTURE, []int32{6}, "DRAW_PICTURE 6"},
+
+		// Messages are quoted, trimmed, and left out when out of range.
+		{scottpb.ActionType_MESSAGE_0, nil, `MESSAGE_0 "OK"`},
+		{scottpb.ActionType_MESSAGE_1, nil, `MESSAGE_1 "Hello"`},
+		{scottpb.ActionType_MESSAGE_2, nil, "MESSAGE_2"},
+
+		// Out of range items and rooms are left unnamed.
+		{scottpb.ActionType_GET_ITEM, []int32{9}, "GET_ITEM 9"},
+		{scottpb.ActionType_PUT_ITEM, []int32{1, -1}, `PUT_ITEM 1 ("*Gold*") -1`},
+	}
+
+	seen := map[scottpb.ActionType]bool{}
+	for _, tc := range tcs {
+		seen[tc.t] = true
+		if n := game.NumParams(tc.t); n != len(tc.params) {
+			t.Errorf("%s: the test has %d parameters, want %d", tc.t, len(tc.params), n)
+		}
+		if got := Command(testGame, tc.t, tc.params); got != tc.want {
+			t.Errorf("Command(%s, %v) = %q, want %q", tc.t, tc.params, got, tc.want)
+		}
+	}
+	for v, name := range scottpb.ActionType_name {
+		if _, ok := game.MessageIndex(scottpb.ActionType(v)); !ok && !seen[scottpb.ActionType(v)] {
+			t.Errorf("There is no test for %s", name)
+		}
+	}
+}
+
+// TestMessages checks the numbering of all the messages.  Message 0 is always
+// empty, so MESSAGE_N prints message N+1, and the numbers skip across the
+// other command types between MESSAGE_50 and MESSAGE_51.
+func TestMessages(t *testing.T) {
+	pb := &scottpb.Game{}
+	for i := 0; i <= 100; i++ {
+		pb.Messages = append(pb.Messages, "Message "+strconv.Itoa(i))
+	}
+	for v, name := range scottpb.ActionType_name {
+		if _, ok := game.MessageIndex(scottpb.ActionType(v)); !ok {
+			continue
+		}
+		n, err := strconv.Atoi(name[len("MESSAGE_"):])
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := fmt.Sprintf("%s \"Message %d\"", name, n+1)
+		if got := Command(pb, scottpb.ActionType(v), nil); got != want {
+			t.Errorf("Command(%s) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	for _, tc := range []struct {
+		verb, noun int32
+		want       string
+	}{
+		{game.AutoVerb, 0, "continued"},
+		{game.AutoVerb, 50, "auto 50%"},
+		{game.AutoVerb, 100, "auto 100%"},
+		{1, 0, "GO"},
+		{2, 1, "GET LAMP"},
+		{5, 1, "#5 LAMP"},
+		{2, 7, "GET #7"},
+	} {
+		a := &scottpb.Action{VerbIndex: tc.verb, NounIndex: tc.noun}
+		if got := Words(testGame, a); got != tc.want {
+			t.Errorf("Words(%d, %d) = %q, want %q", tc.verb, tc.noun, got, tc.want)
+		}
+	}
+}
+
+func TestCommands(t *testing.T) {
+	// The parameters are handed out in order, NOTHING is left out, and
+	// missing parameters come out as zero.
+	a := &scottpb.Action{
+		Conditions: []*scottpb.Condition{
+			cond(scottpb.ConditionType_PARAMETER, 1),
+			cond(scottpb.ConditionType_ITEM_CARRIED, 0),
+			cond(scottpb.ConditionType_PARAMETER, 2),
+			cond(scottpb.ConditionType_PARAMETER, 4),
+		},
+		Actions: []scottpb.ActionType{
+			scottpb.ActionType_PUT_ITEM,
+			scottpb.ActionType_NOTHING,
+			scottpb.ActionType_SET_BIT,
+			scottpb.ActionType_DROP_ITEM,
+		},
+	}
+	want := []string{`PUT_ITEM 1 ("*Gold*") 2 ("hall")`, "SET_BIT 4", `DROP_ITEM 0 ("Lamp")`}
+	got := Commands(testGame, a)
+	if len(got) != len(want) {
+		t.Fatalf("Commands returned %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Commands returned %q, want %q", got, want)
+			break
+		}
+	}
+}
+
+func TestAction(t *testing.T) {
+	for _, tc := range []struct {
+		a    *scottpb.Action
+		want string
+	}{
+		{
+			&scottpb.Action{
+				VerbIndex: 2,
+				NounIndex: 1,
+				Comment:   "get the lamp",
+				Conditions: []*scottpb.Condition{
+					cond(scottpb.ConditionType_ITEM_PRESENT, 0),
+					cond(scottpb.ConditionType_PARAMETER, 0),
+					cond(scottpb.ConditionType_BIT_CLEAR, 5),
+				},
+				Actions: []scottpb.ActionType{scottpb.ActionType_GET_ITEM, scottpb.ActionType_MESSAGE_0},
+			},
+			"GET LAMP -- get the lamp\n" +
+				"  IF ITEM_PRESENT 0 (\"Lamp\")\n" +
+				"  AND BIT_CLEAR 5\n" +
+				"  THEN GET_ITEM 0 (\"Lamp\")\n" +
+				"  AND MESSAGE_0 \"OK\"\n",
+		},
+		{
+			// No conditions at all.
+			&scottpb.Action{
+				VerbIndex: game.AutoVerb,
+				NounIndex: 100,
+				Actions:   []scottpb.ActionType{scottpb.ActionType_SET_DARKNESS},
+			},
+			"auto 100%\n" +
+				"  THEN SET_DARKNESS\n",
+		},
+	} {
+		if got := Action(testGame, tc.a); got != tc.want {
+			t.Errorf("Action(%s):\n got %q\nwant %q", Words(testGame, tc.a), got, tc.want)
+		}
+	}
+}
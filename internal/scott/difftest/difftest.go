@@ -0,0 +1,231 @@
+// Package difftest measures how closely the engine follows ScottFree, the
+// reference implementation, by playing the same commands through both and
+// comparing the output turn by turn.
+//
+// A test case is a pair of files: a script of commands, and a transcript of
+// ScottFree playing them.  The script has one command per line.  Blank lines
+// are ignored, as are lines starting with "#", except for these directives:
+//
+//	# game: adv01.dat     the game file, relative to the games directory
+//	# seed: 1234          the value ScottFree passed to srand()
+//	# options: -y -s      the ScottFree options used (-y, -i, -s, -t, -p, -d)
+//
+// The transcript is everything ScottFree printed, with room descriptions in
+// line where it would have redrawn the top window, and without the banner it
+// prints before the game starts.  It is split into turns at each "Tell me what
+// to do ? " prompt; the command echoed after a prompt is dropped.
+//
+// Random events are reproduced with game.ScottFreeRand, so the transcript must
+// have been captured with a known seed.  Whitespace is normalized before the
+// turns are compared, since the two interpreters wrap text differently.
+package difftest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+	"github.com/chaosotter/golang-adventures/internal/scott/term"
+)
+
+// Prompt is the prompt ScottFree prints before reading each command.
+const Prompt = "Tell me what to do ? "
+
+// A Script is the input side of a test case.
+type Script struct {
+	Game     string       // the game file, relative to the games directory
+	Seed     uint32       // the seed for ScottFree's random events
+	Options  game.Options // the ScottFree options used
+	Commands []string     // the commands, in order
+}
+
+// LoadScript reads a script from a file.
+func LoadScript(path string) (*Script, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := &Script{}
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "#") {
+			s.Commands = append(s.Commands, line)
+			continue
+		}
+
+		i := strings.Index(line, ":")
+		if i < 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[1:i])
+		value := strings.TrimSpace(line[i+1:])
+		switch key {
+		case "game":
+			s.Game = value
+		case "seed":
+			seed, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: Bad seed %q", path, n, value)
+			}
+			s.Seed = uint32(seed)
+		case "options":
+			if err := parseOptions(&s.Options, value); err != nil {
+				return nil, fmt.Errorf("%s:%d: %v", path, n, err)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if s.Game == "" {
+		return nil, fmt.Errorf("%s: No game given", path)
+	}
+	return s, nil
+}
+
+// parseOptions sets the options given as ScottFree command-line flags.
+func parseOptions(opts *game.Options, flags string) error {
+	firstPerson := false
+	for _, f := range strings.Fields(flags) {
+		switch f {
+		case "-y":
+			opts.YouAre = true
+		case "-i":
+			firstPerson = true
+		case "-s":
+			opts.ScottLight = true
+		case "-t":
+			opts.TRS80 = true
+		case "-p":
+			opts.PrehistoricLamp = true
+		case "-d":
+			opts.Debug = true
+		default:
+			return fmt.Errorf("Unknown option %q", f)
+		}
+	}
+	if firstPerson {
+		opts.YouAre = false
+	}
+	return nil
+}
+
+// A Diff is a turn where the engine's output differs from the transcript.
+type Diff struct {
+	Turn    int    // the turn, where 0 is the start of the game
+	Command string // the command for the turn, or "" for turn 0
+	Got     string // the engine's output, normalized
+	Want    string // the transcript's output, normalized
+}
+
+// String describes the difference.
+func (d *Diff) String() string {
+	return fmt.Sprintf("turn %d (%q):\n  got:  %q\n  want: %q", d.Turn, d.Command, d.Got, d.Want)
+}
+
+// A Result is the outcome of comparing a test case.
+type Result struct {
+	Turns int     // the number of turns compared
+	Diffs []*Diff // the turns that differ
+}
+
+// Run plays a script through the engine and compares the output with the
+// transcript, turn by turn.
+func Run(g *game.Game, s *Script, transcript string) *Result {
+	got := Play(g, s)
+	want := Split(transcript, s.Commands)
+
+	r := &Result{}
+	for i := 0; i < len(got) || i < len(want); i++ {
+		d := &Diff{Turn: i}
+		if i > 0 && i <= len(s.Commands) {
+			d.Command = s.Commands[i-1]
+		}
+		if i < len(got) {
+			d.Got = got[i]
+		}
+		if i < len(want) {
+			d.Want = want[i]
+		}
+		r.Turns++
+		if d.Got != d.Want {
+			r.Diffs = append(r.Diffs, d)
+		}
+	}
+	return r
+}
+
+// Play plays a script through the engine, returning the normalized output of
+// each turn, starting with the start of the game.  Play stops early if the
+// game ends.
+func Play(g *game.Game, s *Script) []string {
+	g.Options = s.Options
+	g.Restart()
+	g.Chance = game.NewScottFreeRand(s.Seed).Percent
+
+	var out []string
+	render := func() {
+		b := &bytes.Buffer{}
+		t := term.New(b, nil, nil)
+		for _, ev := range g.Events() {
+			// ScottFree asks for a filename when saving, which the
+			// transcript covers; pauses leave nothing behind.
+			if ev.Type != game.DelayEvent && ev.Type != game.SaveEvent {
+				t.Render([]*game.Event{ev})
+			}
+		}
+		out = append(out, Normalize(b.String()))
+	}
+
+	g.Start()
+	render()
+	for _, cmd := range s.Commands {
+		if g.IsOver() {
+			break
+		}
+		g.Command(cmd)
+		render()
+	}
+	return out
+}
+
+// Split divides a transcript into the normalized output of each turn,
+// dropping the commands echoed after each prompt.
+func Split(transcript string, commands []string) []string {
+	parts := strings.Split(transcript, Prompt)
+	out := make([]string, 0, len(parts))
+	for i, p := range parts {
+		if i > 0 && i <= len(commands) {
+			line := p
+			rest := ""
+			if j := strings.IndexByte(p, '\n'); j >= 0 {
+				line, rest = p[:j], p[j+1:]
+			}
+			if strings.EqualFold(strings.TrimSpace(line), commands[i-1]) {
+				p = rest
+			}
+		}
+		if i == len(parts)-1 && i > len(commands) && strings.TrimSpace(p) == "" {
+			// The final prompt, waiting for a command that never came.
+			break
+		}
+		out = append(out, Normalize(p))
+	}
+	return out
+}
+
+// Normalize collapses each run of whitespace into a single space and trims it
+// from the ends.
+func Normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
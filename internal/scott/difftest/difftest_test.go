@@ -0,0 +1,141 @@
+package difftest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// gamesDir is where the bundled game files live.
+const gamesDir = "../../../games"
+
+// TestScottFreeTranscripts compares the engine with ScottFree for every script
+// in testdata/scottfree, each of which must have a transcript captured from
+// ScottFree alongside it.  Scripts still waiting for one are kept in the
+// pending directory instead.  See the README there for how to capture them.
+func TestScottFreeTranscripts(t *testing.T) {
+	scripts, err := filepath.Glob("testdata/scottfree/*.in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scripts) == 0 {
+		t.Skip("No ScottFree transcripts have been captured yet; see testdata/scottfree/README.md")
+	}
+
+	for _, path := range scripts {
+		g, s, ok := load(t, path)
+		if !ok {
+			continue
+		}
+		transcript, err := ioutil.ReadFile(strings.TrimSuffix(path, ".in") + ".out")
+		if err != nil {
+			t.Errorf("%s: no transcript: %v", path, err)
+			continue
+		}
+
+		r := Run(g, s, string(transcript))
+		t.Logf("%s: %d of %d turns match", path, r.Turns-len(r.Diffs), r.Turns)
+		for _, d := range r.Diffs {
+			t.Errorf("%s: %v", path, d)
+		}
+	}
+}
+
+// TestPendingScripts makes sure that the scripts waiting for transcripts at
+// least play, and that none has been captured without being moved out of the
+// pending directory.
+func TestPendingScripts(t *testing.T) {
+	scripts, err := filepath.Glob("testdata/scottfree/pending/*.in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range scripts {
+		g, s, ok := load(t, path)
+		if !ok {
+			continue
+		}
+		Play(g, s)
+		if _, err := os.Stat(strings.TrimSuffix(path, ".in") + ".out"); err == nil {
+			t.Errorf("%s: has a transcript, so it should be moved up to testdata/scottfree", path)
+		}
+		t.Logf("%s: no transcript yet", path)
+	}
+}
+
+// load loads a script and its game, reporting any error.
+func load(t *testing.T, path string) (*game.Game, *Script, bool) {
+	t.Helper()
+	s, err := LoadScript(path)
+	if err != nil {
+		t.Errorf("%v", err)
+		return nil, nil, false
+	}
+	g, err := game.LoadFromFile(filepath.Join(gamesDir, s.Game))
+	if err != nil {
+		t.Errorf("%s: %v", path, err)
+		return nil, nil, false
+	}
+	return g, s, true
+}
+
+// transcript renders output in the form ScottFree prints it, with each
+// command echoed after the prompt.
+func transcript(out []string, commands []string) string {
+	var b strings.Builder
+	for i, o := range out {
+		if i > 0 {
+			b.WriteString(Prompt + commands[i-1] + "\n")
+		}
+		b.WriteString(o + "\n\n")
+	}
+	b.WriteString(Prompt)
+	return b.String()
+}
+
+func TestRunFindsDifferences(t *testing.T) {
+	g, err := game.LoadFromFile(filepath.Join(gamesDir, "adv01.dat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Script{
+		Game:     "adv01.dat",
+		Seed:     1,
+		Commands: []string{"CLIMB TREE", "GET KEYS", "DOWN", "INVENTORY"},
+	}
+
+	out := Play(g, s)
+	if len(out) != len(s.Commands)+1 {
+		t.Fatalf("Play returned %d turns, want %d", len(out), len(s.Commands)+1)
+	}
+	if r := Run(g, s, transcript(out, s.Commands)); len(r.Diffs) != 0 || r.Turns != len(out) {
+		t.Errorf("Run on the engine's own output: got %d turns with differences %v", r.Turns, r.Diffs)
+	}
+
+	out[2] = "Something else entirely."
+	r := Run(g, s, transcript(out, s.Commands))
+	if len(r.Diffs) != 1 || r.Diffs[0].Turn != 2 || r.Diffs[0].Command != "GET KEYS" {
+		t.Errorf("Run with turn 2 changed: got differences %v, want one in turn 2", r.Diffs)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	for _, tc := range []struct {
+		transcript string
+		commands   []string
+		want       []string
+	}{
+		{"Hello\n  there\n", nil, []string{"Hello there"}},
+		{"Start\n" + Prompt + "look\nA room.\n" + Prompt, []string{"LOOK"}, []string{"Start", "A room."}},
+		{"Start\n" + Prompt + "A room.\n", []string{"LOOK"}, []string{"Start", "A room."}},
+		{"Start\n" + Prompt + "look\nYou died.\n", []string{"LOOK", "NORTH"}, []string{"Start", "You died."}},
+	} {
+		got := Split(tc.transcript, tc.commands)
+		if strings.Join(got, "|") != strings.Join(tc.want, "|") {
+			t.Errorf("Split(%q): got %q, want %q", tc.transcript, got, tc.want)
+		}
+	}
+}
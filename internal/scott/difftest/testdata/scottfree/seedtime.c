@@ -0,0 +1,19 @@
+/*
+ * seedtime pins down the seed that ScottFree passes to srand(), for capturing
+ * transcripts.  ScottFree seeds with time(NULL), so this replaces time() with
+ * one that returns the value of $SCOTTFREE_SEED.  Build and use it with:
+ *
+ *   gcc -shared -fPIC -o seedtime.so seedtime.c
+ *   SCOTTFREE_SEED=1234 LD_PRELOAD=./seedtime.so scottfree adv01.dat
+ */
+#include <stdlib.h>
+#include <time.h>
+
+time_t time(time_t *t)
+{
+	const char *s = getenv("SCOTTFREE_SEED");
+	time_t v = s ? (time_t)strtoul(s, NULL, 10) : 0;
+	if (t)
+		*t = v;
+	return v;
+}
@@ -0,0 +1,565 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+const (
+	NumConditions = 5 // number of conditions in each action
+	NumCommands   = 4 // number of commands in each action
+)
+
+const (
+	GetVerb  = 10 // used for picking up items ("GET")
+	DropVerb = 18 // used for dropping items ("DROP")
+)
+
+// MessageIndex returns the index into Game.Messages printed by the given
+// command type, if it is a message command at all.  Note that, following
+// ScottFree, MESSAGE_0 prints message 1, and so on; message 0 is never printed.
+func MessageIndex(t scottpb.ActionType) (int, bool) {
+	switch {
+	case t >= scottpb.ActionType_MESSAGE_0 && t <= scottpb.ActionType_MESSAGE_50:
+		return int(t), true
+	case t >= scottpb.ActionType_MESSAGE_51 && t <= scottpb.ActionType_MESSAGE_99:
+		return int(t) - 50, true
+	default:
+		return 0, false
+	}
+}
+
+// NumParams returns the number of parameters consumed by the given command
+// type.  Parameters are supplied in order by the PARAMETER conditions of the
+// action containing the command.
+func NumParams(t scottpb.ActionType) int {
+	switch t {
+	case scottpb.ActionType_GET_ITEM,
+		scottpb.ActionType_DROP_ITEM,
+		scottpb.ActionType_MOVE_PLAYER,
+		scottpb.ActionType_REMOVE_ITEM,
+		scottpb.ActionType_SET_BIT,
+		scottpb.ActionType_REMOVE_ITEM2,
+		scottpb.ActionType_CLEAR_BIT,
+		scottpb.ActionType_TAKE_ITEM,
+		scottpb.ActionType_SET_COUNTER,
+		scottpb.ActionType_SELECT_COUNTER,
+		scottpb.ActionType_ADD_TO_COUNTER,
+		scottpb.ActionType_SUB_FROM_COUNTER,
+		scottpb.ActionType_SWAP_LOCATION_N,
+		scottpb.ActionType_DRAW_PICTURE:
+		return 1
+	case scottpb.ActionType_PUT_ITEM,
+		scottpb.ActionType_SWAP_ITEMS,
+		scottpb.ActionType_MOVE_ITEM_TO_ITEM:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// Params returns the parameter values supplied by the PARAMETER conditions of
+// the given action, in order.
+func Params(a *scottpb.Action) []int32 {
+	var ps []int32
+	for _, c := range a.Conditions {
+		if c.Type == scottpb.ConditionType_PARAMETER {
+			ps = append(ps, c.Value)
+		}
+	}
+	return ps
+}
+
+// performActions runs through the action table for the given command, much as
+// ScottFree does.  For automatic actions (verb 0), every action whose random
+// chance comes up is performed.  For other commands, the first action whose
+// words and conditions match is performed.  If no action matches, GET and DROP
+// are handled directly for items with an autograb.
+//
+// Once an action performs CONTINUE, every continuation action (verb 0, noun 0)
+// that immediately follows it is tried in turn, whether or not the ones before
+// it succeeded, until the first action that isn't a continuation.  As in
+// ScottFree, the random chance is rolled for each continuation action too,
+// even though it never matters, so that the random events stay in step.
+//
+// The result is Unknown if no action had matching words, Unsuccessful if the
+// words matched but the conditions didn't, and Success otherwise.  For
+// commands typed by the player, the usual complaint is printed on failure.
+func (g *Game) performActions(pd *ParseData) Status {
+	vb, no := pd.VerbIndex, pd.NounIndex
+	st := Unknown
+	cont := false
+
+	for i, a := range g.DB.Game.Actions {
+		isCont := a.VerbIndex == 0 && a.NounIndex == 0
+		if !isCont {
+			if vb != AutoVerb && cont {
+				break
+			}
+			cont = false
+		}
+
+		var match bool
+		switch {
+		case int(a.VerbIndex) != vb && !(cont && isCont):
+			match = false
+		case a.VerbIndex == AutoVerb:
+			match = g.randomPercent(int(a.NounIndex)) || cont
+		default:
+			match = a.NounIndex == 0 || int(a.NounIndex) == no
+		}
+		if !match {
+			continue
+		}
+
+		if st == Unknown {
+			st = Unsuccessful
+		}
+		g.traceAction(i, a)
+		g.coverAction(i)
+		if ok, more := g.performLine(i, a, pd); ok {
+			g.coverFired(i)
+			st = Success
+			if more {
+				cont = true
+			}
+			if g.State.GameOver || (vb != AutoVerb && !cont) {
+				return st
+			}
+		}
+	}
+
+	if st == Success || vb == AutoVerb || g.noSysCmd {
+		return st
+	}
+	switch vb {
+	case GetVerb:
+		return g.getItem(pd)
+	case DropVerb:
+		return g.dropItem(pd)
+	}
+	if st == Unknown {
+		g.print("I don't understand your command.\n")
+	} else {
+		g.print("I can't do that yet.\n")
+	}
+	return st
+}
+
+// randomPercent returns true with the given percentage chance.
+func (g *Game) randomPercent(n int) bool {
+	if g.Chance != nil {
+		return g.Chance(n)
+	}
+	if g.rng == nil {
+		g.Seed(time.Now().UnixNano())
+	}
+	return g.rng.Intn(100) < n
+}
+
+// performLine checks the conditions of a single action (at index |i| in the
+// table) and, if they are all met, performs its commands.  It reports whether
+// the action was performed and whether it asked for the following
+// continuation actions to be performed.
+func (g *Game) performLine(i int, a *scottpb.Action, pd *ParseData) (ok, cont bool) {
+	for j, c := range a.Conditions {
+		ok := g.checkCondition(c)
+		g.traceCondition(c, ok)
+		g.coverCondition(i, j, ok)
+		if !ok {
+			return false, false
+		}
+	}
+
+	params := Params(a)
+	for _, t := range a.Actions {
+		n := NumParams(t)
+		if len(params) < n {
+			// Malformed action; supply zeroes rather than crash.
+			params = append(params, make([]int32, n-len(params))...)
+		}
+		g.traceCommand(t, params[0:n])
+		if g.performCommand(t, params[0:n], pd) {
+			cont = true
+		}
+		params = params[n:]
+		if g.State.GameOver {
+			break
+		}
+	}
+	return true, cont
+}
+
+// checkCondition checks if a single condition holds.
+func (g *Game) checkCondition(c *scottpb.Condition) bool {
+	st := g.State
+	v := c.Value
+	switch c.Type {
+	case scottpb.ConditionType_PARAMETER:
+		return true
+	case scottpb.ConditionType_ITEM_CARRIED:
+		return g.itemLocation(v) == Inventory
+	case scottpb.ConditionType_ITEM_IN_ROOM:
+		return g.itemLocation(v) == st.Location
+	case scottpb.ConditionType_ITEM_PRESENT:
+		return g.itemLocation(v) == Inventory || g.itemLocation(v) == st.Location
+	case scottpb.ConditionType_PLAYER_IN_ROOM:
+		return st.Location == v
+	case scottpb.ConditionType_ITEM_NOT_IN_ROOM:
+		return g.itemLocation(v) != st.Location
+	case scottpb.ConditionType_ITEM_NOT_CARRIED:
+		return g.itemLocation(v) != Inventory
+	case scottpb.ConditionType_PLAYER_NOT_IN_ROOM:
+		return st.Location != v
+	case scottpb.ConditionType_BIT_SET:
+		return g.flag(v)
+	case scottpb.ConditionType_BIT_CLEAR:
+		return !g.flag(v)
+	case scottpb.ConditionType_INVENTORY_NOT_EMPTY:
+		return g.countCarried() != 0
+	case scottpb.ConditionType_INVENTORY_EMPTY:
+		return g.countCarried() == 0
+	case scottpb.ConditionType_ITEM_NOT_PRESENT:
+		return g.itemLocation(v) != Inventory && g.itemLocation(v) != st.Location
+	case scottpb.ConditionType_ITEM_IN_GAME:
+		return g.itemLocation(v) != 0
+	case scottpb.ConditionType_ITEM_NOT_IN_GAME:
+		return g.itemLocation(v) == 0
+	case scottpb.ConditionType_COUNTER_LE:
+		return st.Counter <= v
+	case scottpb.ConditionType_COUNTER_GE:
+		return st.Counter > v // sic; this is what ScottFree does
+	case scottpb.ConditionType_ITEM_MOVED:
+		return g.itemLocation(v) == g.initialLocation(v)
+	case scottpb.ConditionType_ITEM_NOT_MOVED:
+		return g.itemLocation(v) != g.initialLocation(v)
+	case scottpb.ConditionType_COUNTER_EQ:
+		return st.Counter == v
+	default:
+		return true
+	}
+}
+
+// performCommand performs a single command with its parameters, returning
+// true if it was CONTINUE.
+func (g *Game) performCommand(t scottpb.ActionType, ps []int32, pd *ParseData) bool {
+	if m, ok := MessageIndex(t); ok {
+		if m < len(g.DB.Game.Messages) {
+			g.print(g.DB.Game.Messages[m] + "\n")
+		}
+		return false
+	}
+
+	st := g.State
+	switch t {
+	case scottpb.ActionType_NOTHING:
+		// pass
+	case scottpb.ActionType_GET_ITEM:
+		if g.countCarried() >= int(g.DB.Game.Header.MaxInventory) {
+			g.print(g.person("I've too much to carry!\n", "You are carrying too much.\n"))
+			break
+		}
+		g.moveItem(ps[0], Inventory)
+	case scottpb.ActionType_DROP_ITEM:
+		g.moveItem(ps[0], st.Location)
+	case scottpb.ActionType_MOVE_PLAYER:
+		g.movePlayer(ps[0])
+	case scottpb.ActionType_REMOVE_ITEM, scottpb.ActionType_REMOVE_ITEM2:
+		g.moveItem(ps[0], 0)
+	case scottpb.ActionType_SET_DARKNESS:
+		st.Flags[DarkFlag] = true
+	case scottpb.ActionType_CLEAR_DARKNESS:
+		st.Flags[DarkFlag] = false
+	case scottpb.ActionType_SET_BIT:
+		g.setFlag(ps[0], true)
+	case scottpb.ActionType_CLEAR_BIT:
+		g.setFlag(ps[0], false)
+	case scottpb.ActionType_DEATH:
+		g.print(g.person("I am dead.\n", "You are dead.\n"))
+		g.KillPlayer()
+		g.describe()
+	case scottpb.ActionType_PUT_ITEM:
+		g.moveItem(ps[0], ps[1])
+	case scottpb.ActionType_GAME_OVER:
+		g.endGame()
+	case scottpb.ActionType_DESCRIBE_ROOM, scottpb.ActionType_DESCRIBE_ROOM2:
+		g.describe()
+	case scottpb.ActionType_SCORE:
+		g.score()
+	case scottpb.ActionType_INVENTORY:
+		g.inventory()
+	case scottpb.ActionType_SET_BIT_0:
+		st.Flags[0] = true
+	case scottpb.ActionType_CLEAR_BIT_0:
+		st.Flags[0] = false
+	case scottpb.ActionType_REFILL_LIGHT:
+		st.LightTime = g.DB.Game.Header.LightDuration
+		g.moveItem(LightItem, Inventory)
+		st.Flags[LightOutFlag] = false
+	case scottpb.ActionType_CLEAR_SCREEN:
+		g.emit(ClearScreenEvent)
+	case scottpb.ActionType_SAVE_GAME:
+		g.emit(SaveEvent)
+	case scottpb.ActionType_SWAP_ITEMS:
+		l0, l1 := g.itemLocation(ps[0]), g.itemLocation(ps[1])
+		g.moveItem(ps[0], l1)
+		g.moveItem(ps[1], l0)
+	case scottpb.ActionType_CONTINUE:
+		return true
+	case scottpb.ActionType_TAKE_ITEM:
+		g.moveItem(ps[0], Inventory)
+	case scottpb.ActionType_MOVE_ITEM_TO_ITEM:
+		g.moveItem(ps[0], g.itemLocation(ps[1]))
+	case scottpb.ActionType_DECREMENT_COUNTER:
+		if st.Counter >= 0 {
+			st.Counter--
+		}
+	case scottpb.ActionType_PRINT_COUNTER:
+		g.print(fmt.Sprintf("%d ", st.Counter))
+	case scottpb.ActionType_SET_COUNTER:
+		st.Counter = ps[0]
+	case scottpb.ActionType_SWAP_LOCATION:
+		loc := st.Location
+		g.movePlayer(st.SavedRoom)
+		st.SavedRoom = loc
+	case scottpb.ActionType_SELECT_COUNTER:
+		if r := ps[0]; r >= 0 && r < NumCounters {
+			st.Counter, st.Counters[r] = st.Counters[r], st.Counter
+		}
+	case scottpb.ActionType_ADD_TO_COUNTER:
+		st.Counter += ps[0]
+	case scottpb.ActionType_SUB_FROM_COUNTER:
+		st.Counter -= ps[0]
+		if st.Counter < -1 {
+			st.Counter = -1
+		}
+	case scottpb.ActionType_ECHO_NOUN:
+		g.print(pd.Noun)
+	case scottpb.ActionType_ECHO_NOUN_CR:
+		g.print(pd.Noun + "\n")
+	case scottpb.ActionType_ECHO_CR:
+		g.print("\n")
+	case scottpb.ActionType_SWAP_LOCATION_N:
+		if r := ps[0]; r >= 0 && r < NumCounters {
+			loc := st.Location
+			g.movePlayer(st.SavedRooms[r])
+			st.SavedRooms[r] = loc
+		}
+	case scottpb.ActionType_DELAY:
+		g.emit(DelayEvent)
+	case scottpb.ActionType_DRAW_PICTURE:
+		// We don't do pictures.
+	}
+	return false
+}
+
+// getItem handles GET for items with an autograb, when no action took care of
+// the command.  "GET ALL" picks up everything in the room that can be taken.
+func (g *Game) getItem(pd *ParseData) Status {
+	st := g.State
+	if pd.Noun == "ALL" {
+		if g.IsDark() {
+			g.print("It is dark.\n")
+			return Unsuccessful
+		}
+		taken := false
+		for i, it := range g.DB.Game.Items {
+			if st.ItemLocations[i] != st.Location || it.Autograb == "" || it.Autograb[0] == '*' {
+				continue
+			}
+			g.noSysCmd = true
+			g.performActions(&ParseData{
+				Verb:      pd.Verb,
+				VerbIndex: pd.VerbIndex,
+				Noun:      it.Autograb,
+				NounIndex: g.DB.autograbs[i],
+			})
+			g.noSysCmd = false
+			if g.countCarried() >= int(g.DB.Game.Header.MaxInventory) {
+				g.print(g.person("I've too much to carry.\n", "You are carrying too much.\n"))
+				return Unsuccessful
+			}
+			g.moveItem(int32(i), Inventory)
+			g.print(it.Description + ": O.K.\n")
+			taken = true
+		}
+		if !taken {
+			g.print("Nothing taken.\n")
+		}
+		return Success
+	}
+
+	if pd.Noun == "" {
+		g.print("What?\n")
+		return Unsuccessful
+	}
+	if g.countCarried() >= int(g.DB.Game.Header.MaxInventory) {
+		g.print(g.person("I've too much to carry.\n", "You are carrying too much.\n"))
+		return Unsuccessful
+	}
+	i := g.matchItem(pd.Noun, st.Location)
+	if i < 0 {
+		g.print(g.person("It's beyond my power to do that.\n", "It is beyond your power to do that.\n"))
+		return Unsuccessful
+	}
+	g.moveItem(int32(i), Inventory)
+	g.print("O.K.\n")
+	return Success
+}
+
+// dropItem handles DROP for items with an autograb, when no action took care
+// of the command.  "DROP ALL" drops everything carried that can be dropped.
+func (g *Game) dropItem(pd *ParseData) Status {
+	st := g.State
+	if pd.Noun == "ALL" {
+		dropped := false
+		for i, it := range g.DB.Game.Items {
+			if st.ItemLocations[i] != Inventory || it.Autograb == "" || it.Autograb[0] == '*' {
+				continue
+			}
+			g.noSysCmd = true
+			g.performActions(&ParseData{
+				Verb:      pd.Verb,
+				VerbIndex: pd.VerbIndex,
+				Noun:      it.Autograb,
+				NounIndex: g.DB.autograbs[i],
+			})
+			g.noSysCmd = false
+			g.moveItem(int32(i), st.Location)
+			g.print(it.Description + ": O.K.\n")
+			dropped = true
+		}
+		if !dropped {
+			g.print("Nothing dropped.\n")
+		}
+		return Success
+	}
+
+	if pd.Noun == "" {
+		g.print("What?\n")
+		return Unsuccessful
+	}
+	i := g.matchItem(pd.Noun, Inventory)
+	if i < 0 {
+		g.print(g.person("It's beyond my power to do that.\n", "It is beyond your power to do that.\n"))
+		return Unsuccessful
+	}
+	g.moveItem(int32(i), st.Location)
+	g.print("O.K.\n")
+	return Success
+}
+
+// matchItem finds the item at the given location whose autograb matches the
+// given word, or returns -1.
+func (g *Game) matchItem(word string, loc int32) int {
+	n := int(g.DB.Game.Header.WordLength)
+	word = strings.ToUpper(word)
+	if len(word) > n {
+		word = word[0:n]
+	}
+	for i, it := range g.DB.Game.Items {
+		if g.State.ItemLocations[i] != loc || it.Autograb == "" {
+			continue
+		}
+		auto := strings.ToUpper(it.Autograb)
+		if len(auto) > n {
+			auto = auto[0:n]
+		}
+		if auto == word {
+			return i
+		}
+	}
+	return -1
+}
+
+// itemLocation returns the location of the given item, or 0 if there is no
+// such item.
+func (g *Game) itemLocation(i int32) int32 {
+	if i < 0 || int(i) >= len(g.State.ItemLocations) {
+		return 0
+	}
+	return g.State.ItemLocations[i]
+}
+
+// initialLocation returns the starting location of the given item.
+func (g *Game) initialLocation(i int32) int32 {
+	if i < 0 || int(i) >= len(g.DB.Game.Items) {
+		return 0
+	}
+	if loc := g.DB.Game.Items[i].Location; loc != Inventory255 {
+		return loc
+	}
+	return Inventory
+}
+
+// moveItem moves an item to a new location, noting if the room needs to be
+// described again.
+func (g *Game) moveItem(i int32, loc int32) {
+	if i < 0 || int(i) >= len(g.State.ItemLocations) {
+		return
+	}
+	locs := g.State.ItemLocations
+	if locs[i] == g.State.Location || loc == g.State.Location {
+		g.redraw = true
+	}
+	locs[i] = loc
+}
+
+// movePlayer moves the player to a new room.
+func (g *Game) movePlayer(room int32) {
+	if room < 0 || int(room) >= len(g.DB.Game.Rooms) {
+		return
+	}
+	g.State.Location = room
+	g.redraw = true
+}
+
+// flag returns the value of the given flag.
+func (g *Game) flag(f int32) bool {
+	return f >= 0 && f < NumFlags && g.State.Flags[f]
+}
+
+// setFlag sets the value of the given flag.
+func (g *Game) setFlag(f int32, v bool) {
+	if f >= 0 && f < NumFlags {
+		g.State.Flags[f] = v
+	}
+}
+
+// countCarried returns the number of items the player is carrying.
+func (g *Game) countCarried() int {
+	n := 0
+	for _, loc := range g.State.ItemLocations {
+		if loc == Inventory {
+			n++
+		}
+	}
+	return n
+}
+
+// score prints the player's score, and ends the game if every treasure has
+// been stored.
+func (g *Game) score() {
+	stored, total := g.Score()
+	rating := 0
+	if total > 0 {
+		rating = stored * 100 / total
+	}
+	g.print(fmt.Sprintf(g.person("I've stored", "You have stored")+" %d treasures.  On a scale of 0 to 100, that rates %d.\n", stored, rating))
+	if stored == total {
+		g.print("Well done.\n")
+		g.endGame()
+	}
+}
+
+// inventory prints the items the player is carrying.
+func (g *Game) inventory() {
+	items := g.Carried()
+	if len(items) == 0 {
+		items = []string{"Nothing"}
+	}
+	g.print(g.person("I'm carrying:\n", "You are carrying:\n") + strings.Join(items, " - ") + ".\n")
+}
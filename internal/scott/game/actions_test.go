@@ -0,0 +1,150 @@
+package game
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+// jumpVerb is the one verb of the games made by testGame, besides the usual
+// AUTO and GO.
+const jumpVerb = 2
+
+// testGame makes a minimal game around the given actions, in which message n
+// is the nth letter of the alphabet.
+func testGame(t *testing.T, actions ...*scottpb.Action) *Game {
+	pb := &scottpb.Game{
+		Header: &scottpb.Header{
+			NumItems:      10,
+			NumActions:    int32(len(actions)),
+			NumWords:      7,
+			NumRooms:      2,
+			MaxInventory:  6,
+			StartingRoom:  1,
+			WordLength:    4,
+			NumMessages:   27,
+			LightDuration: -1,
+		},
+		Actions: actions,
+		Rooms: []*scottpb.Room{
+			{Exits: make([]int32, 6)},
+			{Description: "room", Exits: make([]int32, 6)},
+		},
+		Footer: &scottpb.Footer{},
+	}
+	for _, w := range []string{"AUTO", "GO", "JUMP", "", "", "", ""} {
+		pb.Verbs = append(pb.Verbs, &scottpb.Word{Word: w})
+	}
+	for _, w := range []string{"ANY", "NORT", "SOUT", "EAST", "WEST", "UP", "DOWN"} {
+		pb.Nouns = append(pb.Nouns, &scottpb.Word{Word: w})
+	}
+	for i := 0; i < 10; i++ {
+		pb.Items = append(pb.Items, &scottpb.Item{Description: "thing"})
+	}
+	pb.Messages = append(pb.Messages, "")
+	for c := 'A'; c <= 'Z'; c++ {
+		pb.Messages = append(pb.Messages, string(c))
+	}
+
+	db, err := newDatabase(pb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db.NewGame()
+}
+
+// action makes an action that prints the given messages (by letter) and then
+// performs the given commands, if the conditions hold.
+func action(verb, noun int32, conds []*scottpb.Condition, msgs string, cmds ...scottpb.ActionType) *scottpb.Action {
+	a := &scottpb.Action{VerbIndex: verb, NounIndex: noun, Conditions: conds}
+	for _, m := range msgs {
+		a.Actions = append(a.Actions, scottpb.ActionType(m-'A'+1))
+	}
+	a.Actions = append(a.Actions, cmds...)
+	return a
+}
+
+// never is a condition that never holds in the games made by testGame, since
+// nothing is ever carried.
+var never = []*scottpb.Condition{{Type: scottpb.ConditionType_ITEM_CARRIED, Value: 1}}
+
+// output returns the words printed since the last call, separated by spaces.
+func output(g *Game) string {
+	var b strings.Builder
+	for _, ev := range g.Events() {
+		if ev.Type == TextEvent {
+			b.WriteString(ev.Text)
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func TestContinuationChain(t *testing.T) {
+	g := testGame(t,
+		action(jumpVerb, 0, nil, "A", scottpb.ActionType_CONTINUE),
+		action(0, 0, nil, "B"),
+		action(0, 0, never, "X"), // fails, but doesn't end the chain
+		action(0, 0, nil, "C"),
+		action(jumpVerb, 0, nil, "D"), // ends the chain, so isn't tried
+		action(0, 0, nil, "E"),
+	)
+	if st := g.Command("JUMP"); st != Success {
+		t.Errorf("Command returned %v, want Success", st)
+	}
+	if got, want := output(g), "A B C"; got != want {
+		t.Errorf("JUMP printed %q, want %q", got, want)
+	}
+}
+
+func TestContinuationChainAutomatic(t *testing.T) {
+	g := testGame(t,
+		action(0, 100, nil, "A", scottpb.ActionType_CONTINUE),
+		action(0, 0, nil, "B"),
+		action(0, 0, never, "X"),
+		action(0, 0, nil, "C"),
+		action(0, 100, nil, "D"), // not part of the chain, but still performed
+		action(0, 0, nil, "E"),   // not performed, since D didn't continue
+	)
+	g.ExecuteDefault()
+	if got, want := output(g), "A B C D"; got != want {
+		t.Errorf("Automatic actions printed %q, want %q", got, want)
+	}
+}
+
+func TestContinuationRollsChance(t *testing.T) {
+	g := testGame(t,
+		action(0, 100, nil, "A", scottpb.ActionType_CONTINUE),
+		action(0, 0, nil, "B"),
+		action(0, 50, nil, "C"),
+		action(0, 0, nil, "D"),
+		action(jumpVerb, 0, nil, "E", scottpb.ActionType_CONTINUE),
+		action(0, 0, nil, "F"),
+	)
+	var rolls []int
+	g.Chance = func(n int) bool {
+		rolls = append(rolls, n)
+		return n >= 100
+	}
+
+	// ScottFree rolls for every action with verb 0 that it looks at, even
+	// continuations, which can't come up since their chance is 0%.  That
+	// includes F, which looks like an automatic action outside of a chain.
+	g.ExecuteDefault()
+	if want := []int{100, 0, 50, 0, 0}; !reflect.DeepEqual(rolls, want) {
+		t.Errorf("Automatic actions rolled %v, want %v", rolls, want)
+	}
+	if got, want := output(g), "A B"; got != want {
+		t.Errorf("Automatic actions printed %q, want %q", got, want)
+	}
+
+	rolls = nil
+	g.Execute(&ParseData{Verb: "JUMP", VerbIndex: jumpVerb, NounIndex: UnknownWord})
+	if want := []int{0}; !reflect.DeepEqual(rolls, want) {
+		t.Errorf("JUMP rolled %v, want %v", rolls, want)
+	}
+	if got, want := output(g), "E F"; got != want {
+		t.Errorf("JUMP printed %q, want %q", got, want)
+	}
+}
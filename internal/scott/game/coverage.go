@@ -0,0 +1,107 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+// Coverage records how much of the action table has been exercised: which
+// actions were tried and performed, and how often each of their conditions
+// came out true or false.  Set Game.Coverage to record it.  Its JSON form can
+// be saved and merged with the coverage from other runs of the same game.
+type Coverage struct {
+	Actions []*ActionCoverage `json:"actions"` // indexed as in Game.Actions
+}
+
+// ActionCoverage is the coverage of a single action.
+type ActionCoverage struct {
+	Tried int   `json:"tried"` // times the action was tried (see Tracer.Action)
+	Fired int   `json:"fired"` // times its conditions held and its commands were performed
+	True  []int `json:"true"`  // times each condition was true, indexed as in Action.Conditions
+	False []int `json:"false"` // times each condition was false
+}
+
+// NewCoverage initializes an empty Coverage for the given game.
+func NewCoverage(pb *scottpb.Game) *Coverage {
+	c := &Coverage{Actions: make([]*ActionCoverage, len(pb.Actions))}
+	for i, a := range pb.Actions {
+		c.Actions[i] = &ActionCoverage{
+			True:  make([]int, len(a.Conditions)),
+			False: make([]int, len(a.Conditions)),
+		}
+	}
+	return c
+}
+
+// Merge adds the counts from another Coverage for the same game.
+func (c *Coverage) Merge(o *Coverage) error {
+	if len(o.Actions) != len(c.Actions) {
+		return fmt.Errorf("Coverage is for a game with %d actions, not %d", len(o.Actions), len(c.Actions))
+	}
+	for i, a := range c.Actions {
+		b := o.Actions[i]
+		if b == nil || len(b.True) != len(a.True) || len(b.False) != len(a.False) {
+			return fmt.Errorf("Coverage for action %d has the wrong number of conditions", i)
+		}
+	}
+
+	for i, a := range c.Actions {
+		b := o.Actions[i]
+		a.Tried += b.Tried
+		a.Fired += b.Fired
+		for j := range a.True {
+			a.True[j] += b.True[j]
+			a.False[j] += b.False[j]
+		}
+	}
+	return nil
+}
+
+// Fired returns the number of actions that were performed at least once.
+func (c *Coverage) Fired() int {
+	n := 0
+	for _, a := range c.Actions {
+		if a.Fired > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// coverAction records that an action was tried, if coverage is being recorded.
+func (g *Game) coverAction(i int) {
+	if a := g.coverage(i); a != nil {
+		a.Tried++
+	}
+}
+
+// coverCondition records the result of a condition, if coverage is being
+// recorded.
+func (g *Game) coverCondition(i, j int, ok bool) {
+	a := g.coverage(i)
+	if a == nil || j >= len(a.True) {
+		return
+	}
+	if ok {
+		a.True[j]++
+	} else {
+		a.False[j]++
+	}
+}
+
+// coverFired records that an action was performed, if coverage is being
+// recorded.
+func (g *Game) coverFired(i int) {
+	if a := g.coverage(i); a != nil {
+		a.Fired++
+	}
+}
+
+// coverage returns the coverage of an action, or nil if there is none.
+func (g *Game) coverage(i int) *ActionCoverage {
+	if g.Coverage == nil || i >= len(g.Coverage.Actions) {
+		return nil
+	}
+	return g.Coverage.Actions[i]
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+// coverageGame makes a game with a JUMP command that starts a continuation
+// chain, one of whose lines fails.
+func coverageGame(t *testing.T) *Game {
+	g := testGame(t,
+		action(jumpVerb, 0, nil, "A", scottpb.ActionType_CONTINUE),
+		action(0, 0, never, "X"),
+		action(0, 0, nil, "B"),
+	)
+	g.Coverage = NewCoverage(g.DB.Game)
+	return g
+}
+
+func TestCoverage(t *testing.T) {
+	g := coverageGame(t)
+	g.Command("JUMP")
+	g.Command("JUMP")
+
+	want := []ActionCoverage{
+		{Tried: 2, Fired: 2, True: []int{}, False: []int{}},
+		{Tried: 2, Fired: 0, True: []int{0}, False: []int{2}},
+		{Tried: 2, Fired: 2, True: []int{}, False: []int{}},
+	}
+	for i, a := range g.Coverage.Actions {
+		if !reflect.DeepEqual(*a, want[i]) {
+			t.Errorf("Action %d: got coverage %+v, want %+v", i, *a, want[i])
+		}
+	}
+	if got := g.Coverage.Fired(); got != 2 {
+		t.Errorf("Fired() = %d, want 2", got)
+	}
+}
+
+func TestCoverageMerge(t *testing.T) {
+	g1, g2 := coverageGame(t), coverageGame(t)
+	g1.Command("JUMP")
+	g2.Command("JUMP")
+	g2.Command("JUMP")
+
+	// Go through JSON, as cover_scott does.
+	data, err := json.Marshal(g2.Coverage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := &Coverage{}
+	if err := json.Unmarshal(data, o); err != nil {
+		t.Fatal(err)
+	}
+	if err := g1.Coverage.Merge(o); err != nil {
+		t.Fatal(err)
+	}
+	if a := g1.Coverage.Actions[1]; a.Tried != 3 || a.Fired != 0 || a.False[0] != 3 || a.True[0] != 0 {
+		t.Errorf("Merged coverage of action 1 is %+v, want it tried 3 times", *a)
+	}
+	if a := g1.Coverage.Actions[2]; a.Tried != 3 || a.Fired != 3 {
+		t.Errorf("Merged coverage of action 2 is %+v, want it fired 3 times", *a)
+	}
+}
+
+func TestCoverageMergeMismatch(t *testing.T) {
+	c := coverageGame(t).Coverage
+	other := testGame(t, action(jumpVerb, 0, never, "A"))
+	if err := c.Merge(NewCoverage(other.DB.Game)); err == nil {
+		t.Errorf("Merge of coverage with fewer actions succeeded")
+	}
+
+	o := NewCoverage(coverageGame(t).DB.Game)
+	o.Actions[0].Tried = 5
+	o.Actions[1].True = nil
+	if err := c.Merge(o); err == nil {
+		t.Errorf("Merge of coverage with missing conditions succeeded")
+	}
+	if c.Actions[0].Tried != 0 {
+		t.Errorf("A failed Merge changed the coverage")
+	}
+}
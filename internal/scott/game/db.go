@@ -0,0 +1,173 @@
+package game
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/parser"
+	"github.com/chaosotter/golang-adventures/internal/scott/stream"
+)
+
+// A Database holds the parts of a game that never change during play: the
+// game as read from the file, along with indexes for looking up words.  It is
+// never modified once made, so any number of Games may share one, from any
+// number of goroutines.  Each Game keeps only its own State.
+type Database struct {
+	// Game is the game as read from the file.  It must not be changed.
+	Game *scottpb.Game
+
+	// Hash is the SHA-256 hash of the game file, for telling versions of a
+	// game apart.  It is computed once, as the file is read.
+	Hash [sha256.Size]byte
+
+	// DefaultCommand is the default "auto-execution" command for this game.
+	// It is always based on verb 0 and noun 0, but the text might vary from
+	// game to game.
+	DefaultCommand *ParseData
+
+	verbs, nouns map[string]int // word indexes, keyed by wordKey
+	autograbs    []int          // the noun index of each item's autograb
+}
+
+// NewDatabase initializes a Database from the raw bytes read from the external
+// game file.
+func NewDatabase(data []byte) (*Database, error) {
+	pb, err := parser.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	db, err := newDatabase(pb)
+	if err != nil {
+		return nil, err
+	}
+	db.Hash = sha256.Sum256(data)
+	return db, nil
+}
+
+// NewDatabaseFromReader is like NewDatabase, but reads the game file
+// incrementally.  Anything after the end of the game is read too, for the
+// hash.
+func NewDatabaseFromReader(in io.Reader) (*Database, error) {
+	h := sha256.New()
+	pb, err := parser.ParseReader(io.TeeReader(in, h))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(h, in); err != nil {
+		return nil, err
+	}
+	db, err := newDatabase(pb)
+	if err != nil {
+		return nil, err
+	}
+	copy(db.Hash[:], h.Sum(nil))
+	return db, nil
+}
+
+// LoadDatabase tries to initialize a Database from the given file.  Problems
+// with the data are reported in the usual "file:line:column: message" form.
+func LoadDatabase(path string) (*Database, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	db, err := NewDatabaseFromReader(f)
+	var se *stream.Error
+	switch {
+	case errors.As(err, &se):
+		return nil, fmt.Errorf("%s:%v", path, se)
+	case err != nil:
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return db, nil
+}
+
+// newDatabase checks a freshly parsed proto and builds the indexes for it.
+func newDatabase(pb *scottpb.Game) (*Database, error) {
+	if err := check(pb); err != nil {
+		return nil, err
+	}
+	db := &Database{
+		Game: pb,
+		DefaultCommand: &ParseData{
+			Verb:      pb.Verbs[AutoVerb].Word,
+			VerbIndex: AutoVerb,
+			Noun:      pb.Nouns[0].Word,
+		},
+	}
+	db.verbs = db.index(pb.Verbs)
+	db.nouns = db.index(pb.Nouns)
+	db.autograbs = make([]int, len(pb.Items))
+	for i, it := range pb.Items {
+		db.autograbs[i] = db.FindNoun(it.Autograb)
+	}
+	return db, nil
+}
+
+// NewGame makes a new Game using the database, ready to play from the start.
+// Making a Game is cheap, since only its State is its own.
+func (db *Database) NewGame() *Game {
+	g := &Game{DB: db}
+	g.Restart()
+	return g
+}
+
+// FindVerb returns the index of the given verb, or UnknownWord.  Synonyms are
+// resolved down to the word they stand for.
+func (db *Database) FindVerb(w string) int {
+	return db.find(db.verbs, w)
+}
+
+// FindNoun returns the index of the given noun, or UnknownWord.  Synonyms are
+// resolved down to the word they stand for.
+func (db *Database) FindNoun(w string) int {
+	return db.find(db.nouns, w)
+}
+
+// find looks up a word in one of the indexes.
+func (db *Database) find(index map[string]int, w string) int {
+	if i, ok := index[db.wordKey(w)]; ok {
+		return i
+	}
+	return UnknownWord
+}
+
+// index builds the index for a list of words.  Each word maps to the nearest
+// word at or before it that isn't a synonym, and where two words are the same
+// once truncated, the first wins.  A synonym with no such word before it can't
+// be found.
+func (db *Database) index(ws []*scottpb.Word) map[string]int {
+	index := make(map[string]int, len(ws))
+	base := UnknownWord
+	for i, w := range ws {
+		if !w.Synonym {
+			base = i
+		}
+		if base == UnknownWord {
+			continue
+		}
+		k := db.wordKey(w.Word)
+		if _, ok := index[k]; !ok {
+			index[k] = base
+		}
+	}
+	return index
+}
+
+// wordKey returns the part of a word that is significant for comparing it with
+// the vocabulary: the game's word length, less any trailing spaces.  Two words
+// match when their keys are equal, exactly as if both had been padded with
+// spaces to the word length and cut off there.
+func (db *Database) wordKey(w string) string {
+	if n := int(db.Game.Header.WordLength); len(w) > n {
+		w = w[:n]
+	}
+	return strings.TrimRight(w, " ")
+}
@@ -0,0 +1,164 @@
+package game
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+// loadDatabase loads one of the bundled games.
+func loadDatabase(tb testing.TB, name string) *Database {
+	db, err := LoadDatabase(filepath.Join("../../../games", name))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return db
+}
+
+// findWord is the linear search that the word indexes replaced, kept to check
+// them against.
+func findWord(ws []*scottpb.Word, w string, n int) int {
+	truncate := func(w string) string {
+		if len(w) < n {
+			w += strings.Repeat(" ", n-len(w))
+		}
+		return w[0:n]
+	}
+	w = truncate(w)
+	for i := 0; i < len(ws); i++ {
+		if truncate(ws[i].Word) != w {
+			continue
+		}
+		for j := i; j >= 0; j-- {
+			if !ws[j].Synonym {
+				return j
+			}
+		}
+	}
+	return UnknownWord
+}
+
+func TestFindWordMatchesLinearSearch(t *testing.T) {
+	paths, err := filepath.Glob("../../../games/*.dat")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("Could not find the game files: %v", err)
+	}
+	for _, path := range paths {
+		db := loadDatabase(t, filepath.Base(path))
+		n := int(db.Game.Header.WordLength)
+
+		words := []string{"", " ", "XYZZY", "NORTHWEST", "GO", "A"}
+		for _, w := range append(db.Game.Verbs, db.Game.Nouns...) {
+			words = append(words, w.Word, w.Word+"X", strings.TrimSpace(w.Word), strings.ToUpper(w.Word))
+			if len(w.Word) > 1 {
+				words = append(words, w.Word[:len(w.Word)-1])
+			}
+		}
+		for _, w := range words {
+			if got, want := db.FindVerb(w), findWord(db.Game.Verbs, w, n); got != want {
+				t.Errorf("%s: FindVerb(%q): got %d, want %d", path, w, got, want)
+			}
+			if got, want := db.FindNoun(w), findWord(db.Game.Nouns, w, n); got != want {
+				t.Errorf("%s: FindNoun(%q): got %d, want %d", path, w, got, want)
+			}
+		}
+	}
+}
+
+func TestGamesShareDatabase(t *testing.T) {
+	db := loadDatabase(t, "adv01.dat")
+	g1, g2 := db.NewGame(), db.NewGame()
+	want := g2.StateHash()
+
+	g1.Start()
+	for _, cmd := range []string{"CLIMB TREE", "GET KEYS", "DOWN", "DROP KEYS"} {
+		g1.Command(cmd)
+	}
+	if g1.StateHash() == want {
+		t.Errorf("Playing the first game didn't change it")
+	}
+	if g2.StateHash() != want {
+		t.Errorf("Playing the first game changed the second")
+	}
+	if g1.DB != g2.DB {
+		t.Errorf("The games don't share the game data")
+	}
+
+	g1.Restart()
+	if g1.StateHash() != want {
+		t.Errorf("Restart didn't put the first game back at the start")
+	}
+}
+
+func TestDatabaseHash(t *testing.T) {
+	data, err := ioutil.ReadFile("../../../games/adv01.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := sha256.Sum256(data)
+
+	if db := loadDatabase(t, "adv01.dat"); db.Hash != want {
+		t.Errorf("LoadDatabase: got hash %x, want %x", db.Hash, want)
+	}
+	db, err := NewDatabase(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.Hash != want {
+		t.Errorf("NewDatabase: got hash %x, want %x", db.Hash, want)
+	}
+}
+
+// benchCommands is a short walk through Adventureland, which ends up back where
+// it started so that it can be repeated.
+var benchCommands = []string{
+	"CLIMB TREE", "GET KEYS", "DOWN", "INVENTORY", "DROP KEYS", "LOOK",
+	"GET ALL", "DROP ALL", "NORTH", "SOUTH", "XYZZY", "SCORE",
+}
+
+func BenchmarkNewGame(b *testing.B) {
+	db := loadDatabase(b, "adv01.dat")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		db.NewGame()
+	}
+}
+
+func BenchmarkLoadFromFile(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := LoadFromFile("../../../games/adv01.dat"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCommand(b *testing.B) {
+	g := loadDatabase(b, "adv01.dat").NewGame()
+	g.Seed(1)
+	g.Start()
+	g.Events()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if g.IsOver() {
+			g.Restart()
+		}
+		g.Command(benchCommands[i%len(benchCommands)])
+		g.Events()
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	g := loadDatabase(b, "adv01.dat").NewGame()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Parse(benchCommands[i%len(benchCommands)])
+	}
+}
@@ -0,0 +1,67 @@
+package game
+
+import "fmt"
+
+// EventType identifies the kind of output produced by the engine.
+type EventType int
+
+const (
+	TextEvent        = EventType(iota) // some text to print, exactly as given
+	LookEvent                          // the room should be described
+	ClearScreenEvent                   // the screen should be cleared
+	DelayEvent                         // the game wants a pause of a couple of seconds
+	SaveEvent                          // the game wants to be saved
+	GameOverEvent                      // the game has ended
+)
+
+// String returns a short name for the event type.
+func (t EventType) String() string {
+	switch t {
+	case TextEvent:
+		return "text"
+	case LookEvent:
+		return "look"
+	case ClearScreenEvent:
+		return "clear_screen"
+	case DelayEvent:
+		return "delay"
+	case SaveEvent:
+		return "save"
+	case GameOverEvent:
+		return "game_over"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// An Event is a single piece of output from the engine.  We avoid direct output
+// for the benefit of writing additional drivers, so everything that the engine
+// wants to say is queued up as a sequence of events for the driver to render.
+type Event struct {
+	Type EventType // the kind of event
+	Text string    // the text to print, for TextEvent
+	Look *LookData // the room description, for LookEvent
+}
+
+// Events returns the events produced since the last call, and clears the queue.
+func (g *Game) Events() []*Event {
+	evs := g.events
+	g.events = nil
+	return evs
+}
+
+// emit queues up an event of the given type.
+func (g *Game) emit(typ EventType) {
+	g.events = append(g.events, &Event{Type: typ})
+}
+
+// print queues up some text.
+func (g *Game) print(text string) {
+	g.events = append(g.events, &Event{Type: TextEvent, Text: text})
+}
+
+// describe queues up a description of the current room.
+func (g *Game) describe() {
+	g.events = append(g.events, &Event{Type: LookEvent, Look: g.Look()})
+	g.redraw = false
+}
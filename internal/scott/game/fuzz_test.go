@@ -0,0 +1,80 @@
+package game
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// maxFuzzCommands limits the number of commands tried for each game, to keep
+// the fuzzer quick.
+const maxFuzzCommands = 50
+
+// FuzzNew checks that loading a game never panics, and that the engine
+// doesn't either once a game has been loaded.  A few commands are played,
+// taken from the game's own action table so that they do something.
+//
+// The seed is a tiny game of a few rooms, items and actions rather than the
+// bundled games, which are tens of kilobytes each and take the fuzzer far too
+// long to minimize a failure in.  The bundled games are played by other tests.
+func FuzzNew(f *testing.F) {
+	data, err := ioutil.ReadFile("testdata/tiny.dat")
+	if err != nil {
+		f.Fatalf("Could not read the seed game: %v", err)
+	}
+	f.Add(data)
+
+	// Headers that the engine used to trust: word lengths of 0 and more
+	// than 6, no words at all, a nonexistent starting room, and too few
+	// items to have a light source.
+	for _, h := range []struct {
+		field int
+		value string
+	}{
+		{8, "0"}, {8, "9"}, {3, "-1"}, {6, "999"}, {1, "4"},
+	} {
+		f.Add(withHeader(data, h.field, h.value))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		g, err := New(data)
+		if err != nil {
+			return
+		}
+		g.Seed(1)
+
+		cmds := []string{"LOOK", "N", "S", "E", "W", "U", "D", "GET ALL", "DROP ALL", "INVENTORY", "SCORE"}
+		for _, a := range g.DB.Game.Actions {
+			if len(cmds) >= maxFuzzCommands {
+				break
+			}
+			v, n := int(a.VerbIndex), int(a.NounIndex)
+			if v == AutoVerb || v < 0 || n < 0 || v >= len(g.DB.Game.Verbs) || n >= len(g.DB.Game.Nouns) {
+				continue
+			}
+			cmd := g.DB.Game.Verbs[v].Word
+			if n > 0 {
+				cmd += " " + g.DB.Game.Nouns[n].Word
+			}
+			cmds = append(cmds, cmd)
+		}
+
+		g.Start()
+		for _, cmd := range cmds {
+			g.Command(cmd)
+			g.Look()
+			g.Events()
+		}
+		if err := g.RestoreState(g.SaveState()); err != nil {
+			t.Fatalf("Could not restore a saved state: %v", err)
+		}
+	})
+}
+
+// withHeader replaces one of the 12 values in the header of a game file,
+// which come one to a line.
+func withHeader(data []byte, field int, value string) []byte {
+	lines := strings.SplitN(string(data), "\n", 13)
+	lines[field] = " " + value
+	return []byte(strings.Join(lines, "\n"))
+}
@@ -4,14 +4,12 @@ package game
 
 import (
 	"fmt"
-	"io/ioutil"
+	"io"
 	"log"
+	"math/rand"
 	"strings"
 
-	"google.golang.org/protobuf/proto"
-
 	"github.com/chaosotter/golang-adventures/api/scottpb"
-	"github.com/chaosotter/golang-adventures/internal/scott/parser"
 )
 
 const (
@@ -20,11 +18,12 @@ const (
 )
 
 const (
-	LightItem    = 9  // constant across all adventures
-	Inventory    = -1 // location corresponding to player inventory
-	DarkFlag     = 15 // flag number for darkness
-	LightOutFlag = 16 // flag number for light gone out
-	UnknownWord  = -1 // value used to represent unknown words
+	LightItem    = 9   // constant across all adventures
+	Inventory    = -1  // location corresponding to player inventory
+	Inventory255 = 255 // alternate inventory location used by some game files
+	DarkFlag     = 15  // flag number for darkness
+	LightOutFlag = 16  // flag number for light gone out
+	UnknownWord  = -1  // value used to represent unknown words
 )
 
 const (
@@ -43,55 +42,103 @@ const (
 	DangerousDark                // a dangerous move in the dark (valid direction)
 	DeadDark                     // a fatal move in the dark (invalid direction)
 	Unsuccessful                 // command is valid but couldn't be fulfilled
+	GameOver                     // the game has already ended
 )
 
 // A Game encaspulates the current state of a Scott Adams adventure.
 type Game struct {
-	// Initial is a proto holding the initial state of the game in the form of
-	// a proto initialized from the external data file.
-	Initial *scottpb.Game
-
-	// Current is a deep copy of Initial that contains the current game state.
-	Current *scottpb.Game
-
-	// DefaultCommand is the default "auto-execution" command for this game.
-	// It is always based on verb 0 and noun 0, but the text might vary from
-	// game to game.
-	DefaultCommand *ParseData
+	// DB holds the parts of the game that never change, which may be shared
+	// with other Games: the game as read from the file is DB.Game.
+	DB *Database
+
+	// State is the current state of the game: everything that changes during
+	// play, including the location of every item (in ItemLocations).
+	State *scottpb.State
+
+	// Options selects between the variations in behaviour offered by
+	// ScottFree.  They may be changed at any time.
+	Options Options
+
+	// Tracer, if set, is told what the engine does as it works through the
+	// action table.
+	Tracer Tracer
+
+	// Coverage, if set, records which actions are tried and performed, and
+	// how their conditions turn out.  It must have been made for this game.
+	Coverage *Coverage
+
+	// Chance, if set, decides whether a random event with an n% chance
+	// happens, in place of the source of randomness set up by Seed.  See
+	// ScottFreeRand.
+	Chance func(n int) bool
+
+	rng      *rand.Rand // source of randomness for automatic actions, once needed
+	events   []*Event   // output waiting to be collected by the driver
+	redraw   bool       // set if the room needs to be described again
+	noSysCmd bool       // set to stop recursion from GET ALL and DROP ALL
 }
 
 // New initializes a fresh Game value from the raw bytes read from the external
-// game file.
+// game file.  Use NewDatabase instead to play the same game more than once.
 func New(data []byte) (*Game, error) {
-	pb, err := parser.Parse(data)
+	db, err := NewDatabase(data)
 	if err != nil {
 		return nil, err
 	}
+	return db.NewGame(), nil
+}
 
-	g := &Game{
-		Initial: pb,
-		DefaultCommand: &ParseData{
-			Verb:      pb.Verbs[AutoVerb].Word,
-			VerbIndex: AutoVerb,
-			Noun:      pb.Nouns[0].Word,
-		},
+// NewFromReader is like New, but reads the game file incrementally.
+func NewFromReader(in io.Reader) (*Game, error) {
+	db, err := NewDatabaseFromReader(in)
+	if err != nil {
+		return nil, err
 	}
+	return db.NewGame(), nil
+}
 
-	g.Restart()
-	return g, nil
+// check makes sure that a freshly parsed proto has everything the engine
+// relies on.  The parser accepts anything that is numerically well-formed, so
+// without this a damaged or hostile game file could crash the engine.
+func check(pb *scottpb.Game) error {
+	h := pb.Header
+	switch {
+	case len(pb.Verbs) <= AutoVerb || len(pb.Nouns) == 0:
+		return fmt.Errorf("Game has no words")
+	case h.WordLength < 1:
+		return fmt.Errorf("Game has invalid word length %d", h.WordLength)
+	case len(pb.Rooms) == 0:
+		return fmt.Errorf("Game has no rooms")
+	case h.StartingRoom < 0 || int(h.StartingRoom) >= len(pb.Rooms):
+		return fmt.Errorf("Game has invalid starting room %d", h.StartingRoom)
+	case len(pb.Items) <= LightItem:
+		return fmt.Errorf("Game has only %d items, so there is no light source (item %d)", len(pb.Items), LightItem)
+	}
+	for i, r := range pb.Rooms {
+		if len(r.Exits) != 6 {
+			return fmt.Errorf("Room %d has %d exits, not 6", i, len(r.Exits))
+		}
+	}
+	return nil
 }
 
-// MustLoadFromFile tries to initialize a fresh Game value from the given file
-// or aborts the process.
-func MustLoadFromFile(path string) *Game {
-	data, err := ioutil.ReadFile(path)
+// LoadFromFile tries to initialize a fresh Game value from the given file.
+// Problems with the data are reported in the usual "file:line:column: message"
+// form.
+func LoadFromFile(path string) (*Game, error) {
+	db, err := LoadDatabase(path)
 	if err != nil {
-		log.Fatalf("Could not read %q: %v", path, err)
+		return nil, err
 	}
+	return db.NewGame(), nil
+}
 
-	g, err := New(data)
+// MustLoadFromFile tries to initialize a fresh Game value from the given file
+// or aborts the process.
+func MustLoadFromFile(path string) *Game {
+	g, err := LoadFromFile(path)
 	if err != nil {
-		log.Fatalf("Could not parse %q: %v", path, err)
+		log.Fatalf("Could not load game: %v", err)
 	}
 
 	return g
@@ -105,22 +152,29 @@ type LookData struct {
 	RoomDescription string   // the room description, made into a sentence
 	Exits           []string // ordered list of obvious exits
 	Items           []string // ordered list of items in the room
+
+	opts Options // the options in effect, for String
 }
 
 // Look prints the standard description information to the given output.
 func (g *Game) Look() *LookData {
-	ld := &LookData{}
+	ld := &LookData{opts: g.Options}
 
 	if g.IsDark() {
-		ld.RoomDescription = "I can't see. It is too dark!"
+		ld.IsDark = true
+		ld.RoomDescription = g.person("I can't see. It is too dark!", "You can't see. It is too dark!")
 		return ld
 	}
 
-	r := g.Current.Rooms[g.Current.State.Location]
+	r := g.room(g.State.Location)
+	if r == nil {
+		// Only possible if the player was moved somewhere nonexistent.
+		return ld
+	}
 	if r.Literal {
 		ld.RoomDescription = r.Description
 	} else {
-		ld.RoomDescription = fmt.Sprintf("I'm in a %s", r.Description)
+		ld.RoomDescription = fmt.Sprintf(g.person("I'm in a %s", "You are in a %s"), r.Description)
 	}
 
 	for i, dir := range []string{"North", "South", "East", "West", "Up", "Down"} {
@@ -129,9 +183,9 @@ func (g *Game) Look() *LookData {
 		}
 	}
 
-	for i := 0; i < int(g.Current.Header.NumItems); i++ {
-		if it := g.Current.Items[i]; it.Location == g.Current.State.Location {
-			ld.Items = append(ld.Items, it.Description)
+	for i, loc := range g.State.ItemLocations {
+		if loc == g.State.Location {
+			ld.Items = append(ld.Items, g.DB.Game.Items[i].Description)
 		}
 	}
 
@@ -152,7 +206,7 @@ func (g *Game) Parse(input string) *ParseData {
 	input = strings.ToUpper(strings.TrimSpace(input))
 
 	var verb, noun string
-	words := strings.SplitN(input, " ", 2)
+	words := strings.Fields(input)
 	if len(words) > 0 {
 		verb = words[0]
 	}
@@ -179,85 +233,222 @@ func (g *Game) Parse(input string) *ParseData {
 
 	return &ParseData{
 		Verb:      verb,
-		VerbIndex: g.findWord(g.Current.Verbs, verb),
+		VerbIndex: g.DB.FindVerb(verb),
 		Noun:      noun,
-		NounIndex: g.findWord(g.Current.Nouns, noun),
+		NounIndex: g.DB.FindNoun(noun),
 	}
 }
 
-// findWord finds the index of |w| within |ws|.  Synonyms are resolved down to
-// the lowest matching index.
-func (g *Game) findWord(ws []*scottpb.Word, w string) int {
-	w = (w + "      ")[0:g.Current.Header.WordLength]
-	for i := 0; i < len(ws); i++ {
-		if (ws[i].Word + "      ")[0:g.Current.Header.WordLength] != w {
-			continue
-		}
-		for j := i; j >= 0; j-- {
-			if !ws[j].Synonym {
-				return j
-			}
-		}
+// room returns the given room, or nil if there is no such room.
+func (g *Game) room(i int32) *scottpb.Room {
+	if i < 0 || int(i) >= len(g.DB.Game.Rooms) {
+		return nil
 	}
-	return UnknownWord
+	return g.DB.Game.Rooms[i]
 }
 
-// Execute the given command.
+// Start begins play by describing the starting room and running the
+// automatic actions for the first turn.  The output is queued up as events.
+func (g *Game) Start() {
+	if g.Options.Debug {
+		g.debugInfo()
+	}
+	g.describe()
+	g.ExecuteDefault()
+	if g.redraw {
+		g.describe()
+	}
+}
+
+// Command processes a complete turn for the given line of user input: the
+// command itself, the light source burning down, and the automatic actions
+// for the following turn.  The output is queued up as events.  If none of
+// the words are known, no time passes.
+func (g *Game) Command(input string) Status {
+	if g.State.GameOver {
+		return GameOver
+	}
+
+	pd := g.Parse(input)
+	if pd.Verb == "" {
+		return Unknown
+	}
+	if pd.VerbIndex == UnknownWord {
+		g.print("You use word(s) I don't know!\n")
+		return Unknown
+	}
+
+	st := g.Execute(pd)
+	if g.State.GameOver {
+		return st
+	}
+	g.tickLight()
+
+	if g.redraw {
+		g.describe()
+	}
+	g.ExecuteDefault()
+	if g.redraw {
+		g.describe()
+	}
+	return st
+}
+
+// Execute the given command.  This follows ScottFree closely: movement is
+// handled as a special case, then the actions are tried in order, and finally
+// GET and DROP are handled for items that name themselves with an autograb.
 func (g *Game) Execute(pd *ParseData) Status {
+	if g.State.GameOver {
+		return GameOver
+	}
+
 	// Try movement first as a special case.
 	if pd.VerbIndex == GoVerb {
 		switch {
 		case pd.NounIndex == UnknownWord:
+			g.print("Give me a direction too.\n")
 			return NoDirection
 		case pd.NounIndex >= 1 && pd.NounIndex <= 6: // nouns 1..6 are always the directions
 			dark := g.IsDark()
-			dest := g.Current.Rooms[g.Current.State.Location].Exits[pd.NounIndex-1]
+			if dark {
+				g.print("Dangerous to move in the dark!\n")
+			}
+			var dest int32
+			if r := g.room(g.State.Location); r != nil {
+				dest = r.Exits[pd.NounIndex-1]
+			}
 			switch {
 			case dark && dest == 0:
+				g.print(g.person("I fell down and broke my neck.\n", "You fell down and broke your neck.\n"))
 				g.KillPlayer()
+				g.endGame()
 				return DeadDark
-			case dark:
-				g.Current.State.Location = dest
-				return DangerousDark
-			case dest == 0:
+			case dest == 0 || g.room(dest) == nil:
+				g.print(g.person("I can't go in that direction.\n", "You can't go in that direction.\n"))
 				return BadDirection
 			default:
-				g.Current.State.Location = dest
+				g.State.Location = dest
+				g.print("O.K.\n")
+				g.describe()
+				if dark {
+					return DangerousDark
+				}
 				return Success
 			}
 		}
 	}
 
-	return Success
+	return g.performActions(pd)
 }
 
 // Execute the default commands (i.e., actions that represent the passage of
 // time rather a reaction to user input).  This is always verb 0 (usually
 // "AUTO") and noun 0 (usually "ANY").
 func (g *Game) ExecuteDefault() Status {
-	return g.Execute(g.DefaultCommand)
+	return g.Execute(g.DB.DefaultCommand)
+}
+
+// Seed reseeds the source of randomness used for automatic actions, so that
+// a game can be replayed exactly.
+func (g *Game) Seed(seed int64) {
+	g.rng = rand.New(rand.NewSource(seed))
+}
+
+// Score returns the number of treasures stored in the treasure room and the
+// total number of treasures in the game.
+func (g *Game) Score() (stored, total int) {
+	for i, it := range g.DB.Game.Items {
+		if it.IsTreasure && g.State.ItemLocations[i] == g.DB.Game.Header.TreasureRoom {
+			stored++
+		}
+	}
+	return stored, int(g.DB.Game.Header.NumTreasures)
+}
+
+// Carried returns the descriptions of the items the player is carrying.
+func (g *Game) Carried() []string {
+	var items []string
+	for i, loc := range g.State.ItemLocations {
+		if loc == Inventory {
+			items = append(items, g.DB.Game.Items[i].Description)
+		}
+	}
+	return items
+}
+
+// IsOver checks if the game has ended.
+func (g *Game) IsOver() bool {
+	return g.State.GameOver
 }
 
 // IsDark checks if the player is currently in the dark.
 func (g *Game) IsDark() bool {
-	return g.Current.State.Flags[DarkFlag] &&
-		(g.Current.Items[LightItem].Location != Inventory) &&
-		(g.Current.Items[LightItem].Location != g.Current.State.Location)
+	return g.State.Flags[DarkFlag] &&
+		(g.State.ItemLocations[LightItem] != Inventory) &&
+		(g.State.ItemLocations[LightItem] != g.State.Location)
 }
 
 // KillPlayer kills the player.  This turns darkness off and places them in the
 // last room in the game (action DEATH).
 func (g *Game) KillPlayer() {
-	g.Current.State.Location = g.Current.Header.NumRooms - 1
-	g.Current.State.Flags[DarkFlag] = false
+	g.State.Location = int32(len(g.DB.Game.Rooms) - 1)
+	g.State.Flags[DarkFlag] = false
+}
+
+// endGame marks the game as over.
+func (g *Game) endGame() {
+	g.print("The game is now over.\n")
+	g.State.GameOver = true
+	g.emit(GameOverEvent)
+}
+
+// tickLight burns down the light source at the end of a turn, if it's in play
+// and not eternal.
+func (g *Game) tickLight() {
+	st := g.State
+	lamp := st.ItemLocations[LightItem]
+	if lamp == 0 || st.LightTime == -1 {
+		return
+	}
+
+	st.LightTime--
+	visible := lamp == Inventory || lamp == st.Location
+	switch {
+	case st.LightTime < 1:
+		st.Flags[LightOutFlag] = true
+		if visible {
+			if g.Options.ScottLight {
+				g.print("Light has run out!\n")
+			} else {
+				g.print("Your light has run out.\n")
+			}
+		}
+		if g.Options.PrehistoricLamp {
+			st.ItemLocations[LightItem] = 0
+		}
+	case st.LightTime < 25 && visible:
+		if g.Options.ScottLight {
+			g.print(fmt.Sprintf("Light runs out in %d turns.\n", st.LightTime))
+		} else if st.LightTime%5 == 0 {
+			g.print("Your light is growing dim.\n")
+		}
+	}
 }
 
-// Restart the game.
+// Restart the game.  Only the State is made afresh; the Database is shared.
 func (g *Game) Restart() {
-	g.Current = proto.Clone(g.Initial).(*scottpb.Game)
-	g.Current.State = &scottpb.State{
-		Location: g.Current.Header.StartingRoom,
-		Flags:    make([]bool, NumFlags),
-		Counters: make([]int32, NumCounters),
+	h := g.DB.Game.Header
+	g.State = &scottpb.State{
+		Location:      h.StartingRoom,
+		Flags:         make([]bool, NumFlags),
+		Counters:      make([]int32, NumCounters),
+		SavedRooms:    make([]int32, NumCounters),
+		LightTime:     h.LightDuration,
+		ItemLocations: make([]int32, len(g.DB.Game.Items)),
+	}
+	for i := range g.State.ItemLocations {
+		g.State.ItemLocations[i] = g.initialLocation(int32(i))
 	}
+	g.events = nil
+	g.redraw = false
 }
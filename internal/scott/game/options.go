@@ -0,0 +1,99 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Options selects between the variations in behaviour offered by the ScottFree
+// interpreter through its command-line options.  The zero value gives the
+// default behaviour.
+type Options struct {
+	// YouAre uses "You are" narration ("You are in a forest") instead of the
+	// first person ("I'm in a forest"), for games written that way (-y).
+	YouAre bool
+
+	// ScottLight uses the light messages of the original Scott Adams
+	// interpreters, counting down every turn once the light runs low (-s).
+	ScottLight bool
+
+	// TRS80 describes rooms in the style of the TRS-80 interpreter, with
+	// items separated by periods and a rule under the description (-t).
+	TRS80 bool
+
+	// PrehistoricLamp destroys the light source when it runs out, as some
+	// early interpreters did (-p).
+	PrehistoricLamp bool
+
+	// Debug reports the size of the game when it starts, as ScottFree does
+	// while loading (-d).
+	Debug bool
+}
+
+// TRS80Width is the width of the TRS-80 screen, for drivers to wrap to.
+const TRS80Width = 64
+
+// trs80Rule is drawn under the room description in TRS-80 style.
+const trs80Rule = "<" + "------------------------------------------------------------" + ">"
+
+// person picks the first-person or second-person version of a message,
+// according to the options.
+func (g *Game) person(i, you string) string {
+	if g.Options.YouAre {
+		return you
+	}
+	return i
+}
+
+// debugInfo reports the size of the game, in the manner of ScottFree's
+// debugging output, which gives the counts from the header as they stand.
+func (g *Game) debugInfo() {
+	h := g.DB.Game.Header
+	g.print(fmt.Sprintf("Reading %d actions.\n", h.NumActions))
+	g.print(fmt.Sprintf("Reading %d word pairs.\n", h.NumWords))
+	g.print(fmt.Sprintf("Reading %d rooms.\n", h.NumRooms))
+	g.print(fmt.Sprintf("Reading %d messages.\n", h.NumMessages))
+	g.print(fmt.Sprintf("Reading %d items.\n", h.NumItems))
+	g.print(fmt.Sprintf("Version %d.%02d of Adventure %d.\n", g.DB.Game.Footer.Version/100, g.DB.Game.Footer.Version%100, g.DB.Game.Footer.Adventure))
+	g.print("Load Complete.\n\n")
+}
+
+// String formats the room description for a plain text display, in the style
+// selected by the options in effect when the description was made.  The result
+// ends with a newline.
+func (ld *LookData) String() string {
+	var b strings.Builder
+	b.WriteString(ld.RoomDescription + "\n")
+
+	if ld.opts.TRS80 {
+		b.WriteString("\nObvious exits: ")
+		if len(ld.Exits) > 0 {
+			b.WriteString(strings.Join(ld.Exits, ", "))
+		} else {
+			b.WriteString("none")
+		}
+		b.WriteString(".\n")
+	} else if len(ld.Exits) > 0 {
+		b.WriteString("Obvious exits: " + strings.Join(ld.Exits, ", ") + "\n")
+	} else {
+		b.WriteString("Obvious exits: None\n")
+	}
+
+	if len(ld.Items) > 0 {
+		if ld.opts.YouAre {
+			b.WriteString("\nYou can also see: ")
+		} else {
+			b.WriteString("\nI can also see: ")
+		}
+		if ld.opts.TRS80 {
+			b.WriteString(strings.Join(ld.Items, ". ") + ".\n")
+		} else {
+			b.WriteString(strings.Join(ld.Items, " - ") + "\n")
+		}
+	}
+
+	if ld.opts.TRS80 {
+		b.WriteString(trs80Rule + "\n")
+	}
+	return b.String()
+}
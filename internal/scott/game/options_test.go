@@ -0,0 +1,84 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookDataString(t *testing.T) {
+	ld := &LookData{
+		RoomDescription: "I'm in a forest",
+		Exits:           []string{"North", "East"},
+		Items:           []string{"Trees", "Sign"},
+	}
+	bare := &LookData{RoomDescription: "I'm in a void"}
+
+	for _, tc := range []struct {
+		ld   *LookData
+		opts Options
+		want string
+	}{
+		{ld, Options{}, "I'm in a forest\nObvious exits: North, East\n\nI can also see: Trees - Sign\n"},
+		{ld, Options{YouAre: true}, "I'm in a forest\nObvious exits: North, East\n\nYou can also see: Trees - Sign\n"},
+		{ld, Options{TRS80: true}, "I'm in a forest\n\nObvious exits: North, East.\n\nI can also see: Trees. Sign.\n" + trs80Rule + "\n"},
+		{bare, Options{}, "I'm in a void\nObvious exits: None\n"},
+		{bare, Options{TRS80: true}, "I'm in a void\n\nObvious exits: none.\n" + trs80Rule + "\n"},
+	} {
+		tc.ld.opts = tc.opts
+		if got := tc.ld.String(); got != tc.want {
+			t.Errorf("String with %+v:\ngot  %q\nwant %q", tc.opts, got, tc.want)
+		}
+	}
+}
+
+func TestYouAre(t *testing.T) {
+	g := loadDatabase(t, "adv01.dat").NewGame()
+	if got := g.Look().RoomDescription; !strings.HasPrefix(got, "I'm in a ") {
+		t.Errorf("Without YouAre, the room is %q", got)
+	}
+	g.Options.YouAre = true
+	if got := g.Look().RoomDescription; !strings.HasPrefix(got, "You are in a ") {
+		t.Errorf("With YouAre, the room is %q", got)
+	}
+	if ld := g.Look(); !ld.opts.YouAre {
+		t.Errorf("Look didn't record the options for String")
+	}
+}
+
+func TestDebug(t *testing.T) {
+	g := loadDatabase(t, "adv01.dat").NewGame()
+	g.Options.Debug = true
+	g.Start()
+	if got, want := output(g), "Reading 170 actions."; !strings.HasPrefix(got, want) {
+		t.Errorf("With Debug, the game started with %q, want %q", got, want)
+	}
+}
+
+func TestLightOptions(t *testing.T) {
+	for _, tc := range []struct {
+		opts     Options
+		time     int32
+		want     string
+		lampGone bool
+	}{
+		{Options{}, 11, "Your light is growing dim.", false},
+		{Options{}, 12, "", false},
+		{Options{ScottLight: true}, 12, "Light runs out in 11 turns.", false},
+		{Options{}, 1, "Your light has run out.", false},
+		{Options{ScottLight: true}, 1, "Light has run out!", false},
+		{Options{PrehistoricLamp: true}, 1, "Your light has run out.", true},
+	} {
+		g := testGame(t)
+		g.Options = tc.opts
+		g.State.ItemLocations[LightItem] = Inventory
+		g.State.LightTime = tc.time
+		g.tickLight()
+
+		if got := output(g); got != tc.want {
+			t.Errorf("%+v, %d turns left: got %q, want %q", tc.opts, tc.time, got, tc.want)
+		}
+		if gone := g.State.ItemLocations[LightItem] == 0; gone != tc.lampGone {
+			t.Errorf("%+v, %d turns left: lamp gone is %v, want %v", tc.opts, tc.time, gone, tc.lampGone)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package game
+
+// These are the parameters of the additive feedback generator behind rand()
+// in the GNU C library (its TYPE_3 generator).
+const (
+	randDegree = 31  // number of words of state
+	randSep    = 3   // separation between the front and rear pointers
+	randWarmUp = 310 // number of results discarded after seeding
+)
+
+// ScottFreeRand reproduces the random events of ScottFree built against the
+// GNU C library, for comparing the engine with it.  Use it by setting
+// Game.Chance to the Percent method.
+type ScottFreeRand struct {
+	state [randDegree]int32
+	f, r  int // the front and rear pointers into |state|
+}
+
+// NewScottFreeRand initializes a generator in the state left by srand(seed).
+func NewScottFreeRand(seed uint32) *ScottFreeRand {
+	r := &ScottFreeRand{}
+	if seed == 0 {
+		seed = 1
+	}
+	r.state[0] = int32(seed)
+	for i := 1; i < randDegree; i++ {
+		// This is 16807 * state[i-1] % (2^31 - 1), computed without
+		// overflowing 32 bits, exactly as glibc does it.
+		hi := r.state[i-1] / 127773
+		lo := r.state[i-1] % 127773
+		word := 16807*lo - 2836*hi
+		if word < 0 {
+			word += 2147483647
+		}
+		r.state[i] = word
+	}
+	r.f, r.r = randSep, 0
+	for i := 0; i < randWarmUp; i++ {
+		r.Rand()
+	}
+	return r
+}
+
+// Rand returns the next result of rand(), between 0 and 2^31 - 1.
+func (r *ScottFreeRand) Rand() int32 {
+	r.state[r.f] += r.state[r.r]
+	result := int32(uint32(r.state[r.f]) >> 1)
+	r.f = (r.f + 1) % randDegree
+	r.r = (r.r + 1) % randDegree
+	return result
+}
+
+// Percent returns true with the given percentage chance, exactly as
+// RandomPercent does in ScottFree.
+func (r *ScottFreeRand) Percent(n int) bool {
+	rv := uint32(r.Rand()) << 6
+	return rv%100 < uint32(n)
+}
@@ -0,0 +1,32 @@
+package game
+
+import "testing"
+
+func TestRandMatchesGlibc(t *testing.T) {
+	// The first few results of rand() after srand(1) with the GNU C library.
+	want := []int32{1804289383, 846930886, 1681692777, 1714636915, 1957747793}
+	r := NewScottFreeRand(1)
+	for i, w := range want {
+		if got := r.Rand(); got != w {
+			t.Errorf("Rand() #%d: got %d, want %d", i, got, w)
+		}
+	}
+}
+
+func TestPercentMatchesGlibc(t *testing.T) {
+	// The values of rand()<<6 % 100 that RandomPercent compares against,
+	// after srand(seed) with the GNU C library.
+	for seed, want := range map[uint32][]uint32{
+		1:       {16, 52, 28, 60, 68},
+		1234567: {48, 68, 88, 96, 16},
+	} {
+		// The roll comes up for any chance above the value, so two
+		// generators in step tell us the value exactly.
+		below, above := NewScottFreeRand(seed), NewScottFreeRand(seed)
+		for i, w := range want {
+			if below.Percent(int(w)) || !above.Percent(int(w)+1) {
+				t.Errorf("Seed %d, roll %d: want the value %d", seed, i, w)
+			}
+		}
+	}
+}
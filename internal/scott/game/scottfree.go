@@ -0,0 +1,119 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+// ScottFree saves games as plain text, with one or more integers to a line:
+//
+//	16 lines of "counter room", for the counters and location-swap registers
+//	"flags dark location counter room light", where |flags| holds the bit
+//	    flags, |dark| is 1 if the dark flag is set, |counter| is the current
+//	    counter, |room| is the location-swap register, and |light| is the
+//	    number of turns of light remaining
+//	one line for the location of each item, with 255 for the inventory
+//
+// WriteScottFreeSave and ReadScottFreeSave use this format, so that saved
+// games can be moved between the two interpreters.  Whether the game is over
+// isn't saved.
+
+// WriteScottFreeSave writes out the current state of the game in ScottFree's
+// save format.
+func (g *Game) WriteScottFreeSave(out io.Writer) error {
+	st := g.State
+	w := bufio.NewWriter(out)
+	for i := 0; i < NumCounters; i++ {
+		fmt.Fprintf(w, "%d %d\n", st.Counters[i], st.SavedRooms[i])
+	}
+
+	var flags uint32
+	for i, f := range st.Flags {
+		if f && i < 32 {
+			flags |= 1 << uint(i)
+		}
+	}
+	dark := 0
+	if g.flag(DarkFlag) {
+		dark = 1
+	}
+	fmt.Fprintf(w, "%d %d %d %d %d %d\n", flags, dark, st.Location, st.Counter, st.SavedRoom, st.LightTime)
+
+	for _, loc := range st.ItemLocations {
+		if loc == Inventory {
+			loc = Inventory255
+		}
+		fmt.Fprintf(w, "%d\n", loc)
+	}
+	return w.Flush()
+}
+
+// ReadScottFreeSave restores the state of the game from a file in ScottFree's
+// save format.  The game is left untouched if the file can't be read.
+func (g *Game) ReadScottFreeSave(in io.Reader) error {
+	r := bufio.NewReader(in)
+	n := 0 // the number of values read so far
+	next := func(what string) (int64, error) {
+		var v int64
+		if _, err := fmt.Fscan(r, &v); err != nil {
+			return 0, fmt.Errorf("Bad ScottFree save file: value %d (%s): %v", n+1, what, err)
+		}
+		n++
+		return v, nil
+	}
+
+	st := &scottpb.State{
+		Flags:      make([]bool, NumFlags),
+		Counters:   make([]int32, NumCounters),
+		SavedRooms: make([]int32, NumCounters),
+	}
+	for i := 0; i < NumCounters; i++ {
+		c, err := next("counter")
+		if err != nil {
+			return err
+		}
+		room, err := next("location-swap register")
+		if err != nil {
+			return err
+		}
+		st.Counters[i], st.SavedRooms[i] = int32(c), int32(room)
+	}
+
+	var vals [6]int64
+	for i, what := range []string{"flags", "dark flag", "location", "current counter", "location-swap register", "light time"} {
+		v, err := next(what)
+		if err != nil {
+			return err
+		}
+		vals[i] = v
+	}
+	for i := range st.Flags {
+		st.Flags[i] = vals[0]&(1<<uint(i)) != 0
+	}
+	if vals[1] != 0 {
+		// Older versions of ScottFree only saved the dark flag here.
+		st.Flags[DarkFlag] = true
+	}
+	st.Location = int32(vals[2])
+	st.Counter = int32(vals[3])
+	st.SavedRoom = int32(vals[4])
+	st.LightTime = int32(int16(vals[5]))
+
+	for range g.DB.Game.Items {
+		loc, err := next("item location")
+		if err != nil {
+			return err
+		}
+		// ScottFree keeps item locations in a single byte.
+		loc &= 0xff
+		if loc == Inventory255 {
+			loc = Inventory
+		}
+		st.ItemLocations = append(st.ItemLocations, int32(loc))
+	}
+
+	return g.RestoreState(st)
+}
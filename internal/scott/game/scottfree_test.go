@@ -0,0 +1,65 @@
+package game
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestScottFreeSaveRoundTrip(t *testing.T) {
+	g, err := LoadFromFile("../../../games/adv01.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Seed(1)
+	g.Start()
+	for _, cmd := range []string{"CLIMB TREE", "GET KEYS", "DOWN", "NORTH", "GET AXE"} {
+		g.Command(cmd)
+	}
+	g.setFlag(31, true)
+	want := g.StateHash()
+
+	b := &bytes.Buffer{}
+	if err := g.WriteScottFreeSave(b); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if n := NumCounters + 1 + len(g.DB.Game.Items); len(lines) != n {
+		t.Errorf("Wrote %d lines, want %d", len(lines), n)
+	}
+
+	g.Restart()
+	if err := g.ReadScottFreeSave(b); err != nil {
+		t.Fatal(err)
+	}
+	if g.StateHash() != want {
+		t.Errorf("Restored state differs from the one saved:\n%s", b)
+	}
+}
+
+func TestScottFreeSaveErrors(t *testing.T) {
+	g, err := LoadFromFile("../../../games/adv01.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &bytes.Buffer{}
+	g.WriteScottFreeSave(b)
+	data := b.String()
+	lines := strings.Split(data, "\n")
+	lines[NumCounters] = "0 0 999 0 0 125" // a nonexistent location
+
+	for _, bad := range []string{
+		"",
+		data[0 : len(data)/2],
+		strings.Replace(data, "0 0\n", "x 0\n", 1),
+		strings.Join(lines, "\n"),
+	} {
+		before := g.StateHash()
+		if err := g.ReadScottFreeSave(strings.NewReader(bad)); err == nil {
+			t.Errorf("ReadScottFreeSave(%q) succeeded", bad)
+		}
+		if g.StateHash() != before {
+			t.Errorf("ReadScottFreeSave(%q) changed the game", bad)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package game
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+// SaveState returns a snapshot of everything that changes during play: the
+// player's state along with the location of every item.  The snapshot shares
+// nothing with the game, so it can be kept around and restored later.
+func (g *Game) SaveState() *scottpb.State {
+	return proto.Clone(g.State).(*scottpb.State)
+}
+
+// RestoreState puts the game back into a state returned by SaveState.
+func (g *Game) RestoreState(st *scottpb.State) error {
+	if len(st.ItemLocations) != len(g.DB.Game.Items) {
+		return fmt.Errorf("state has %d items, but game has %d", len(st.ItemLocations), len(g.DB.Game.Items))
+	}
+	if len(st.Flags) != NumFlags || len(st.Counters) != NumCounters || len(st.SavedRooms) != NumCounters {
+		return fmt.Errorf("state has %d flags, %d counters and %d saved rooms", len(st.Flags), len(st.Counters), len(st.SavedRooms))
+	}
+	if st.Location < 0 || int(st.Location) >= len(g.DB.Game.Rooms) {
+		return fmt.Errorf("state has invalid location %d", st.Location)
+	}
+
+	g.State = proto.Clone(st).(*scottpb.State)
+	g.events = nil
+	g.redraw = false
+	return nil
+}
+
+// StateHash returns a hash of the current state of the game, such that two
+// games with equal hashes will behave identically from here on.
+func (g *Game) StateHash() [sha256.Size]byte {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(g.State)
+	if err != nil {
+		// This can't happen for a well-formed State.
+		panic(fmt.Sprintf("could not marshal state: %v", err))
+	}
+	return sha256.Sum256(data)
+}
@@ -0,0 +1,133 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+// A Tracer is told what the engine does as it works through the action table,
+// for debugging games.  Set Game.Tracer to use one.
+type Tracer interface {
+	// Action is called for each action that is tried: those whose words
+	// match the command, or, for automatic actions, whose chance came up,
+	// along with the continuations that follow.  The index is the position
+	// of the action in the table.
+	Action(index int, a *scottpb.Action)
+
+	// Condition is called for each condition of the action being tried, in
+	// turn, with the result.  PARAMETER entries aren't reported, and the
+	// remaining conditions aren't checked once one fails.
+	Condition(c *scottpb.Condition, ok bool)
+
+	// Command is called for each command performed, with its parameters.
+	// NOTHING isn't reported.
+	Command(t scottpb.ActionType, params []int32)
+}
+
+// traceAction reports an action to the tracer, if there is one.
+func (g *Game) traceAction(i int, a *scottpb.Action) {
+	if g.Tracer != nil {
+		g.Tracer.Action(i, a)
+	}
+}
+
+// traceCondition reports a condition to the tracer, if there is one.
+func (g *Game) traceCondition(c *scottpb.Condition, ok bool) {
+	if g.Tracer != nil && c.Type != scottpb.ConditionType_PARAMETER {
+		g.Tracer.Condition(c, ok)
+	}
+}
+
+// traceCommand reports a command to the tracer, if there is one.
+func (g *Game) traceCommand(t scottpb.ActionType, params []int32) {
+	if g.Tracer != nil && t != scottpb.ActionType_NOTHING {
+		g.Tracer.Command(t, params)
+	}
+}
+
+// TextTracer is a Tracer that writes a line of text for everything it is
+// told, naming the words, items and messages involved.
+type TextTracer struct {
+	Out  io.Writer // where the trace goes
+	Game *Game     // the game being traced, for looking up names
+}
+
+// Action writes out the action's words and comment.
+func (t *TextTracer) Action(index int, a *scottpb.Action) {
+	var words string
+	switch {
+	case a.VerbIndex == AutoVerb && a.NounIndex == 0:
+		words = "continued"
+	case a.VerbIndex == AutoVerb:
+		words = fmt.Sprintf("auto %d%%", a.NounIndex)
+	default:
+		words = t.word(t.Game.DB.Game.Verbs, a.VerbIndex)
+		if a.NounIndex != 0 {
+			words += " " + t.word(t.Game.DB.Game.Nouns, a.NounIndex)
+		}
+	}
+
+	s := fmt.Sprintf("[trace] action %d: %s", index, words)
+	if a.Comment != "" {
+		s += " -- " + a.Comment
+	}
+	fmt.Fprintln(t.Out, s)
+}
+
+// Condition writes out the condition, its value and its result.
+func (t *TextTracer) Condition(c *scottpb.Condition, ok bool) {
+	fmt.Fprintf(t.Out, "[trace]   %s %d%s: %v\n", c.Type, c.Value, t.describeCondition(c), ok)
+}
+
+// Command writes out the command and its parameters.
+func (t *TextTracer) Command(ty scottpb.ActionType, params []int32) {
+	s := "[trace]   => " + ty.String()
+	for _, p := range params {
+		s += fmt.Sprintf(" %d", p)
+	}
+	if m, ok := MessageIndex(ty); ok && m < len(t.Game.DB.Game.Messages) {
+		s += fmt.Sprintf(" %q", strings.TrimSpace(t.Game.DB.Game.Messages[m]))
+	}
+	fmt.Fprintln(t.Out, s)
+}
+
+// describeCondition names the item or room a condition refers to, if any.
+func (t *TextTracer) describeCondition(c *scottpb.Condition) string {
+	g := t.Game.DB.Game
+	switch c.Type {
+	case scottpb.ConditionType_ITEM_CARRIED,
+		scottpb.ConditionType_ITEM_IN_ROOM,
+		scottpb.ConditionType_ITEM_PRESENT,
+		scottpb.ConditionType_ITEM_NOT_IN_ROOM,
+		scottpb.ConditionType_ITEM_NOT_CARRIED,
+		scottpb.ConditionType_ITEM_NOT_PRESENT,
+		scottpb.ConditionType_ITEM_IN_GAME,
+		scottpb.ConditionType_ITEM_NOT_IN_GAME,
+		scottpb.ConditionType_ITEM_MOVED,
+		scottpb.ConditionType_ITEM_NOT_MOVED:
+		if c.Value >= 0 && int(c.Value) < len(g.Items) {
+			return fmt.Sprintf(" (%q)", g.Items[c.Value].Description)
+		}
+	case scottpb.ConditionType_PLAYER_IN_ROOM,
+		scottpb.ConditionType_PLAYER_NOT_IN_ROOM:
+		if c.Value >= 0 && int(c.Value) < len(g.Rooms) {
+			return fmt.Sprintf(" (%q)", g.Rooms[c.Value].Description)
+		}
+	case scottpb.ConditionType_COUNTER_LE,
+		scottpb.ConditionType_COUNTER_GE,
+		scottpb.ConditionType_COUNTER_EQ:
+		return fmt.Sprintf(" (counter is %d)", t.Game.State.Counter)
+	}
+	return ""
+}
+
+// word returns the text of a word, or its index if it's out of range.
+func (t *TextTracer) word(ws []*scottpb.Word, i int32) string {
+	if i < 0 || int(i) >= len(ws) {
+		return fmt.Sprintf("#%d", i)
+	}
+	return ws[i].Word
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+// recorder is a Tracer that notes which actions are tried.
+type recorder struct {
+	actions []int
+	conds   []bool
+	cmds    []scottpb.ActionType
+}
+
+func (r *recorder) Action(i int, a *scottpb.Action)              { r.actions = append(r.actions, i) }
+func (r *recorder) Condition(c *scottpb.Condition, ok bool)      { r.conds = append(r.conds, ok) }
+func (r *recorder) Command(t scottpb.ActionType, params []int32) { r.cmds = append(r.cmds, t) }
+
+// traceGame makes a game with a JUMP command that starts a continuation chain,
+// and one automatic action that always comes up and does nothing.
+func traceGame(t *testing.T) *Game {
+	jump := action(jumpVerb, 0, nil, "A", scottpb.ActionType_CONTINUE)
+	jump.Comment = "jump about"
+	return testGame(t,
+		action(0, 100, nil, ""),
+		jump,
+		action(0, 0, never, "X"),
+		action(0, 0, nil, "B"),
+		action(jumpVerb, 0, nil, "C"),
+	)
+}
+
+func TestTracer(t *testing.T) {
+	g := traceGame(t)
+	r := &recorder{}
+	g.Tracer = r
+	g.Command("JUMP")
+
+	if want := []int{1, 2, 3, 0}; !reflect.DeepEqual(r.actions, want) {
+		t.Errorf("Tracer saw actions %v, want %v", r.actions, want)
+	}
+	if want := []bool{false}; !reflect.DeepEqual(r.conds, want) {
+		t.Errorf("Tracer saw conditions %v, want %v", r.conds, want)
+	}
+	if want := "[MESSAGE_0 CONTINUE MESSAGE_1]"; fmt.Sprint(r.cmds) != want {
+		t.Errorf("Tracer saw commands %v, want %v", r.cmds, want)
+	}
+}
+
+func TestTextTracer(t *testing.T) {
+	g := traceGame(t)
+	var b bytes.Buffer
+	g.Tracer = &TextTracer{Out: &b, Game: g}
+	g.Command("JUMP")
+
+	want := `[trace] action 1: JUMP -- jump about
+[trace]   => MESSAGE_0 "A"
+[trace]   => CONTINUE
+[trace] action 2: continued
+[trace]   ITEM_CARRIED 1 ("thing"): false
+[trace] action 3: continued
+[trace]   => MESSAGE_1 "B"
+[trace] action 0: auto 100%
+`
+	if got := b.String(); got != want {
+		t.Errorf("Got trace:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+// Package graph builds a map of the world of a Scott Adams adventure, suitable
+// for rendering as a diagram.
+//
+// The map is derived statically from the game data: rooms become nodes and the
+// exits in each room become edges.  Exits in both directions between the same
+// pair of rooms are merged into a single edge, so that one-way passages stand
+// out.  Actions that move the player become conditional passages.
+package graph
+
+import (
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// Directions gives the names of the six exits, in the order used by Room.Exits.
+var Directions = []string{"North", "South", "East", "West", "Up", "Down"}
+
+// Node is a single room in the map.
+type Node struct {
+	Room        int      // the index of the room
+	Description string   // the room description, as stored in the game
+	Literal     bool     // if set, the description has no "I'm in a" prefix
+	Dark        bool     // true if the room is (probably) dark
+	Items       []string // descriptions of ordinary items initially here
+	Treasures   []string // descriptions of treasures initially here
+}
+
+// Edge is a connection between two rooms.  If exits lead in both directions,
+// they are merged into a single edge with From < To.
+type Edge struct {
+	From, To int      // the rooms connected by this edge
+	Forward  []string // directions leading from From to To
+	Backward []string // directions leading from To back to From
+}
+
+// OneWay checks if there is no exit leading back along this edge.
+func (e *Edge) OneWay() bool {
+	return len(e.Backward) == 0 && e.From != e.To
+}
+
+// Graph is the complete map of a game.
+type Graph struct {
+	Nodes    []*Node    // the rooms, excluding room 0 ("nowhere")
+	Edges    []*Edge    // the connections between rooms, in a stable order
+	Passages []*Passage // the connections made by actions, in a stable order
+	Start    int        // the index of the starting room
+	Treasure int        // the index of the treasure room
+}
+
+// New builds the map for the given game.
+func New(pb *scottpb.Game) *Graph {
+	g := &Graph{
+		Start:    int(pb.Header.StartingRoom),
+		Treasure: int(pb.Header.TreasureRoom),
+	}
+
+	dark := findDarkRooms(pb)
+	for i := 1; i < len(pb.Rooms); i++ {
+		r := pb.Rooms[i]
+		g.Nodes = append(g.Nodes, &Node{
+			Room:        i,
+			Description: r.Description,
+			Literal:     r.Literal,
+			Dark:        dark[i],
+		})
+	}
+
+	for _, it := range pb.Items {
+		n := g.Node(int(it.Location))
+		switch {
+		case n == nil:
+			// carried or not in play
+		case it.IsTreasure:
+			n.Treasures = append(n.Treasures, it.Description)
+		default:
+			n.Items = append(n.Items, it.Description)
+		}
+	}
+
+	g.addExits(pb)
+	g.addPassages(pb)
+	return g
+}
+
+// Node returns the node for the given room, or nil if there isn't one.
+func (g *Graph) Node(room int) *Node {
+	if room < 1 || room > len(g.Nodes) {
+		return nil
+	}
+	return g.Nodes[room-1]
+}
+
+// addExits adds an edge for every exit, merging exits that lead in opposite
+// directions between the same pair of rooms.
+func (g *Graph) addExits(pb *scottpb.Game) {
+	type pair struct{ from, to int }
+	edges := map[pair]*Edge{}
+
+	for i := 1; i < len(pb.Rooms); i++ {
+		for j, dest := range pb.Rooms[i].Exits {
+			to := int(dest)
+			if j >= len(Directions) || g.Node(to) == nil {
+				continue
+			}
+			if e, ok := edges[pair{to, i}]; ok && to != i {
+				e.Backward = append(e.Backward, Directions[j])
+				continue
+			}
+			e, ok := edges[pair{i, to}]
+			if !ok {
+				e = &Edge{From: i, To: to}
+				edges[pair{i, to}] = e
+				g.Edges = append(g.Edges, e)
+			}
+			e.Forward = append(e.Forward, Directions[j])
+		}
+	}
+}
+
+// findDarkRooms makes a best guess at which rooms are dark.  Darkness is a
+// global flag rather than a property of a room, so we look for the rooms in
+// which actions turn it on and off, then spread the darkness along the exits
+// from the rooms where it is turned on, stopping at rooms where it is turned
+// off.
+func findDarkRooms(pb *scottpb.Game) []bool {
+	var seeds []int32
+	lit := map[int32]bool{}
+
+	for _, a := range pb.Actions {
+		var here []int32
+		for _, c := range a.Conditions {
+			if c.Type == scottpb.ConditionType_PLAYER_IN_ROOM {
+				here = append(here, c.Value)
+			}
+		}
+
+		params := game.Params(a)
+		for _, t := range a.Actions {
+			n := game.NumParams(t)
+			if len(params) < n {
+				break
+			}
+			switch {
+			case t == scottpb.ActionType_MOVE_PLAYER:
+				here = []int32{params[0]}
+			case t == scottpb.ActionType_SET_DARKNESS,
+				t == scottpb.ActionType_SET_BIT && params[0] == game.DarkFlag:
+				seeds = append(seeds, here...)
+			case t == scottpb.ActionType_CLEAR_DARKNESS,
+				t == scottpb.ActionType_CLEAR_BIT && params[0] == game.DarkFlag:
+				for _, r := range here {
+					lit[r] = true
+				}
+			}
+			params = params[n:]
+		}
+	}
+
+	dark := make([]bool, len(pb.Rooms))
+	for len(seeds) > 0 {
+		r := seeds[0]
+		seeds = seeds[1:]
+		if r < 1 || int(r) >= len(pb.Rooms) || dark[r] || lit[r] {
+			continue
+		}
+		dark[r] = true
+		seeds = append(seeds, pb.Rooms[r].Exits...)
+	}
+	return dark
+}
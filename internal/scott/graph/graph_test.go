@@ -0,0 +1,185 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+// testGame makes a small world with the given actions:
+//
+//	                              [4 vault]
+//	                                 / (Up)
+//	      [2 hall] --East--> [3 cellar]
+//	         |
+//	[1 forest]
+//
+// The forest and the hall are joined both ways, the hall leads one way into
+// the cellar, and the vault lies above the cellar.  Room 5 can't be reached
+// on foot.  The lamp starts in the forest, the gold in the vault, and the map
+// is carried.
+func testGame(actions ...*scottpb.Action) *scottpb.Game {
+	exits := func(n, s, e, w, u, d int32) []int32 { return []int32{n, s, e, w, u, d} }
+	return &scottpb.Game{
+		Header: &scottpb.Header{
+			NumItems:     3,
+			NumRooms:     6,
+			StartingRoom: 1,
+			TreasureRoom: 2,
+		},
+		Actions: actions,
+		Verbs:   []*scottpb.Word{{Word: "AUTO"}, {Word: "GO"}, {Word: "SAY"}},
+		Nouns:   []*scottpb.Word{{Word: "ANY"}, {Word: "NORT"}, {Word: "HOLE"}, {Word: "XYZZ"}},
+		Rooms: []*scottpb.Room{
+			{Exits: exits(0, 0, 0, 0, 0, 0)},
+			{Description: "forest", Exits: exits(2, 0, 0, 0, 0, 0)},
+			{Description: "hall", Exits: exits(0, 1, 3, 0, 0, 0)},
+			{Description: "cellar", Exits: exits(0, 0, 0, 0, 4, 0)},
+			{Description: "vault", Exits: exits(0, 0, 0, 0, 0, 3)},
+			{Description: "Nowhere special", Literal: true, Exits: exits(0, 0, 0, 0, 0, 0)},
+		},
+		Items: []*scottpb.Item{
+			{Description: "Lamp", Location: 1},
+			{Description: "*Gold*", Location: 4, IsTreasure: true},
+			{Description: "Map", Location: -1},
+		},
+		Footer: &scottpb.Footer{Adventure: 99},
+	}
+}
+
+// inRoom is a condition that holds when the player is in the given room.
+func inRoom(room int32) *scottpb.Condition {
+	return &scottpb.Condition{Type: scottpb.ConditionType_PLAYER_IN_ROOM, Value: room}
+}
+
+func TestNew(t *testing.T) {
+	g := New(testGame(&scottpb.Action{
+		Conditions: []*scottpb.Condition{inRoom(3)},
+		Actions:    []scottpb.ActionType{scottpb.ActionType_SET_DARKNESS},
+	}))
+
+	if g.Start != 1 || g.Treasure != 2 {
+		t.Errorf("Start and Treasure are %d and %d, want 1 and 2", g.Start, g.Treasure)
+	}
+	if len(g.Nodes) != 5 {
+		t.Fatalf("New made %d nodes, want 5", len(g.Nodes))
+	}
+	for _, tc := range []struct {
+		room      int
+		desc      string
+		literal   bool
+		dark      bool
+		items     []string
+		treasures []string
+	}{
+		{1, "forest", false, false, []string{"Lamp"}, nil},
+		{2, "hall", false, false, nil, nil},
+		{3, "cellar", false, true, nil, nil},
+		{4, "vault", false, true, nil, []string{"*Gold*"}},
+		{5, "Nowhere special", true, false, nil, nil},
+	} {
+		n := g.Node(tc.room)
+		if n == nil {
+			t.Errorf("Node(%d) is nil", tc.room)
+			continue
+		}
+		if n.Room != tc.room || n.Description != tc.desc || n.Literal != tc.literal {
+			t.Errorf("Node(%d) is %d %q (literal %v), want %d %q (literal %v)", tc.room, n.Room, n.Description, n.Literal, tc.room, tc.desc, tc.literal)
+		}
+		if n.Dark != tc.dark {
+			t.Errorf("Node(%d) dark is %v, want %v", tc.room, n.Dark, tc.dark)
+		}
+		if !reflect.DeepEqual(n.Items, tc.items) || !reflect.DeepEqual(n.Treasures, tc.treasures) {
+			t.Errorf("Node(%d) holds %q and %q, want %q and %q", tc.room, n.Items, n.Treasures, tc.items, tc.treasures)
+		}
+	}
+	for _, room := range []int{-1, 0, 6} {
+		if n := g.Node(room); n != nil {
+			t.Errorf("Node(%d) is %+v, want nil", room, n)
+		}
+	}
+
+	want := []Edge{
+		{From: 1, To: 2, Forward: []string{"North"}, Backward: []string{"South"}},
+		{From: 2, To: 3, Forward: []string{"East"}},
+		{From: 3, To: 4, Forward: []string{"Up"}, Backward: []string{"Down"}},
+	}
+	if len(g.Edges) != len(want) {
+		t.Fatalf("New made %d edges, want %d", len(g.Edges), len(want))
+	}
+	for i, e := range g.Edges {
+		if !reflect.DeepEqual(*e, want[i]) {
+			t.Errorf("Edge %d is %+v, want %+v", i, *e, want[i])
+		}
+		if got, want := e.OneWay(), i == 1; got != want {
+			t.Errorf("Edge %d OneWay is %v, want %v", i, got, want)
+		}
+	}
+	if len(g.Passages) != 0 {
+		t.Errorf("New made %d passages, want none", len(g.Passages))
+	}
+}
+
+func TestNewDarknessStops(t *testing.T) {
+	// Darkness falls in the hall and spreads along the exits, except into the
+	// vault, where it is turned off again.
+	g := New(testGame(
+		&scottpb.Action{
+			Conditions: []*scottpb.Condition{inRoom(2)},
+			Actions:    []scottpb.ActionType{scottpb.ActionType_SET_DARKNESS},
+		},
+		&scottpb.Action{
+			Conditions: []*scottpb.Condition{inRoom(4)},
+			Actions:    []scottpb.ActionType{scottpb.ActionType_CLEAR_DARKNESS},
+		},
+	))
+	var dark []int
+	for _, n := range g.Nodes {
+		if n.Dark {
+			dark = append(dark, n.Room)
+		}
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(dark, want) {
+		t.Errorf("Dark rooms are %v, want %v", dark, want)
+	}
+}
+
+func TestLayout(t *testing.T) {
+	g := New(testGame())
+	pos := g.Layout()
+
+	if len(pos) != len(g.Nodes) {
+		t.Fatalf("Layout placed %d rooms, want %d", len(pos), len(g.Nodes))
+	}
+	used := map[Point]int{}
+	for room, p := range pos {
+		if other, ok := used[p]; ok {
+			t.Errorf("Rooms %d and %d are both at %v", room, other, p)
+		}
+		used[p] = room
+		if p.X < 0 || p.Y < 0 {
+			t.Errorf("Room %d is at %v, outside the grid", room, p)
+		}
+	}
+
+	for _, tc := range []struct {
+		from, to int
+		delta    Point
+	}{
+		{1, 2, Point{0, -1}}, // North
+		{2, 3, Point{1, 0}},  // East
+		{3, 4, Point{1, -1}}, // Up
+	} {
+		if got := (Point{pos[tc.to].X - pos[tc.from].X, pos[tc.to].Y - pos[tc.from].Y}); got != tc.delta {
+			t.Errorf("Room %d is %v from room %d, want %v", tc.to, got, tc.from, tc.delta)
+		}
+	}
+
+	// The unreachable room goes below everything else.
+	for room, p := range pos {
+		if room != 5 && p.Y >= pos[5].Y {
+			t.Errorf("Room %d is at %v, not above the unreachable room at %v", room, p, pos[5])
+		}
+	}
+}
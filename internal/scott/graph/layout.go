@@ -0,0 +1,139 @@
+package graph
+
+// Point is a position on the layout grid, in cells.
+type Point struct {
+	X, Y int
+}
+
+// deltas gives the preferred grid offset for each of the six directions.
+// There's no third dimension on paper, so up and down are drawn diagonally.
+var deltas = map[string]Point{
+	"North": {0, -1},
+	"South": {0, 1},
+	"East":  {1, 0},
+	"West":  {-1, 0},
+	"Up":    {1, -1},
+	"Down":  {-1, 1},
+}
+
+// Layout assigns every node a distinct cell on a grid, trying to honour the
+// compass directions of the exits, so that the result looks like a hand-drawn
+// adventure map.  The starting room is placed first and the rest of the world
+// is explored breadth-first from it; rooms that can't be reached by walking
+// are laid out afterwards in the same way, below everything else.  Passages
+// are only used to place rooms that have no exits leading to them.  The result
+// is normalized so that the smallest coordinates are zero.
+func (g *Graph) Layout() map[int]Point {
+	pos := map[int]Point{}
+	used := map[Point]bool{}
+	place := func(room int, want Point) {
+		p := nearestFree(used, want)
+		pos[room] = p
+		used[p] = true
+	}
+
+	// Build an undirected adjacency list that remembers the direction of
+	// travel from each end.
+	type link struct {
+		to  int
+		dir Point
+	}
+	adj := map[int][]link{}
+	for _, e := range g.Edges {
+		for _, d := range e.Forward {
+			adj[e.From] = append(adj[e.From], link{e.To, deltas[d]})
+			adj[e.To] = append(adj[e.To], link{e.From, Point{-deltas[d].X, -deltas[d].Y}})
+		}
+		for _, d := range e.Backward {
+			adj[e.To] = append(adj[e.To], link{e.From, deltas[d]})
+			adj[e.From] = append(adj[e.From], link{e.To, Point{-deltas[d].X, -deltas[d].Y}})
+		}
+	}
+	// Passages have no direction, so we just try to keep them close by.
+	for _, p := range g.Passages {
+		if p.From != Anywhere {
+			adj[p.From] = append(adj[p.From], link{p.To, Point{1, 1}})
+			adj[p.To] = append(adj[p.To], link{p.From, Point{-1, -1}})
+		}
+	}
+
+	roots := []int{g.Start}
+	for _, n := range g.Nodes {
+		roots = append(roots, n.Room)
+	}
+
+	for _, root := range roots {
+		if _, ok := pos[root]; ok || g.Node(root) == nil {
+			continue
+		}
+		place(root, Point{0, bottom(used) + 2})
+
+		queue := []int{root}
+		for len(queue) > 0 {
+			r := queue[0]
+			queue = queue[1:]
+			for _, l := range adj[r] {
+				if _, ok := pos[l.to]; ok {
+					continue
+				}
+				place(l.to, Point{pos[r].X + l.dir.X, pos[r].Y + l.dir.Y})
+				queue = append(queue, l.to)
+			}
+		}
+	}
+
+	minX, minY := 0, 0
+	first := true
+	for _, p := range pos {
+		if first || p.X < minX {
+			minX = p.X
+		}
+		if first || p.Y < minY {
+			minY = p.Y
+		}
+		first = false
+	}
+	for r, p := range pos {
+		pos[r] = Point{p.X - minX, p.Y - minY}
+	}
+	return pos
+}
+
+// nearestFree finds the unused cell closest to |want|, searching outwards in
+// rings of increasing size.
+func nearestFree(used map[Point]bool, want Point) Point {
+	if !used[want] {
+		return want
+	}
+	for d := 1; ; d++ {
+		for dy := -d; dy <= d; dy++ {
+			for dx := -d; dx <= d; dx++ {
+				if abs(dx) != d && abs(dy) != d {
+					continue // interior of the ring, already checked
+				}
+				if p := (Point{want.X + dx, want.Y + dy}); !used[p] {
+					return p
+				}
+			}
+		}
+	}
+}
+
+// bottom returns the largest Y coordinate in use, or -2 if there are none.
+func bottom(used map[Point]bool) int {
+	y := -2
+	for p := range used {
+		if p.Y > y {
+			y = p.Y
+		}
+	}
+	return y
+}
+
+// abs returns the absolute value of an integer.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
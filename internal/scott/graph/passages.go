@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// Anywhere is used as the source room of a passage that doesn't depend on the
+// location of the player, such as a magic word.
+const Anywhere = -1
+
+// Passage is a conditional connection between two rooms that comes from an
+// action rather than an exit, such as CLIMB TREE or GO HOLE.
+type Passage struct {
+	From, To int    // the rooms connected; From may be Anywhere
+	Label    string // the command that triggers the passage, e.g., "CLIMB TREE"
+	Action   int    // the index of the action responsible
+	Swap     bool   // true if the passage comes from swapping locations
+}
+
+// addPassages scans the actions for commands that move the player and adds a
+// passage for each of them.
+//
+// The source room comes from a PLAYER_IN_ROOM condition if there is one;
+// failing that, from the initial location of an item that must be in the room
+// (e.g., the HOLE in GO HOLE).  Otherwise, the passage works from anywhere.
+//
+// SWAP_LOCATION sends the player back to wherever the last swap happened, which
+// we can't know statically, so we connect every pair of rooms in which swaps
+// with the same register happen.
+func (g *Graph) addPassages(pb *scottpb.Game) {
+	swaps := map[int32][]*Passage{}
+
+	for i, a := range pb.Actions {
+		from := g.actionRoom(pb, a)
+		label := actionLabel(pb, a)
+
+		params := game.Params(a)
+		for _, t := range a.Actions {
+			n := game.NumParams(t)
+			if len(params) < n {
+				break
+			}
+			switch t {
+			case scottpb.ActionType_MOVE_PLAYER:
+				if to := int(params[0]); g.Node(to) != nil && to != from {
+					g.Passages = append(g.Passages, &Passage{From: from, To: to, Label: label, Action: i})
+				}
+			case scottpb.ActionType_SWAP_LOCATION:
+				if from != Anywhere {
+					swaps[-1] = append(swaps[-1], &Passage{From: from, Label: label, Action: i, Swap: true})
+				}
+			case scottpb.ActionType_SWAP_LOCATION_N:
+				if from != Anywhere {
+					swaps[params[0]] = append(swaps[params[0]], &Passage{From: from, Label: label, Action: i, Swap: true})
+				}
+			}
+			params = params[n:]
+		}
+	}
+
+	for _, reg := range sortedKeys(swaps) {
+		for _, p := range swaps[reg] {
+			for _, q := range swaps[reg] {
+				if p.From != q.From {
+					swap := *p
+					swap.To = q.From
+					g.Passages = append(g.Passages, &swap)
+				}
+			}
+		}
+	}
+}
+
+// actionRoom works out the room in which an action can fire, or Anywhere.
+func (g *Graph) actionRoom(pb *scottpb.Game, a *scottpb.Action) int {
+	for _, c := range a.Conditions {
+		if c.Type == scottpb.ConditionType_PLAYER_IN_ROOM && g.Node(int(c.Value)) != nil {
+			return int(c.Value)
+		}
+	}
+	for _, c := range a.Conditions {
+		switch c.Type {
+		case scottpb.ConditionType_ITEM_IN_ROOM, scottpb.ConditionType_ITEM_PRESENT:
+			if c.Value >= 0 && int(c.Value) < len(pb.Items) {
+				if r := int(pb.Items[c.Value].Location); g.Node(r) != nil {
+					return r
+				}
+			}
+		}
+	}
+	return Anywhere
+}
+
+// actionLabel describes the command that triggers an action.
+func actionLabel(pb *scottpb.Game, a *scottpb.Action) string {
+	if a.VerbIndex == game.AutoVerb {
+		return fmt.Sprintf("(auto %d%%)", a.NounIndex)
+	}
+	verb, noun := fmt.Sprintf("#%d", a.VerbIndex), fmt.Sprintf("#%d", a.NounIndex)
+	if int(a.VerbIndex) < len(pb.Verbs) {
+		verb = pb.Verbs[a.VerbIndex].Word
+	}
+	if a.NounIndex == 0 {
+		return verb
+	}
+	if int(a.NounIndex) < len(pb.Nouns) {
+		noun = pb.Nouns[a.NounIndex].Word
+	}
+	return verb + " " + noun
+}
+
+// sortedKeys returns the keys of a map of swap registers in increasing order.
+func sortedKeys(m map[int32][]*Passage) []int32 {
+	var ks []int32
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Slice(ks, func(i, j int) bool { return ks[i] < ks[j] })
+	return ks
+}
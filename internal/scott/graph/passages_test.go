@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+// param is a parameter condition, used to pass a value to a command.
+func param(v int32) *scottpb.Condition {
+	return &scottpb.Condition{Type: scottpb.ConditionType_PARAMETER, Value: v}
+}
+
+// move makes an action for the given words that performs the given commands
+// if the conditions hold.
+func move(verb, noun int32, conds []*scottpb.Condition, cmds ...scottpb.ActionType) *scottpb.Action {
+	return &scottpb.Action{VerbIndex: verb, NounIndex: noun, Conditions: conds, Actions: cmds}
+}
+
+func TestPassages(t *testing.T) {
+	const (
+		goVerb  = 1
+		sayVerb = 2
+		hole    = 2
+		xyzzy   = 3
+		present = scottpb.ConditionType_ITEM_PRESENT
+		moveTo  = scottpb.ActionType_MOVE_PLAYER
+		swap    = scottpb.ActionType_SWAP_LOCATION
+		swapN   = scottpb.ActionType_SWAP_LOCATION_N
+	)
+	g := New(testGame(
+		// 0: GO HOLE, where the lamp is, leads to the cellar.
+		move(goVerb, hole, []*scottpb.Condition{{Type: present, Value: 0}, param(3)}, moveTo),
+		// 1: SAY XYZZY leads to room 5 from anywhere.
+		move(sayVerb, xyzzy, []*scottpb.Condition{param(5)}, moveTo),
+		// 2, 3: Moving to the same room, or to no room at all, isn't a passage.
+		move(sayVerb, xyzzy, []*scottpb.Condition{inRoom(2), param(2)}, moveTo),
+		move(sayVerb, xyzzy, []*scottpb.Condition{inRoom(2), param(9)}, moveTo),
+		// 4: The player is thrown out of the vault half the time.
+		move(0, 50, []*scottpb.Condition{inRoom(4), param(1)}, moveTo),
+		// 5: The parameters of earlier commands are skipped over.
+		move(goVerb, hole, []*scottpb.Condition{inRoom(3), param(1), param(5)}, scottpb.ActionType_DROP_ITEM, moveTo),
+		// 6, 7: Swapping in the hall and the vault connects them both ways.
+		move(sayVerb, xyzzy, []*scottpb.Condition{inRoom(2)}, swap),
+		move(sayVerb, xyzzy, []*scottpb.Condition{inRoom(4)}, swap),
+		// 8, 9: Likewise for the forest and the cellar, with register 3.
+		move(goVerb, hole, []*scottpb.Condition{inRoom(1), param(3)}, swapN),
+		move(goVerb, hole, []*scottpb.Condition{inRoom(3), param(3)}, swapN),
+		// 10: Swaps from anywhere can't be placed.
+		move(goVerb, hole, nil, swap),
+	))
+
+	want := []Passage{
+		{From: 1, To: 3, Label: "GO HOLE", Action: 0},
+		{From: Anywhere, To: 5, Label: "SAY XYZZ", Action: 1},
+		{From: 4, To: 1, Label: "(auto 50%)", Action: 4},
+		{From: 3, To: 5, Label: "GO HOLE", Action: 5},
+		{From: 2, To: 4, Label: "SAY XYZZ", Action: 6, Swap: true},
+		{From: 4, To: 2, Label: "SAY XYZZ", Action: 7, Swap: true},
+		{From: 1, To: 3, Label: "GO HOLE", Action: 8, Swap: true},
+		{From: 3, To: 1, Label: "GO HOLE", Action: 9, Swap: true},
+	}
+	var got []Passage
+	for _, p := range g.Passages {
+		got = append(got, *p)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Passages are:\n%+v\nwant:\n%+v", got, want)
+	}
+}
+
+func TestActionLabel(t *testing.T) {
+	pb := testGame()
+	for _, tc := range []struct {
+		verb, noun int32
+		want       string
+	}{
+		{1, 0, "GO"},
+		{2, 3, "SAY XYZZ"},
+		{0, 25, "(auto 25%)"},
+		{7, 1, "#7 NORT"},
+		{1, 8, "GO #8"},
+	} {
+		a := &scottpb.Action{VerbIndex: tc.verb, NounIndex: tc.noun}
+		if got := actionLabel(pb, a); got != tc.want {
+			t.Errorf("actionLabel(%d, %d) = %q, want %q", tc.verb, tc.noun, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// addGames seeds a fuzz target with the bundled game files.
+func addGames(f *testing.F) {
+	paths, err := filepath.Glob("../../../games/*.dat")
+	if err != nil || len(paths) == 0 {
+		f.Fatalf("Could not find the game files: %v", err)
+	}
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			f.Fatalf("Could not read %q: %v", path, err)
+		}
+		f.Add(data)
+	}
+}
+
+// FuzzParse checks that parsing never panics, and that the three ways of
+// parsing agree.
+func FuzzParse(f *testing.F) {
+	addGames(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pb, err := Parse(data)
+		pb2, err2 := ParseReader(bytes.NewReader(data))
+		if (err == nil) != (err2 == nil) {
+			t.Fatalf("Parse returned %v, but ParseReader returned %v", err, err2)
+		}
+		if err == nil && !proto.Equal(pb, pb2) {
+			t.Fatalf("Parse and ParseReader returned different games")
+		}
+
+		pb3, _, err3 := ParseLenient(data)
+		if err == nil && (err3 != nil || !proto.Equal(pb, pb3)) {
+			t.Fatalf("ParseLenient returned %v for a game that Parse accepts", err3)
+		}
+	})
+}
@@ -7,23 +7,51 @@ package parser
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/chaosotter/golang-adventures/api/scottpb"
 	"github.com/chaosotter/golang-adventures/internal/scott/stream"
 )
 
-// Parse attempts to initialize a new Game proto from the game file.
+// Parse attempts to initialize a new Game proto from the game file.  Errors
+// with the data are reported as a *stream.Error, giving the position of the
+// problem within the file.
 func Parse(data []byte) (*scottpb.Game, error) {
 	s, err := stream.New(data)
 	if err != nil {
-		return nil, fmt.Errorf("Could not tokenize game data: %v", err)
+		return nil, stream.Annotate(err, "Could not tokenize game data")
 	}
+	return parse(s)
+}
+
+// ParseReader is like Parse, but reads the game file incrementally, so that
+// the whole file is never held in memory.
+func ParseReader(in io.Reader) (*scottpb.Game, error) {
+	return parse(stream.NewReader(in))
+}
+
+// ParseLenient is like Parse, but recovers from damaged game files as best it
+// can rather than failing.  Stray characters are skipped, tokens of the wrong
+// type are skipped, and anything missing at the end of the file (typically the
+// comments) is filled in with zeroes and empty strings.  Each problem found is
+// returned as a warning.  An error is returned only if so much is missing that
+// the header can't be trusted.
+func ParseLenient(data []byte) (*scottpb.Game, []*stream.Error, error) {
+	s, warnings := stream.NewLenient(data)
+	l := &lenient{s: s, warnings: warnings}
+	pb, err := parse(l)
+	return pb, l.warnings, err
+}
+
+// parse initializes a new Game proto from a source of tokens.
+func parse(s stream.Source) (*scottpb.Game, error) {
+	l, _ := s.(*lenient)
 
 	pb := &scottpb.Game{}
 	for _, step := range []struct {
 		phase string
-		fn    func(*scottpb.Game, *stream.Stream) error
+		fn    func(*scottpb.Game, stream.Source) error
 	}{
 		{"header", loadHeader},
 		{"actions", loadActions},
@@ -34,8 +62,11 @@ func Parse(data []byte) (*scottpb.Game, error) {
 		{"comments", loadComments},
 		{"footer", loadFooter},
 	} {
+		if l != nil {
+			l.phase = step.phase
+		}
 		if err := step.fn(pb, s); err != nil {
-			return nil, fmt.Errorf("Error parsing %s: %v", step.phase, err)
+			return nil, stream.Annotate(err, "Error parsing %s", step.phase)
 		}
 	}
 
@@ -43,7 +74,7 @@ func Parse(data []byte) (*scottpb.Game, error) {
 }
 
 // loadHeader loads in the game header, which consists of 14 integer values.
-func loadHeader(pb *scottpb.Game, s *stream.Stream) error {
+func loadHeader(pb *scottpb.Game, s stream.Source) error {
 	h := &scottpb.Header{}
 	for _, field := range []*int32{
 		&h.Unknown0,
@@ -78,17 +109,18 @@ func loadHeader(pb *scottpb.Game, s *stream.Stream) error {
 }
 
 // loadActions loads in the actions.  Each action has the following form:
-//   (150 * verb index) + noun index
-//   5x conditions, expressed as condition type + (20 * value)
-//   (150 * action0 type) + action1 type
-//   (150 * action2 type) + action3 type
-func loadActions(pb *scottpb.Game, s *stream.Stream) error {
+//
+//	(150 * verb index) + noun index
+//	5x conditions, expressed as condition type + (20 * value)
+//	(150 * action0 type) + action1 type
+//	(150 * action2 type) + action3 type
+func loadActions(pb *scottpb.Game, s stream.Source) error {
 	for i := 0; i < int(pb.Header.NumActions); i++ {
 		a := &scottpb.Action{}
 
 		val, err := s.NextInt()
 		if err != nil {
-			return fmt.Errorf("Action %d: %v", i, err)
+			return stream.Annotate(err, "Action %d", i)
 		}
 		a.VerbIndex = int32(val / 150)
 		a.NounIndex = int32(val % 150)
@@ -96,7 +128,7 @@ func loadActions(pb *scottpb.Game, s *stream.Stream) error {
 		for j := 0; j < 5; j++ {
 			val, err := s.NextInt()
 			if err != nil {
-				return fmt.Errorf("Action %d, condition %d: %v", i, j, err)
+				return stream.Annotate(err, "Action %d, condition %d", i, j)
 			}
 			a.Conditions = append(a.Conditions, &scottpb.Condition{
 				Type:  (scottpb.ConditionType)(val % 20),
@@ -107,7 +139,7 @@ func loadActions(pb *scottpb.Game, s *stream.Stream) error {
 		for j := 0; j < 2; j++ {
 			val, err := s.NextInt()
 			if err != nil {
-				return fmt.Errorf("Action %d, action value %d: %v", i, j, err)
+				return stream.Annotate(err, "Action %d, action value %d", i, j)
 			}
 			a.Actions = append(a.Actions, (scottpb.ActionType)(val/150))
 			a.Actions = append(a.Actions, (scottpb.ActionType)(val%150))
@@ -121,17 +153,17 @@ func loadActions(pb *scottpb.Game, s *stream.Stream) error {
 
 // loadWords loads in the verbs and nouns, which are an interleaved array of
 // strings.  An initial "*" indicates a synonym.
-func loadWords(pb *scottpb.Game, s *stream.Stream) error {
+func loadWords(pb *scottpb.Game, s stream.Source) error {
 	for i := 0; i < int(pb.Header.NumWords); i++ {
 		val, err := s.NextString()
 		if err != nil {
-			return fmt.Errorf("Verb %d: %v", i, err)
+			return stream.Annotate(err, "Verb %d", i)
 		}
 		pb.Verbs = append(pb.Verbs, makeWord(val))
 
 		val, err = s.NextString()
 		if err != nil {
-			return fmt.Errorf("Noun %d: %v", i, err)
+			return stream.Annotate(err, "Noun %d", i)
 		}
 		pb.Nouns = append(pb.Nouns, makeWord(val))
 	}
@@ -151,20 +183,20 @@ func makeWord(raw string) *scottpb.Word {
 // loadRooms loads in the rooms, each of which consists of six directions
 // followed by a description.  The description starts with "*" to indicate that
 // it stands alone, with no "I'm in a" prefix.
-func loadRooms(pb *scottpb.Game, s *stream.Stream) error {
+func loadRooms(pb *scottpb.Game, s stream.Source) error {
 	for i := 0; i < int(pb.Header.NumRooms); i++ {
 		r := &scottpb.Room{}
 		for j := 0; j < 6; j++ { // north, south, east, west, up, down
 			val, err := s.NextInt()
 			if err != nil {
-				return fmt.Errorf("Room %d, direction %d: %v", i, j, err)
+				return stream.Annotate(err, "Room %d, direction %d", i, j)
 			}
 			r.Exits = append(r.Exits, int32(val))
 		}
 
 		desc, err := s.NextString()
 		if err != nil {
-			return fmt.Errorf("Room %d, description: %v", i, err)
+			return stream.Annotate(err, "Room %d, description", i)
 		}
 		if len(desc) > 0 && desc[0] == '*' {
 			r.Description = desc[1:len(desc)]
@@ -180,11 +212,11 @@ func loadRooms(pb *scottpb.Game, s *stream.Stream) error {
 }
 
 // loadMessages loads in the messages, which are simply an array of strings.
-func loadMessages(pb *scottpb.Game, s *stream.Stream) error {
+func loadMessages(pb *scottpb.Game, s stream.Source) error {
 	for i := 0; i < int(pb.Header.NumMessages); i++ {
 		val, err := s.NextString()
 		if err != nil {
-			return fmt.Errorf("Message %d: %v", i, err)
+			return stream.Annotate(err, "Message %d", i)
 		}
 
 		pb.Messages = append(pb.Messages, val)
@@ -197,13 +229,14 @@ func loadMessages(pb *scottpb.Game, s *stream.Stream) error {
 // and a room number indicating the initial location.  Treasures are indicated
 // with a leading "*".  If the description has a suffix of /XXX/, then automatic
 // GET and DROP operations can be performed using "XXX" as a noun.
-func loadItems(pb *scottpb.Game, s *stream.Stream) error {
+func loadItems(pb *scottpb.Game, s stream.Source) error {
 	for i := 0; i < int(pb.Header.NumItems); i++ {
 		it := &scottpb.Item{}
 
+		start := s.Pos()
 		val, err := s.NextString()
 		if err != nil {
-			return fmt.Errorf("Item %d, description: %v", i, err)
+			return stream.Annotate(err, "Item %d, description", i)
 		}
 		if len(val) > 0 {
 			if val[0] == '*' {
@@ -218,12 +251,19 @@ func loadItems(pb *scottpb.Game, s *stream.Stream) error {
 			}
 		}
 
+		loc := s.Pos()
 		val2, err := s.NextInt()
 		if err != nil {
-			return fmt.Errorf("Item %d, location: %v", i, err)
+			return stream.Annotate(err, "Item %d, location", i)
 		}
 		it.Location = int32(val2)
 
+		// Note the layout of the first item, so that we can write it back out.
+		if i == 0 {
+			end := start.Line + strings.Count(val, "\n")
+			pb.Layout = &scottpb.Layout{ItemLocationOnOwnLine: loc.Line > end}
+		}
+
 		pb.Items = append(pb.Items, it)
 	}
 
@@ -231,11 +271,11 @@ func loadItems(pb *scottpb.Game, s *stream.Stream) error {
 }
 
 // loadComments loads in the comments, which annotate the actions.
-func loadComments(pb *scottpb.Game, s *stream.Stream) error {
+func loadComments(pb *scottpb.Game, s stream.Source) error {
 	for i := 0; i < int(pb.Header.NumActions); i++ {
 		val, err := s.NextString()
 		if err != nil {
-			return fmt.Errorf("Comment %d: %v", i, err)
+			return stream.Annotate(err, "Comment %d", i)
 		}
 
 		pb.Actions[i].Comment = val
@@ -245,7 +285,7 @@ func loadComments(pb *scottpb.Game, s *stream.Stream) error {
 }
 
 // loadFooter loads in the game footer, which consists of 3 integer values.
-func loadFooter(pb *scottpb.Game, s *stream.Stream) error {
+func loadFooter(pb *scottpb.Game, s stream.Source) error {
 	f := &scottpb.Footer{}
 	for _, field := range []*int32{
 		&f.Version,
@@ -262,3 +302,94 @@ func loadFooter(pb *scottpb.Game, s *stream.Stream) error {
 	pb.Footer = f
 	return nil
 }
+
+// maxPadding is the most values that lenient parsing will make up to fill in
+// for a truncated file before giving up.
+const maxPadding = 10000
+
+// lenient wraps a stream.Source for ParseLenient, skipping over tokens of the
+// wrong type and making up values once the end of the input is reached.
+type lenient struct {
+	s        stream.Source
+	phase    string          // the part of the file being parsed
+	warnings []*stream.Error // the problems found so far
+	tokens   int             // the number of tokens read so far
+	padded   int             // the number of values made up so far
+	padPhase string          // the last part of the file that needed padding
+}
+
+// Done checks if we're at the end of the input.
+func (l *lenient) Done() bool {
+	return l.s.Done()
+}
+
+// Pos returns the position of the next token.
+func (l *lenient) Pos() stream.Pos {
+	return l.s.Pos()
+}
+
+// NextInt returns the next integer, skipping any strings in the way, or 0 at
+// the end of the input.
+func (l *lenient) NextInt() (int, error) {
+	for !l.s.Done() {
+		val, err := l.s.NextInt()
+		if err == nil {
+			l.tokens++
+			return val, nil
+		}
+		l.warn(err, "skipping it")
+		if _, err := l.s.NextString(); err != nil {
+			return 0, err
+		}
+		l.tokens++
+	}
+	return 0, l.pad("0")
+}
+
+// NextString returns the next string, skipping any integers in the way, or ""
+// at the end of the input.
+func (l *lenient) NextString() (string, error) {
+	for !l.s.Done() {
+		val, err := l.s.NextString()
+		if err == nil {
+			l.tokens++
+			return val, nil
+		}
+		l.warn(err, "skipping it")
+		if _, err := l.s.NextInt(); err != nil {
+			return "", err
+		}
+		l.tokens++
+	}
+	return "", l.pad(`""`)
+}
+
+// warn records a problem, noting the part of the file and what was done.
+func (l *lenient) warn(err error, action string) {
+	se, ok := stream.Annotate(err, "Error parsing %s", l.phase).(*stream.Error)
+	if !ok {
+		se = &stream.Error{Pos: l.s.Pos(), Token: l.tokens, Msg: err.Error()}
+	}
+	se.Msg += "; " + action
+	l.warnings = append(l.warnings, se)
+}
+
+// pad notes that a value has been made up at the end of the input.  Only the
+// first value made up for each part of the file gets a warning.
+func (l *lenient) pad(val string) error {
+	if l.padded == maxPadding {
+		return &stream.Error{Pos: l.s.Pos(), Token: l.tokens, Msg: "Too much data missing at end of file"}
+	}
+	l.padded++
+
+	if l.padPhase == l.phase {
+		return nil
+	}
+	l.padPhase = l.phase
+	l.warnings = append(l.warnings, &stream.Error{
+		Pos:   l.s.Pos(),
+		Token: l.tokens,
+		Msg:   fmt.Sprintf("Error parsing %s: Premature end of stream; using %s for this and any further values", l.phase, val),
+	})
+	return nil
+}
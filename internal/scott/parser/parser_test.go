@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+func TestParseLenient(t *testing.T) {
+	data, err := ioutil.ReadFile("../../../games/adv01.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	full, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := string(data)
+
+	for _, tc := range []struct {
+		name     string
+		in       string
+		warnings []string // the messages of the warnings, in order
+		err      bool
+		want     func(pb *scottpb.Game) // makes the expected game from the full one
+	}{{
+		name: "intact",
+		in:   game,
+		want: func(pb *scottpb.Game) {},
+	}, {
+		name: "truncated footer",
+		in:   strings.TrimSuffix(game, " 416 \n 1 \n 819 \n"),
+		warnings: []string{
+			"Error parsing footer: Premature end of stream; using 0 for this and any further values",
+		},
+		want: func(pb *scottpb.Game) { pb.Footer = &scottpb.Footer{} },
+	}, {
+		name: "truncated header",
+		in:   " 5953 \n 65 \n 169 \n",
+		warnings: []string{
+			"Error parsing header: Premature end of stream; using 0 for this and any further values",
+			"Error parsing actions: Premature end of stream; using 0 for this and any further values",
+			"Error parsing words: Premature end of stream; using \"\" for this and any further values",
+			"Error parsing rooms: Premature end of stream; using 0 for this and any further values",
+			"Error parsing messages: Premature end of stream; using \"\" for this and any further values",
+			"Error parsing items: Premature end of stream; using \"\" for this and any further values",
+			"Error parsing comments: Premature end of stream; using \"\" for this and any further values",
+			"Error parsing footer: Premature end of stream; using 0 for this and any further values",
+		},
+	}, {
+		name: "too much missing",
+		in:   " 0 \n 10 \n 5000 \n",
+		warnings: []string{
+			"Error parsing header: Premature end of stream; using 0 for this and any further values",
+			"Error parsing actions: Premature end of stream; using 0 for this and any further values",
+		},
+		err: true,
+	}, {
+		name: "string for an integer",
+		in:   strings.Replace(game, " 65 \n", " 65 \n\"junk\"\n", 1),
+		warnings: []string{
+			"Error parsing header: Expected an integer, found the string \"junk\"; skipping it",
+		},
+		want: func(pb *scottpb.Game) {},
+	}, {
+		name: "stray characters",
+		in:   strings.Replace(game, " 65 \n", " 65 \n@\n", 1),
+		warnings: []string{
+			"Skipping unexpected character '@' between tokens",
+		},
+		want: func(pb *scottpb.Game) {},
+	}} {
+		pb, warnings, err := ParseLenient([]byte(tc.in))
+		if (err != nil) != tc.err {
+			t.Errorf("%s: ParseLenient returned error %v, want error %v", tc.name, err, tc.err)
+		}
+		var msgs []string
+		for _, w := range warnings {
+			msgs = append(msgs, w.Msg)
+		}
+		if !reflect.DeepEqual(msgs, tc.warnings) {
+			t.Errorf("%s: ParseLenient warned:\n%q\nwant:\n%q", tc.name, msgs, tc.warnings)
+		}
+		if tc.err {
+			if pb != nil {
+				t.Errorf("%s: ParseLenient returned a game along with an error", tc.name)
+			}
+			continue
+		}
+		if pb == nil {
+			t.Errorf("%s: ParseLenient returned no game", tc.name)
+			continue
+		}
+		if tc.want != nil {
+			want := proto.Clone(full).(*scottpb.Game)
+			tc.want(want)
+			if !proto.Equal(pb, want) {
+				t.Errorf("%s: ParseLenient returned a different game than expected", tc.name)
+			}
+		}
+	}
+}
+
+func TestParseLenientPositions(t *testing.T) {
+	_, warnings, _ := ParseLenient([]byte(" 5953 \n 65 \n\"junk\"\n"))
+	if len(warnings) == 0 {
+		t.Fatal("ParseLenient returned no warnings")
+	}
+	if got, want := warnings[0].Pos.String(), "3:1"; got != want {
+		t.Errorf("The first warning is at %s, want %s", got, want)
+	}
+	if got, want := warnings[0].Token, 2; got != want {
+		t.Errorf("The first warning is at token %d, want %d", got, want)
+	}
+}
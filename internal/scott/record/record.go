@@ -0,0 +1,224 @@
+// Package record captures play sessions in a form that can be played back
+// exactly, so that engine bugs can be filed against a particular game file
+// along with the session that shows them up.
+//
+// A recording (scottpb.Recording) holds the hash of the game file, the seed
+// for the random events, the options in effect (including whether wizard
+// commands were allowed), and every line typed by the player along with the
+// events it produced and when it was entered.  It is written out in the proto
+// text format, so it can be read (and trimmed down) by hand.  Since the engine is deterministic for a given seed, playing the
+// same lines back must produce the same events; the first turn where it
+// doesn't is reported as a Divergence.
+package record
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// A Recorder records a session as it is played, writing the recording out
+// after every turn so that nothing is lost if the driver crashes.
+type Recorder struct {
+	path  string
+	rec   *scottpb.Recording
+	start time.Time
+}
+
+// NewRecorder starts a recording of a session with the given game file, seed
+// and options, to be written to |path|.  If |wizard| is set, input starting
+// with "#" is being taken as wizard commands, and must be on playback too.
+func NewRecorder(path, gamePath string, seed int64, opts game.Options, wizard bool) (*Recorder, error) {
+	sum, err := HashFile(gamePath)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	return &Recorder{
+		path: path,
+		rec: &scottpb.Recording{
+			GameFile:   gamePath,
+			GameSha256: sum,
+			Seed:       seed,
+			Options:    OptionsProto(opts),
+			Started:    start.UnixNano() / int64(time.Millisecond),
+			Wizard:     wizard,
+		},
+		start: start,
+	}, nil
+}
+
+// Turn records a turn, with the line the player typed ("" for the start of the
+// game) and the events it produced, and writes out the recording.
+func (r *Recorder) Turn(input string, evs []*game.Event) error {
+	r.rec.Turns = append(r.rec.Turns, &scottpb.Turn{
+		Input:     input,
+		ElapsedMs: int64(time.Since(r.start) / time.Millisecond),
+		Events:    Events(evs),
+	})
+	return Save(r.path, r.rec)
+}
+
+// Recording returns the recording so far.
+func (r *Recorder) Recording() *scottpb.Recording {
+	return r.rec
+}
+
+// Load reads a recording from a file.
+func Load(path string) (*scottpb.Recording, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read recording: %v", err)
+	}
+	rec := &scottpb.Recording{}
+	if err := prototext.Unmarshal(data, rec); err != nil {
+		return nil, fmt.Errorf("Could not parse recording %q: %v", path, err)
+	}
+	return rec, nil
+}
+
+// Save writes a recording to a file, replacing it atomically.
+func Save(path string, rec *scottpb.Recording) error {
+	data := []byte(prototext.MarshalOptions{Multiline: true}.Format(rec))
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("Could not write recording: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("Could not write recording: %v", err)
+	}
+	return nil
+}
+
+// HashFile returns the SHA-256 hash of a game file.
+func HashFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read game: %v", err)
+	}
+	sum := sha256.Sum256(data)
+	return sum[:], nil
+}
+
+// CheckGame checks that a recording was made with the given game file.
+func CheckGame(rec *scottpb.Recording, gamePath string) error {
+	sum, err := HashFile(gamePath)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sum, rec.GameSha256) {
+		return fmt.Errorf("Recording was made with a different game file (%s, SHA-256 %x)", rec.GameFile, rec.GameSha256)
+	}
+	return nil
+}
+
+// OptionsProto converts game options into their proto form.
+func OptionsProto(opts game.Options) *scottpb.Options {
+	return &scottpb.Options{
+		YouAre:          opts.YouAre,
+		ScottLight:      opts.ScottLight,
+		Trs80:           opts.TRS80,
+		PrehistoricLamp: opts.PrehistoricLamp,
+		Debug:           opts.Debug,
+	}
+}
+
+// GameOptions converts game options from their proto form.
+func GameOptions(pb *scottpb.Options) game.Options {
+	return game.Options{
+		YouAre:          pb.GetYouAre(),
+		ScottLight:      pb.GetScottLight(),
+		TRS80:           pb.GetTrs80(),
+		PrehistoricLamp: pb.GetPrehistoricLamp(),
+		Debug:           pb.GetDebug(),
+	}
+}
+
+// Events converts events from the engine into their proto form.
+func Events(evs []*game.Event) []*scottpb.Event {
+	pbs := make([]*scottpb.Event, len(evs))
+	for i, ev := range evs {
+		pbs[i] = &scottpb.Event{
+			Type: scottpb.EventType(ev.Type),
+			Text: ev.Text,
+		}
+		if ld := ev.Look; ld != nil {
+			pbs[i].Look = &scottpb.LookData{
+				IsDark:          ld.IsDark,
+				RoomDescription: ld.RoomDescription,
+				Exits:           ld.Exits,
+				Items:           ld.Items,
+			}
+		}
+	}
+	return pbs
+}
+
+// A Divergence is the first place where a session played back differs from
+// the recording.
+type Divergence struct {
+	Turn  int            // index of the turn in the recording
+	Input string         // the line typed by the player
+	Event int            // index of the first differing event in the turn
+	Got   *scottpb.Event // the event produced on playback, or nil if there were too few
+	Want  *scottpb.Event // the event in the recording, or nil if there were too many
+}
+
+// String describes the divergence.
+func (d *Divergence) String() string {
+	return fmt.Sprintf("turn %d (%q), event %d: got %s, want %s",
+		d.Turn, d.Input, d.Event, describe(d.Got), describe(d.Want))
+}
+
+// Compare checks the events produced by a turn on playback against those in
+// the recording, returning nil if they match.
+func Compare(turn int, t *scottpb.Turn, got []*scottpb.Event) *Divergence {
+	want := t.Events
+	for i := 0; i < len(got) || i < len(want); i++ {
+		d := &Divergence{Turn: turn, Input: t.Input, Event: i}
+		if i < len(got) {
+			d.Got = got[i]
+		}
+		if i < len(want) {
+			d.Want = want[i]
+		}
+		if d.Got == nil || d.Want == nil || !proto.Equal(d.Got, d.Want) {
+			return d
+		}
+	}
+	return nil
+}
+
+// Playback plays the recording back from the start of the game, calling |run|
+// to perform each turn and return the events produced, and stops at the first
+// divergence, if any.  The game is restarted and set up with the recorded
+// seed and options first.
+func Playback(g *game.Game, rec *scottpb.Recording, run func(input string, start bool) []*game.Event) *Divergence {
+	g.Options = GameOptions(rec.Options)
+	g.Restart()
+	g.Seed(rec.Seed)
+	for i, t := range rec.Turns {
+		if d := Compare(i, t, Events(run(t.Input, i == 0))); d != nil {
+			return d
+		}
+	}
+	return nil
+}
+
+// describe renders an event for a Divergence.
+func describe(ev *scottpb.Event) string {
+	if ev == nil {
+		return "nothing"
+	}
+	return prototext.Format(ev)
+}
@@ -0,0 +1,176 @@
+package record
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+const gamePath = "../../../games/adv01.dat"
+
+// session is a short walk through Adventureland.
+var session = []string{"CLIMB TREE", "GET KEYS", "DOWN", "NORTH", "GO LAKE", "SWIM", "SCORE"}
+
+// turnFunc returns a function that plays turns of the given game, as the
+// drivers do.
+func turnFunc(g *game.Game) func(input string, start bool) []*game.Event {
+	return func(input string, start bool) []*game.Event {
+		if start {
+			g.Start()
+		} else {
+			g.Command(input)
+		}
+		return g.Events()
+	}
+}
+
+// recordSession records the session, returning the recording as read back from
+// the file.
+func recordSession(t *testing.T, dir string) *scottpb.Recording {
+	g, err := game.LoadFromFile(gamePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Options = game.Options{YouAre: true}
+	g.Seed(42)
+
+	path := filepath.Join(dir, "session.rec")
+	r, err := NewRecorder(path, gamePath, 42, g.Options, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	turn := turnFunc(g)
+	if err := r.Turn("", turn("", true)); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range session {
+		if err := r.Turn(line, turn(line, false)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckGame(rec, gamePath); err != nil {
+		t.Fatal(err)
+	}
+	return rec
+}
+
+func TestPlaybackMatches(t *testing.T) {
+	dir, err := ioutil.TempDir("", "record")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rec := recordSession(t, dir)
+	if len(rec.Turns) != len(session)+1 {
+		t.Fatalf("Recording has %d turns, want %d", len(rec.Turns), len(session)+1)
+	}
+	if rec.Seed != 42 || !rec.Options.YouAre {
+		t.Errorf("Recording has seed %d and options %v", rec.Seed, rec.Options)
+	}
+
+	// Play back on a game with different settings, which Playback must
+	// replace with the recorded ones.
+	g, err := game.LoadFromFile(gamePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Seed(1)
+	g.Command("CLIMB TREE")
+	if d := Playback(g, rec, turnFunc(g)); d != nil {
+		t.Errorf("Playback diverged at %s", d)
+	}
+}
+
+func TestPlaybackDiverges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "record")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rec := recordSession(t, dir)
+	rec.Turns[3].Events[1].Text = "Something else happened.\n"
+
+	g, err := game.LoadFromFile(gamePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := Playback(g, rec, turnFunc(g))
+	if d == nil {
+		t.Fatal("Playback matched a changed recording")
+	}
+	if d.Turn != 3 || d.Input != session[2] || d.Event != 1 {
+		t.Errorf("Playback diverged at %s, want turn 3 (%q), event 1", d, session[2])
+	}
+	if d.Want.Text != "Something else happened.\n" || d.Got == nil || d.Got.Text == d.Want.Text {
+		t.Errorf("Divergence has got %v, want %v", d.Got, d.Want)
+	}
+}
+
+func TestCompareLengths(t *testing.T) {
+	ev := &scottpb.Event{Type: scottpb.EventType(game.TextEvent), Text: "Hello.\n"}
+	turn := &scottpb.Turn{Input: "HELLO", Events: []*scottpb.Event{ev}}
+
+	if d := Compare(0, turn, []*scottpb.Event{ev}); d != nil {
+		t.Errorf("Compare of the same events: %s", d)
+	}
+	if d := Compare(0, turn, nil); d == nil || d.Event != 0 || d.Got != nil || d.Want != ev {
+		t.Errorf("Compare with too few events: got %v", d)
+	}
+	if d := Compare(0, turn, []*scottpb.Event{ev, ev}); d == nil || d.Event != 1 || d.Got != ev || d.Want != nil {
+		t.Errorf("Compare with too many events: got %v", d)
+	}
+}
+
+func TestCheckGameMismatch(t *testing.T) {
+	rec := &scottpb.Recording{GameFile: "other.dat", GameSha256: make([]byte, 32)}
+	if err := CheckGame(rec, gamePath); err == nil {
+		t.Errorf("CheckGame accepted a recording of a different game")
+	}
+}
+
+func TestOptionsRoundTrip(t *testing.T) {
+	opts := game.Options{YouAre: true, TRS80: true, Debug: true}
+	if got := GameOptions(OptionsProto(opts)); got != opts {
+		t.Errorf("Options came back as %+v, want %+v", got, opts)
+	}
+	if got := GameOptions(nil); got != (game.Options{}) {
+		t.Errorf("Missing options came back as %+v", got)
+	}
+}
+
+func TestWizardRecorded(t *testing.T) {
+	dir, err := ioutil.TempDir("", "record")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, wizard := range []bool{false, true} {
+		path := filepath.Join(dir, "session.rec")
+		r, err := NewRecorder(path, gamePath, 42, game.Options{}, wizard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Turn("", nil); err != nil {
+			t.Fatal(err)
+		}
+		rec, err := Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rec.Wizard != wizard {
+			t.Errorf("A recording with wizard commands %v came back with %v", wizard, rec.Wizard)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// Package rest exposes game sessions as resources in an HTTP/JSON API:
+//
+//	GET    /games                     list the games that can be played, as catalog entries
+//	POST   /sessions                  start a session: {"game": "adv01", "seed": 0}
+//	DELETE /sessions/{id}             end a session
+//	POST   /sessions/{id}/commands    type a command: {"input": "GET LAMP"}
+//	GET    /sessions/{id}/look        describe the current room
+//	GET    /sessions/{id}/inventory   list the items being carried
+//	GET    /sessions/{id}/score       count the treasures stored
+//
+// Errors are reported with a suitable status code and a body of the form
+// {"error": "..."}.
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/catalog"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+)
+
+// maxBody is the largest request body we'll read.
+const maxBody = 64 << 10
+
+// Event is the JSON form of an event from the engine.
+type Event struct {
+	Type string `json:"type"`           // the kind of event, as given by game.EventType.String
+	Text string `json:"text,omitempty"` // the text to print, for "text" events
+	Look *Look  `json:"look,omitempty"` // the room description, for "look" events
+}
+
+// Look is the JSON form of a room description.  Text is the whole description
+// formatted as the game would print it, so that clients needn't duplicate the
+// wording chosen by the game options.
+type Look struct {
+	IsDark          bool     `json:"is_dark"`
+	RoomDescription string   `json:"room_description"`
+	Exits           []string `json:"exits"`
+	Items           []string `json:"items"`
+	Text            string   `json:"text"`
+}
+
+// Handler serves the API for the sessions held by a session.Manager.
+type Handler struct {
+	sessions *session.Manager
+}
+
+// New initializes a new Handler for the given session manager.
+func New(m *session.Manager) *Handler {
+	return &Handler{sessions: m}
+}
+
+// ServeHTTP dispatches a request according to its path and method.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "games":
+		h.only(w, r, http.MethodGet, h.games)
+	case len(parts) == 1 && parts[0] == "sessions":
+		h.only(w, r, http.MethodPost, h.create)
+	case len(parts) == 2 && parts[0] == "sessions":
+		h.only(w, r, http.MethodDelete, func(w http.ResponseWriter, r *http.Request) {
+			h.close(w, parts[1])
+		})
+	case len(parts) == 3 && parts[0] == "sessions":
+		h.session(w, r, parts[1], parts[2])
+	default:
+		writeError(w, http.StatusNotFound, "No such resource: %s", r.URL.Path)
+	}
+}
+
+// session handles the resources belonging to a single session.
+func (h *Handler) session(w http.ResponseWriter, r *http.Request, id, resource string) {
+	var method string
+	var f func(g *game.Game) interface{}
+	switch resource {
+	case "commands":
+		var req struct {
+			Input string `json:"input"`
+		}
+		if r.Method == http.MethodPost && !readJSON(w, r, &req) {
+			return
+		}
+		method = http.MethodPost
+		f = func(g *game.Game) interface{} {
+			g.Command(req.Input)
+			return map[string]interface{}{
+				"events":    Events(g.Events()),
+				"game_over": g.IsOver(),
+			}
+		}
+	case "look":
+		method = http.MethodGet
+		f = func(g *game.Game) interface{} {
+			return LookData(g.Look())
+		}
+	case "inventory":
+		method = http.MethodGet
+		f = func(g *game.Game) interface{} {
+			items := g.Carried()
+			if items == nil {
+				items = []string{}
+			}
+			return map[string]interface{}{"items": items}
+		}
+	case "score":
+		method = http.MethodGet
+		f = func(g *game.Game) interface{} {
+			stored, total := g.Score()
+			return map[string]interface{}{
+				"stored":    stored,
+				"total":     total,
+				"game_over": g.IsOver(),
+			}
+		}
+	default:
+		writeError(w, http.StatusNotFound, "No such resource: %s", r.URL.Path)
+		return
+	}
+
+	h.only(w, r, method, func(w http.ResponseWriter, r *http.Request) {
+		s, err := h.sessions.Get(id)
+		if err != nil {
+			writeError(w, http.StatusNotFound, "%v: %q", err, id)
+			return
+		}
+		var resp interface{}
+		s.Run(func(g *game.Game) {
+			resp = f(g)
+		})
+		writeJSON(w, http.StatusOK, resp)
+	})
+}
+
+// games lists the games that can be played.
+func (h *Handler) games(w http.ResponseWriter, r *http.Request) {
+	games, err := h.sessions.Games()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Could not list games: %v", err)
+		return
+	}
+	if games == nil {
+		games = []*catalog.Entry{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"games": games})
+}
+
+// create starts a new session.
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Game string `json:"game"`
+		Seed int64  `json:"seed"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s, err := h.sessions.Create(req.Game, req.Seed)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	var evs []*Event
+	s.Run(func(g *game.Game) {
+		g.Start()
+		evs = Events(g.Events())
+	})
+
+	w.Header().Set("Location", "/sessions/"+s.ID)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"id":     s.ID,
+		"game":   s.Name,
+		"events": evs,
+	})
+}
+
+// close ends a session.
+func (h *Handler) close(w http.ResponseWriter, id string) {
+	if err := h.sessions.Close(id); err != nil {
+		writeError(w, http.StatusNotFound, "%v: %q", err, id)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// only calls |f| if the request uses the given method, and reports an error
+// otherwise.
+func (h *Handler) only(w http.ResponseWriter, r *http.Request, method string, f http.HandlerFunc) {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		writeError(w, http.StatusMethodNotAllowed, "Method %s not allowed; use %s", r.Method, method)
+		return
+	}
+	f(w, r)
+}
+
+// Events converts events from the engine into their JSON form.
+func Events(evs []*game.Event) []*Event {
+	out := make([]*Event, len(evs))
+	for i, ev := range evs {
+		out[i] = &Event{Type: ev.Type.String(), Text: ev.Text}
+		if ev.Look != nil {
+			out[i].Look = LookData(ev.Look)
+		}
+	}
+	return out
+}
+
+// LookData converts a room description into its JSON form.
+func LookData(ld *game.LookData) *Look {
+	l := &Look{
+		IsDark:          ld.IsDark,
+		RoomDescription: ld.RoomDescription,
+		Exits:           ld.Exits,
+		Items:           ld.Items,
+		Text:            ld.String(),
+	}
+	if l.Exits == nil {
+		l.Exits = []string{}
+	}
+	if l.Items == nil {
+		l.Items = []string{}
+	}
+	return l
+}
+
+// readJSON decodes the request body into |v|, reporting an error and
+// returning false if it can't.
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Bad request body: %v", err)
+		return false
+	}
+	return true
+}
+
+// writeJSON sends |v| as the response body with the given status code.
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+// writeError sends an error response with the given status code.
+func writeError(w http.ResponseWriter, code int, format string, args ...interface{}) {
+	writeJSON(w, code, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
@@ -0,0 +1,216 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+)
+
+// newServer starts a server for the bundled games.
+func newServer(t *testing.T) (*httptest.Server, *session.Manager) {
+	m := session.NewManager("../../../games")
+	srv := httptest.NewServer(New(m))
+	t.Cleanup(srv.Close)
+	return srv, m
+}
+
+// do makes a request with the given JSON body (if any), checks the status code
+// and decodes the response into |v| (if given).
+func do(t *testing.T, srv *httptest.Server, method, path, body string, code int, v interface{}) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != code {
+		t.Fatalf("%s %s returned %s, want %d", method, path, resp.Status, code)
+	}
+	if v != nil {
+		if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s %s returned Content-Type %q", method, path, ct)
+		}
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("%s %s returned bad JSON: %v", method, path, err)
+		}
+	}
+	return resp
+}
+
+// created is the response to starting a session.
+type created struct {
+	ID     string   `json:"id"`
+	Game   string   `json:"game"`
+	Events []*Event `json:"events"`
+}
+
+// text joins the text of the given events.
+func text(evs []*Event) string {
+	var b strings.Builder
+	for _, ev := range evs {
+		b.WriteString(ev.Text)
+	}
+	return b.String()
+}
+
+func TestGames(t *testing.T) {
+	srv, _ := newServer(t)
+	var resp struct {
+		Games []map[string]interface{} `json:"games"`
+	}
+	do(t, srv, "GET", "/games", "", http.StatusOK, &resp)
+	if len(resp.Games) != 18 {
+		t.Errorf("GET /games listed %d games, want 18", len(resp.Games))
+	}
+	for _, g := range resp.Games {
+		if g["file"] == "adv01.dat" {
+			if g["title"] != "Adventureland" || g["adventure"] != 1.0 {
+				t.Errorf("GET /games listed adv01.dat as %v", g)
+			}
+			return
+		}
+	}
+	t.Errorf("GET /games didn't list adv01.dat: %v", resp.Games)
+}
+
+func TestSession(t *testing.T) {
+	srv, m := newServer(t)
+
+	var c created
+	resp := do(t, srv, "POST", "/sessions", `{"game": "adv01", "seed": 1}`, http.StatusCreated, &c)
+	if c.ID == "" || c.Game != "adv01.dat" {
+		t.Errorf("POST /sessions returned %+v", c)
+	}
+	if got, want := resp.Header.Get("Location"), "/sessions/"+c.ID; got != want {
+		t.Errorf("POST /sessions returned Location %q, want %q", got, want)
+	}
+	if len(c.Events) == 0 {
+		t.Errorf("POST /sessions returned no opening events")
+	}
+	if m.Len() != 1 {
+		t.Errorf("There are %d sessions, want 1", m.Len())
+	}
+	path := "/sessions/" + c.ID
+
+	var cmd struct {
+		Events   []*Event `json:"events"`
+		GameOver bool     `json:"game_over"`
+	}
+	for _, input := range []string{"GO EAST", "GO SOUTH", "GET MUD"} {
+		do(t, srv, "POST", path+"/commands", `{"input": "`+input+`"}`, http.StatusOK, &cmd)
+	}
+	if got := text(cmd.Events); !strings.HasPrefix(got, "OK") {
+		t.Errorf("GET MUD printed %q", got)
+	}
+	if cmd.GameOver {
+		t.Errorf("The game is over after GET MUD")
+	}
+
+	var look Look
+	do(t, srv, "GET", path+"/look", "", http.StatusOK, &look)
+	if !strings.Contains(look.RoomDescription, "swamp") || look.IsDark || len(look.Exits) == 0 {
+		t.Errorf("GET look returned %+v", look)
+	}
+	if !strings.HasPrefix(look.Text, look.RoomDescription+"\n") || !strings.Contains(look.Text, "\nI can also see: ") {
+		t.Errorf("GET look formatted the room as %q", look.Text)
+	}
+
+	var inv struct {
+		Items []string `json:"items"`
+	}
+	do(t, srv, "GET", path+"/inventory", "", http.StatusOK, &inv)
+	if len(inv.Items) != 1 || !strings.Contains(inv.Items[0], "mud") {
+		t.Errorf("GET inventory returned %q", inv.Items)
+	}
+
+	var score struct {
+		Stored   int  `json:"stored"`
+		Total    int  `json:"total"`
+		GameOver bool `json:"game_over"`
+	}
+	do(t, srv, "GET", path+"/score", "", http.StatusOK, &score)
+	if score.Stored != 0 || score.Total != 13 || score.GameOver {
+		t.Errorf("GET score returned %+v", score)
+	}
+
+	do(t, srv, "POST", path+"/commands", `{"input": "QUIT"}`, http.StatusOK, &cmd)
+	do(t, srv, "POST", path+"/commands", `{"input": "Y"}`, http.StatusOK, &cmd)
+	do(t, srv, "GET", path+"/score", "", http.StatusOK, &score)
+	if !cmd.GameOver || !score.GameOver {
+		t.Errorf("After QUIT, game over is %v in the command and %v in the score", cmd.GameOver, score.GameOver)
+	}
+
+	do(t, srv, "DELETE", path, "", http.StatusNoContent, nil)
+	if m.Len() != 0 {
+		t.Errorf("There are %d sessions after DELETE, want 0", m.Len())
+	}
+}
+
+func TestErrors(t *testing.T) {
+	srv, _ := newServer(t)
+	var c created
+	do(t, srv, "POST", "/sessions", `{"game": "adv01"}`, http.StatusCreated, &c)
+	path := "/sessions/" + c.ID
+
+	for _, tc := range []struct {
+		method, path, body string
+		code               int
+	}{
+		{"GET", "/nonesuch", "", http.StatusNotFound},
+		{"GET", "/sessions/nonesuch/look", "", http.StatusNotFound},
+		{"POST", "/sessions/nonesuch/commands", `{"input": "LOOK"}`, http.StatusNotFound},
+		{"GET", "/sessions/nonesuch/score", "", http.StatusNotFound},
+		{"DELETE", "/sessions/nonesuch", "", http.StatusNotFound},
+		{"GET", path + "/nonesuch", "", http.StatusNotFound},
+		{"GET", path + "/look/more", "", http.StatusNotFound},
+		{"POST", "/sessions", `{"game": "missing"}`, http.StatusBadRequest},
+		{"POST", "/sessions", `{"game": "../games/adv01"}`, http.StatusBadRequest},
+		{"POST", "/sessions", `{"game": "adv01", "extra": 1}`, http.StatusBadRequest},
+		{"POST", "/sessions", `not JSON`, http.StatusBadRequest},
+		{"POST", path + "/commands", `{"input": 7}`, http.StatusBadRequest},
+		{"POST", path + "/commands", strings.Repeat(" ", maxBody+1) + "{}", http.StatusBadRequest},
+		{"GET", "/sessions", "", http.StatusMethodNotAllowed},
+		{"POST", "/games", "", http.StatusMethodNotAllowed},
+		{"GET", path + "/commands", "", http.StatusMethodNotAllowed},
+		{"POST", path + "/look", "", http.StatusMethodNotAllowed},
+		{"GET", path, "", http.StatusMethodNotAllowed},
+	} {
+		var e struct {
+			Error string `json:"error"`
+		}
+		resp := do(t, srv, tc.method, tc.path, tc.body, tc.code, &e)
+		if e.Error == "" {
+			t.Errorf("%s %s returned no error message", tc.method, tc.path)
+		}
+		if tc.code == http.StatusMethodNotAllowed && resp.Header.Get("Allow") == "" {
+			t.Errorf("%s %s returned no Allow header", tc.method, tc.path)
+		}
+	}
+}
+
+func TestIdleExpiry(t *testing.T) {
+	srv, m := newServer(t)
+	m.IdleTimeout = 50 * time.Millisecond
+
+	var c created
+	do(t, srv, "POST", "/sessions", `{"game": "adv01"}`, http.StatusCreated, &c)
+	path := "/sessions/" + c.ID
+	do(t, srv, "GET", path+"/look", "", http.StatusOK, &Look{})
+
+	time.Sleep(100 * time.Millisecond)
+	do(t, srv, "GET", path+"/look", "", http.StatusNotFound, nil)
+	if n := m.Expire(); n != 1 {
+		t.Errorf("Expire ended %d sessions, want 1", n)
+	}
+	do(t, srv, "DELETE", path, "", http.StatusNotFound, nil)
+}
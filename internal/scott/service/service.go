@@ -0,0 +1,150 @@
+// Package service implements the ScottService gRPC service defined in
+// scott.proto, playing games held in a session.Manager.
+package service
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+)
+
+// Server implements scottpb.ScottServiceServer.
+type Server struct {
+	scottpb.UnimplementedScottServiceServer
+
+	sessions *session.Manager
+}
+
+// New initializes a new Server for the sessions held by the given manager.
+func New(m *session.Manager) *Server {
+	return &Server{sessions: m}
+}
+
+// NewSession starts a new game session.
+func (s *Server) NewSession(ctx context.Context, req *scottpb.NewSessionRequest) (*scottpb.NewSessionResponse, error) {
+	sess, err := s.sessions.Create(req.Game, req.Seed)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	resp := &scottpb.NewSessionResponse{SessionId: sess.ID}
+	sess.Run(func(g *game.Game) {
+		g.Start()
+		resp.Events = Events(g.Events())
+	})
+	return resp, nil
+}
+
+// Command processes a line of input from the player.
+func (s *Server) Command(ctx context.Context, req *scottpb.CommandRequest) (*scottpb.CommandResponse, error) {
+	sess, err := s.get(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &scottpb.CommandResponse{}
+	sess.Run(func(g *game.Game) {
+		g.Command(req.Input)
+		resp.Events = Events(g.Events())
+		resp.GameOver = g.IsOver()
+	})
+	return resp, nil
+}
+
+// Look describes the current room.
+func (s *Server) Look(ctx context.Context, req *scottpb.LookRequest) (*scottpb.LookResponse, error) {
+	sess, err := s.get(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &scottpb.LookResponse{}
+	sess.Run(func(g *game.Game) {
+		resp.Look = LookData(g.Look())
+	})
+	return resp, nil
+}
+
+// Save saves the state of the game.
+func (s *Server) Save(ctx context.Context, req *scottpb.SaveRequest) (*scottpb.SaveResponse, error) {
+	sess, err := s.get(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &scottpb.SaveResponse{}
+	sess.Run(func(g *game.Game) {
+		resp.State = g.SaveState()
+	})
+	return resp, nil
+}
+
+// Restore restores a previously saved state.
+func (s *Server) Restore(ctx context.Context, req *scottpb.RestoreRequest) (*scottpb.RestoreResponse, error) {
+	sess, err := s.get(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	if req.State == nil {
+		return nil, status.Error(codes.InvalidArgument, "No state given")
+	}
+
+	resp := &scottpb.RestoreResponse{}
+	sess.Run(func(g *game.Game) {
+		if err = g.RestoreState(req.State); err == nil {
+			resp.Look = LookData(g.Look())
+		}
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Could not restore state: %v", err)
+	}
+	return resp, nil
+}
+
+// Close ends a game session.
+func (s *Server) Close(ctx context.Context, req *scottpb.CloseRequest) (*scottpb.CloseResponse, error) {
+	if err := s.sessions.Close(req.SessionId); err != nil {
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	}
+	return &scottpb.CloseResponse{}, nil
+}
+
+// get looks up a session, turning a failure into a gRPC error.
+func (s *Server) get(id string) (*session.Session, error) {
+	sess, err := s.sessions.Get(id)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%v: %q", err, id)
+	}
+	return sess, nil
+}
+
+// Events converts events from the engine into their proto form.  The event
+// types are numbered the same way in both.
+func Events(evs []*game.Event) []*scottpb.Event {
+	pbs := make([]*scottpb.Event, len(evs))
+	for i, ev := range evs {
+		pbs[i] = &scottpb.Event{
+			Type: scottpb.EventType(ev.Type),
+			Text: ev.Text,
+		}
+		if ev.Look != nil {
+			pbs[i].Look = LookData(ev.Look)
+		}
+	}
+	return pbs
+}
+
+// LookData converts a room description into its proto form.
+func LookData(ld *game.LookData) *scottpb.LookData {
+	return &scottpb.LookData{
+		IsDark:          ld.IsDark,
+		RoomDescription: ld.RoomDescription,
+		Exits:           ld.Exits,
+		Items:           ld.Items,
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+)
+
+// newClient starts a server for the bundled games on an in-process connection
+// and returns a client for it.
+func newClient(t *testing.T) scottpb.ScottServiceClient {
+	l := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	scottpb.RegisterScottServiceServer(s, New(session.NewManager("../../../games")))
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return scottpb.NewScottServiceClient(conn)
+}
+
+// text joins the text of the given events.
+func text(evs []*scottpb.Event) string {
+	var b strings.Builder
+	for _, ev := range evs {
+		b.WriteString(ev.Text)
+	}
+	return b.String()
+}
+
+func TestSession(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	ns, err := c.NewSession(ctx, &scottpb.NewSessionRequest{Game: "adv01", Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ns.SessionId == "" || len(ns.Events) == 0 {
+		t.Fatalf("NewSession returned %v", ns)
+	}
+	id := ns.SessionId
+
+	cmd, err := c.Command(ctx, &scottpb.CommandRequest{SessionId: id, Input: "INVENTORY"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := text(cmd.Events); !strings.Contains(got, "carrying") {
+		t.Errorf("INVENTORY printed %q", got)
+	}
+	if cmd.GameOver {
+		t.Errorf("The game is over after INVENTORY")
+	}
+
+	look, err := c.Look(ctx, &scottpb.LookRequest{SessionId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(look.Look.RoomDescription, "forest") {
+		t.Errorf("Look described %q", look.Look.RoomDescription)
+	}
+
+	save, err := c.Save(ctx, &scottpb.SaveRequest{SessionId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Command(ctx, &scottpb.CommandRequest{SessionId: id, Input: "CLIMB TREE"}); err != nil {
+		t.Fatal(err)
+	}
+	restore, err := c.Restore(ctx, &scottpb.RestoreRequest{SessionId: id, State: save.State})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restore.Look.RoomDescription != look.Look.RoomDescription {
+		t.Errorf("After Restore, the room is %q, want %q", restore.Look.RoomDescription, look.Look.RoomDescription)
+	}
+
+	if _, err := c.Close(ctx, &scottpb.CloseRequest{SessionId: id}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	ns, err := c.NewSession(ctx, &scottpb.NewSessionRequest{Game: "adv01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Close(ctx, &scottpb.CloseRequest{SessionId: ns.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"NewSession for a missing game", func() error {
+			_, err := c.NewSession(ctx, &scottpb.NewSessionRequest{Game: "missing"})
+			return err
+		}, codes.InvalidArgument},
+		{"NewSession outside the game directory", func() error {
+			_, err := c.NewSession(ctx, &scottpb.NewSessionRequest{Game: "../games/adv01.dat"})
+			return err
+		}, codes.InvalidArgument},
+		{"Command for an unknown session", func() error {
+			_, err := c.Command(ctx, &scottpb.CommandRequest{SessionId: "nonesuch", Input: "LOOK"})
+			return err
+		}, codes.NotFound},
+		{"Command for a closed session", func() error {
+			_, err := c.Command(ctx, &scottpb.CommandRequest{SessionId: ns.SessionId, Input: "LOOK"})
+			return err
+		}, codes.NotFound},
+		{"Look for an unknown session", func() error {
+			_, err := c.Look(ctx, &scottpb.LookRequest{SessionId: "nonesuch"})
+			return err
+		}, codes.NotFound},
+		{"Save for an unknown session", func() error {
+			_, err := c.Save(ctx, &scottpb.SaveRequest{SessionId: "nonesuch"})
+			return err
+		}, codes.NotFound},
+		{"Restore for an unknown session", func() error {
+			_, err := c.Restore(ctx, &scottpb.RestoreRequest{SessionId: "nonesuch", State: &scottpb.State{}})
+			return err
+		}, codes.NotFound},
+		{"Close for a closed session", func() error {
+			_, err := c.Close(ctx, &scottpb.CloseRequest{SessionId: ns.SessionId})
+			return err
+		}, codes.NotFound},
+	} {
+		if got := status.Code(tc.call()); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRestoreErrors(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	ns, err := c.NewSession(ctx, &scottpb.NewSessionRequest{Game: "adv01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, state := range []*scottpb.State{nil, {}} {
+		_, err := c.Restore(ctx, &scottpb.RestoreRequest{SessionId: ns.SessionId, State: state})
+		if got := status.Code(err); got != codes.InvalidArgument {
+			t.Errorf("Restore of %v: got %v, want InvalidArgument", state, got)
+		}
+	}
+}
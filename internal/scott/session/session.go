@@ -0,0 +1,214 @@
+// Package session keeps track of games being played by remote players.  Each
+// session wraps a single game.Game, which isn't safe for concurrent use, so all
+// access to the game goes through the session's lock.
+//
+// Sessions that go unused for too long are expired by the Manager, since
+// players rarely bother to close them.
+//
+// Each game file is only read once: the sessions playing it share a single
+// game.Database, and keep just their own state.
+package session
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/catalog"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// ErrNotFound is returned for a session that doesn't exist, or no longer does.
+var ErrNotFound = errors.New("No such session")
+
+// A Session is a single game in progress.
+type Session struct {
+	ID   string         // identifies the session to the client
+	Name string         // name of the game file
+	DB   *game.Database // the game's database, shared with other sessions
+
+	mu       sync.Mutex
+	game     *game.Game
+	lastUsed time.Time // when the session was last used, guarded by the Manager's lock
+}
+
+// Run calls |f| with the session's game, holding the lock for the duration.
+func (s *Session) Run(f func(g *game.Game)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s.game)
+}
+
+// A Manager keeps track of the sessions in progress.  It is safe for
+// concurrent use.
+type Manager struct {
+	Dir         string        // directory holding the game files
+	IdleTimeout time.Duration // how long a session may go unused, or 0 for forever
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+	games    []*catalog.Entry          // the catalog, once it has been read
+	dbs      map[string]*game.Database // the games that have been read, by file name
+}
+
+// NewManager initializes a new Manager for the games in the given directory.
+func NewManager(dir string) *Manager {
+	return &Manager{
+		Dir:      dir,
+		sessions: map[string]*Session{},
+		dbs:      map[string]*game.Database{},
+	}
+}
+
+// Create starts a new session for the named game, which must be a file in the
+// game directory; the ".dat" extension may be left off.  If the seed is 0, the
+// random events are seeded from the clock.  The game is restarted but not yet
+// started, so that the caller can collect the opening events.
+func (m *Manager) Create(name string, seed int64) (*Session, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("Bad game name %q", name)
+	}
+	if filepath.Ext(name) == "" {
+		name += catalog.Extension
+	}
+	path := filepath.Join(m.Dir, name)
+	db, err := m.database(name, path)
+	if err != nil {
+		return nil, err
+	}
+	g := db.NewGame()
+	if seed != 0 {
+		g.Seed(seed)
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	s := &Session{ID: id, Name: name, DB: db, game: g, lastUsed: time.Now()}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[id] = s
+	return s, nil
+}
+
+// database returns the database for the named game, reading the game file the
+// first time it is needed.
+func (m *Manager) database(name, path string) (*game.Database, error) {
+	m.mu.Lock()
+	db := m.dbs[name]
+	m.mu.Unlock()
+	if db != nil {
+		return db, nil
+	}
+
+	// The file is read without the lock held, so two sessions starting at
+	// once might both read it; the first to finish wins.
+	db, err := game.LoadDatabase(path)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if old := m.dbs[name]; old != nil {
+		return old, nil
+	}
+	m.dbs[name] = db
+	return db, nil
+}
+
+// Games returns the catalog of the games in the game directory.  The
+// directory is only scanned the first time.
+func (m *Manager) Games() ([]*catalog.Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.games == nil {
+		games, err := catalog.Scan(m.Dir)
+		if err != nil {
+			return nil, err
+		}
+		m.games = games
+	}
+	return m.games, nil
+}
+
+// Get returns the session with the given ID, and marks it as used.
+func (m *Manager) Get(id string) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	if !ok || m.idle(s, time.Now()) {
+		return nil, ErrNotFound
+	}
+	s.lastUsed = time.Now()
+	return s, nil
+}
+
+// Close ends the session with the given ID.
+func (m *Manager) Close(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[id]; !ok {
+		return ErrNotFound
+	}
+	delete(m.sessions, id)
+	return nil
+}
+
+// Len returns the number of sessions in progress.
+func (m *Manager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sessions)
+}
+
+// Expire ends every session that has gone unused for longer than the idle
+// timeout, returning the number of sessions ended.
+func (m *Manager) Expire() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	n := 0
+	for id, s := range m.sessions {
+		if m.idle(s, now) {
+			delete(m.sessions, id)
+			n++
+		}
+	}
+	return n
+}
+
+// Reap calls Expire at the given interval until |stop| is closed.
+func (m *Manager) Reap(interval time.Duration, stop <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			m.Expire()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// idle checks if the session has gone unused for too long (for internal use,
+// with the lock held).
+func (m *Manager) idle(s *Session, now time.Time) bool {
+	return m.IdleTimeout > 0 && now.Sub(s.lastUsed) > m.IdleTimeout
+}
+
+// newID makes a random session ID that is hard to guess.
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("Could not make session ID: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
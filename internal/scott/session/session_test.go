@@ -0,0 +1,204 @@
+package session
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+const gamesDir = "../../../games"
+
+// transcript starts the session's game and plays the given commands, returning
+// everything printed.
+func transcript(s *Session, cmds ...string) string {
+	out := ""
+	s.Run(func(g *game.Game) {
+		g.Start()
+		for _, cmd := range cmds {
+			g.Command(cmd)
+		}
+		for _, ev := range g.Events() {
+			out += ev.Text
+		}
+	})
+	return out
+}
+
+func TestCreateRunClose(t *testing.T) {
+	m := NewManager(gamesDir)
+
+	s, err := m.Create("adv01", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Name != "adv01.dat" {
+		t.Errorf("Session is for %q, want adv01.dat", s.Name)
+	}
+	if len(s.ID) != 32 {
+		t.Errorf("Session ID %q is %d characters, want 32", s.ID, len(s.ID))
+	}
+	s2, err := m.Create("adv01.dat", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s2.ID == s.ID {
+		t.Errorf("Two sessions have the same ID %q", s.ID)
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len is %d, want 2", m.Len())
+	}
+
+	// The same seed gives the same game.
+	cmds := []string{"GO EAST", "GET AXE", "INVENTORY"}
+	if got, want := transcript(s, cmds...), transcript(s2, cmds...); got != want {
+		t.Errorf("Sessions with the same seed played differently:\n%s\n---\n%s", got, want)
+	}
+
+	if got, err := m.Get(s.ID); err != nil || got != s {
+		t.Errorf("Get returned %v, %v; want the session", got, err)
+	}
+	if err := m.Close(s.ID); err != nil {
+		t.Errorf("Close returned %v", err)
+	}
+	if _, err := m.Get(s.ID); err != ErrNotFound {
+		t.Errorf("Get after Close returned %v, want ErrNotFound", err)
+	}
+	if err := m.Close(s.ID); err != ErrNotFound {
+		t.Errorf("Second Close returned %v, want ErrNotFound", err)
+	}
+	if m.Len() != 1 {
+		t.Errorf("Len is %d, want 1", m.Len())
+	}
+}
+
+func TestCreateBadNames(t *testing.T) {
+	m := NewManager(gamesDir)
+	for _, name := range []string{"", ".", "..", ".hidden", "../games/adv01.dat", "sub/adv01.dat", "missing"} {
+		if s, err := m.Create(name, 1); err == nil {
+			t.Errorf("Create(%q) started session %q", name, s.ID)
+		}
+	}
+	if m.Len() != 0 {
+		t.Errorf("Len is %d, want 0", m.Len())
+	}
+}
+
+func TestDatabaseShared(t *testing.T) {
+	m := NewManager(gamesDir)
+	s, err := m.Create("adv01", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := m.Create("adv01.dat", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s3, err := m.Create("adv02", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.DB != s2.DB {
+		t.Errorf("Two sessions of the same game have different databases")
+	}
+	if s.DB == s3.DB {
+		t.Errorf("Sessions of different games share a database")
+	}
+	if len(m.dbs) != 2 {
+		t.Errorf("Manager holds %d databases, want 2", len(m.dbs))
+	}
+
+	// The sessions share the database, but not their state.
+	transcript(s, "GO EAST")
+	var room, room2 int32
+	s.Run(func(g *game.Game) { room = g.State.Location })
+	s2.Run(func(g *game.Game) { room2 = g.State.Location })
+	if room == room2 {
+		t.Errorf("Moving in one session moved the other as well, to room %d", room)
+	}
+}
+
+func TestExpire(t *testing.T) {
+	m := NewManager(gamesDir)
+	m.IdleTimeout = time.Hour
+
+	fresh, err := m.Create("adv01", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale, err := m.Create("adv01", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.lastUsed = time.Now().Add(-2 * time.Hour)
+
+	if _, err := m.Get(stale.ID); err != ErrNotFound {
+		t.Errorf("Get of an idle session returned %v, want ErrNotFound", err)
+	}
+	if n := m.Expire(); n != 1 {
+		t.Errorf("Expire ended %d sessions, want 1", n)
+	}
+	if _, err := m.Get(fresh.ID); err != nil {
+		t.Errorf("Get of a fresh session returned %v", err)
+	}
+	if m.Len() != 1 {
+		t.Errorf("Len is %d, want 1", m.Len())
+	}
+
+	// Without a timeout, nothing is ever idle.
+	m.IdleTimeout = 0
+	fresh.lastUsed = time.Now().Add(-1000 * time.Hour)
+	if n := m.Expire(); n != 0 {
+		t.Errorf("Expire with no timeout ended %d sessions", n)
+	}
+}
+
+func TestReap(t *testing.T) {
+	m := NewManager(gamesDir)
+	m.IdleTimeout = time.Hour
+	s, err := m.Create("adv01", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.mu.Lock()
+	s.lastUsed = time.Now().Add(-2 * time.Hour)
+	m.mu.Unlock()
+
+	stop := make(chan struct{})
+	done := make(chan bool)
+	go func() {
+		m.Reap(time.Millisecond, stop)
+		done <- true
+	}()
+	for deadline := time.Now().Add(5 * time.Second); m.Len() > 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("Reap didn't end the idle session")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(stop)
+	<-done
+}
+
+func TestGames(t *testing.T) {
+	m := NewManager(gamesDir)
+	games, err := m.Games()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(games) == 0 {
+		t.Fatal("Games found no games")
+	}
+	again, err := m.Games()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(games, again) || &games[0] != &again[0] {
+		t.Errorf("Games scanned the directory again")
+	}
+
+	if _, err := NewManager(t.TempDir() + "/missing").Games(); err == nil {
+		t.Errorf("Games found a missing directory")
+	}
+}
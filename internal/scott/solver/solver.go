@@ -0,0 +1,276 @@
+// Package solver searches for a walkthrough of a Scott Adams adventure by
+// trying commands against the game engine.  The worlds are small, and apart
+// from the percentage chances of the automatic actions they're deterministic,
+// so a bounded best-first search over game states gets surprisingly far.
+//
+// The random events are pinned down by reseeding the game before each command
+// from the search seed and a hash of the current state.  The outcome of a
+// command therefore depends only on the state it's typed in, which is what
+// makes it sound to remember states by their hashes, and a walkthrough can be
+// reproduced exactly with Replay.
+package solver
+
+import (
+	"container/heap"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// Options controls the search.
+type Options struct {
+	Seed      int64 // seed for the random events
+	MaxDepth  int   // maximum number of commands in a walkthrough
+	MaxStates int   // maximum number of distinct states to explore
+}
+
+// Result describes the best walkthrough found.
+type Result struct {
+	Commands []string // the commands to type, in order
+	Stored   int      // number of treasures stored at the end
+	Total    int      // total number of treasures in the game
+	Won      bool     // set if the game ended with every treasure stored
+	States   int      // number of distinct states explored
+
+	// Hash is the hash of the final state, for checking replays.
+	Hash [sha256.Size]byte
+}
+
+// String summarizes the result on one line.
+func (r *Result) String() string {
+	s := fmt.Sprintf("stored %d of %d treasures in %d commands (%d states searched)", r.Stored, r.Total, len(r.Commands), r.States)
+	if r.Won {
+		s += ", game won"
+	}
+	return s
+}
+
+// A node is a single state reached during the search.
+type node struct {
+	state  *scottpb.State
+	hash   [sha256.Size]byte
+	parent *node
+	cmd    string // the command that led here from the parent
+	depth  int    // the number of commands from the start
+	value  int    // how promising this state looks
+	stored int    // treasures stored in this state
+	over   bool   // set if the game has ended
+	won    bool   // set if the game has ended with every treasure stored
+}
+
+// path returns the commands that lead to this node.
+func (n *node) path() []string {
+	cmds := make([]string, n.depth)
+	for ; n.parent != nil; n = n.parent {
+		cmds[n.depth-1] = n.cmd
+	}
+	return cmds
+}
+
+// queue is a priority queue of nodes, most promising first, and shallowest
+// first among equally promising nodes.
+type queue []*node
+
+func (q queue) Len() int { return len(q) }
+func (q queue) Less(i, j int) bool {
+	if q[i].value != q[j].value {
+		return q[i].value > q[j].value
+	}
+	return q[i].depth < q[j].depth
+}
+func (q queue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x interface{}) { *q = append(*q, x.(*node)) }
+func (q *queue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[0 : len(old)-1]
+	return n
+}
+
+// Solve searches for a sequence of commands that stores as many treasures as
+// possible, starting from a freshly restarted game.  The game is left in an
+// unspecified state.
+func Solve(g *game.Game, opts Options) *Result {
+	cmds := Candidates(g.DB.Game)
+
+	g.Restart()
+	seed(g, opts.Seed)
+	g.Start()
+	g.Events()
+
+	start := newNode(g, nil, "")
+	best := start
+	seen := map[[sha256.Size]byte]bool{start.hash: true}
+	q := &queue{start}
+
+	for q.Len() > 0 && !best.won && len(seen) < opts.MaxStates {
+		n := heap.Pop(q).(*node)
+		if n.depth >= opts.MaxDepth {
+			continue
+		}
+
+		for _, cmd := range cmds {
+			if err := g.RestoreState(n.state); err != nil {
+				panic(err) // the state came from this very game
+			}
+			seed(g, opts.Seed)
+			g.Command(cmd)
+			g.Events()
+
+			next := newNode(g, n, cmd)
+			if seen[next.hash] {
+				continue
+			}
+			seen[next.hash] = true
+
+			if better(next, best) {
+				best = next
+			}
+			if best.won || len(seen) >= opts.MaxStates {
+				break
+			}
+			if !next.over {
+				heap.Push(q, next)
+			}
+		}
+	}
+
+	_, total := g.Score()
+	return &Result{
+		Commands: best.path(),
+		Stored:   best.stored,
+		Total:    total,
+		Won:      best.won,
+		States:   len(seen),
+		Hash:     best.hash,
+	}
+}
+
+// Replay restarts the game and types in the given commands, reseeding the
+// random events exactly as Solve does.  It returns the events produced by each
+// command, starting with those produced by the start of the game.
+func Replay(g *game.Game, s int64, cmds []string) [][]*game.Event {
+	g.Restart()
+	seed(g, s)
+	g.Start()
+	evs := [][]*game.Event{g.Events()}
+	for _, cmd := range cmds {
+		seed(g, s)
+		g.Command(cmd)
+		evs = append(evs, g.Events())
+	}
+	return evs
+}
+
+// Candidates returns the commands worth trying in the given game: a move in
+// every direction, every verb and noun pair mentioned by an action, and GET
+// and DROP for every item with an autograb.
+func Candidates(pb *scottpb.Game) []string {
+	var cmds []string
+	seen := map[string]bool{}
+	add := func(cmd string) {
+		if cmd != "" && !seen[cmd] {
+			seen[cmd] = true
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	for _, dir := range []string{"NORTH", "SOUTH", "EAST", "WEST", "UP", "DOWN"} {
+		add(dir)
+	}
+	for _, a := range pb.Actions {
+		if a.VerbIndex == game.AutoVerb {
+			continue
+		}
+		verb := word(pb.Verbs, int(a.VerbIndex))
+		if verb == "" {
+			continue
+		}
+		if a.NounIndex == 0 {
+			add(verb)
+		} else if noun := word(pb.Nouns, int(a.NounIndex)); noun != "" {
+			add(verb + " " + noun)
+		}
+	}
+	get, drop := word(pb.Verbs, game.GetVerb), word(pb.Verbs, game.DropVerb)
+	for _, it := range pb.Items {
+		if it.Autograb == "" {
+			continue
+		}
+		if get != "" {
+			add(get + " " + it.Autograb)
+		}
+		if drop != "" {
+			add(drop + " " + it.Autograb)
+		}
+	}
+	return cmds
+}
+
+// word returns the text of the given word, or "" if it can't be typed.
+func word(ws []*scottpb.Word, i int) string {
+	if i < 0 || i >= len(ws) {
+		return ""
+	}
+	w := strings.TrimSpace(ws[i].Word)
+	if w == "" || strings.ContainsAny(w, " .") {
+		return ""
+	}
+	return w
+}
+
+// newNode makes a search node for the current state of the game.
+func newNode(g *game.Game, parent *node, cmd string) *node {
+	stored, _ := g.Score()
+	carried := 0
+	for i, it := range g.DB.Game.Items {
+		if it.IsTreasure && g.State.ItemLocations[i] == game.Inventory {
+			carried++
+		}
+	}
+
+	n := &node{
+		state:  g.SaveState(),
+		hash:   g.StateHash(),
+		parent: parent,
+		cmd:    cmd,
+		stored: stored,
+		value:  10*stored + carried,
+		over:   g.IsOver(),
+		won:    isWon(g),
+	}
+	if parent != nil {
+		n.depth = parent.depth + 1
+	}
+	return n
+}
+
+// better checks if node |a| is a better place to stop than node |b|.
+func better(a, b *node) bool {
+	switch {
+	case a.won != b.won:
+		return a.won
+	case a.stored != b.stored:
+		return a.stored > b.stored
+	case a.over != b.over:
+		return !a.over
+	default:
+		return a.depth < b.depth
+	}
+}
+
+// isWon checks if the game ended with every treasure stored.
+func isWon(g *game.Game) bool {
+	stored, total := g.Score()
+	return g.IsOver() && total > 0 && stored == total
+}
+
+// seed reseeds the game from the search seed and the current state.
+func seed(g *game.Game, s int64) {
+	h := g.StateHash()
+	g.Seed(s ^ int64(binary.BigEndian.Uint64(h[0:8])))
+}
@@ -0,0 +1,64 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// loadGame loads one of the bundled games.
+func loadGame(t *testing.T, name string) *game.Game {
+	g, err := game.LoadFromFile("../../../games/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestCandidates(t *testing.T) {
+	g := loadGame(t, "adv01.dat")
+	cmds := Candidates(g.DB.Game)
+
+	seen := map[string]bool{}
+	for _, cmd := range cmds {
+		if seen[cmd] {
+			t.Errorf("Candidates lists %q more than once", cmd)
+		}
+		seen[cmd] = true
+	}
+	for _, want := range []string{"NORTH", "DOWN", "GO TRE", "GET MUD", "DRO HON"} {
+		if !seen[want] {
+			t.Errorf("Candidates doesn't include %q", want)
+		}
+	}
+}
+
+func TestSolveReplays(t *testing.T) {
+	// A small search is enough to store a few treasures in this one.
+	g := loadGame(t, "quest1.dat")
+	r := Solve(g, Options{Seed: 1, MaxDepth: 30, MaxStates: 2000})
+	if r.States > 2000 {
+		t.Errorf("Solve searched %d states, more than the 2000 allowed", r.States)
+	}
+	if len(r.Commands) > 30 {
+		t.Errorf("Solve found %d commands, more than the 30 allowed", len(r.Commands))
+	}
+	if r.Stored == 0 {
+		t.Errorf("Solve stored no treasures: %v", r)
+	}
+
+	evs := Replay(g, 1, r.Commands)
+	if len(evs) != len(r.Commands)+1 {
+		t.Errorf("Replay returned events for %d commands, want %d", len(evs)-1, len(r.Commands))
+	}
+	if g.StateHash() != r.Hash {
+		t.Errorf("Replay of %q ended in a different state", r.Commands)
+	}
+	if stored, _ := g.Score(); stored != r.Stored {
+		t.Errorf("Replay stored %d treasures, want %d", stored, r.Stored)
+	}
+
+	if r2 := Solve(g, Options{Seed: 1, MaxDepth: 30, MaxStates: 2000}); r2.Hash != r.Hash {
+		t.Errorf("Solving again with the same seed gave a different result")
+	}
+}
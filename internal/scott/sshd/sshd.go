@@ -0,0 +1,353 @@
+// Package sshd serves Scott Adams adventure games over SSH.  Each SSH session
+// picks a game from a menu of those held by a session.Manager and plays it,
+// with the output word-wrapped to the width of the player's terminal as it is
+// resized.  Sessions without a terminal work too, minus the line editing.
+//
+// If autosaves are turned on, players who log in with a key are told apart by
+// its fingerprint; anyone else is asked for a name and a password of their
+// own.
+package sshd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	xterm "golang.org/x/term"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/autosave"
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+	"github.com/chaosotter/golang-adventures/internal/scott/stream"
+	"github.com/chaosotter/golang-adventures/internal/scott/term"
+)
+
+// A Server serves the games held by a session.Manager.  The fields should be
+// set before calling Serve.
+type Server struct {
+	HostKey        ssh.Signer      // the server's host key
+	User           string          // the only user name players may log in with
+	Password       string          // the password players must give, if any
+	AuthorizedKeys map[string]bool // the keys players may log in with, in wire format, or nil for none
+	IdleTimeout    time.Duration   // how long a player may go without input, or 0 for forever
+	Charset        stream.Charset  // the character set of the game text
+
+	Autosaves     *autosave.Store // where to keep autosaves, or nil for none
+	AutosaveEvery time.Duration   // how often to autosave a game in progress
+
+	sessions *session.Manager
+}
+
+// New initializes a new Server for the sessions held by the given manager, for
+// players logging in as |user| with the given host key.  Unless a password or
+// authorized keys are set, anyone may play.
+func New(m *session.Manager, hostKey ssh.Signer, user string) *Server {
+	return &Server{HostKey: hostKey, User: user, sessions: m}
+}
+
+// Serve accepts connections on the listener, serving each in its own
+// goroutine, until the listener fails.
+func (s *Server) Serve(l net.Listener) error {
+	config := s.config()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go s.serve(config, conn)
+	}
+}
+
+// config sets up the host key and the authentication methods.
+func (s *Server) config() *ssh.ServerConfig {
+	config := &ssh.ServerConfig{}
+
+	if s.Password != "" {
+		config.PasswordCallback = func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == s.User && subtle.ConstantTimeCompare(pass, []byte(s.Password)) == 1 {
+				return nil, nil
+			}
+			return nil, errors.New("Wrong user or password")
+		}
+	}
+	if s.AuthorizedKeys != nil {
+		config.PublicKeyCallback = func(c ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if c.User() == s.User && s.AuthorizedKeys[string(key.Marshal())] {
+				// This tells the player's autosaves apart.
+				return &ssh.Permissions{Extensions: map[string]string{
+					"fingerprint": ssh.FingerprintSHA256(key),
+				}}, nil
+			}
+			return nil, errors.New("Wrong user or key")
+		}
+	}
+	// The user name is checked after the handshake in this case.
+	config.NoClientAuth = s.Password == "" && s.AuthorizedKeys == nil
+
+	config.AddHostKey(s.HostKey)
+	return config
+}
+
+// LoadHostKey reads the host key from the given file, first generating a new
+// one if the file doesn't exist.
+func LoadHostKey(path string) (ssh.Signer, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("Could not generate host key: %v", err)
+		}
+		block, err := ssh.MarshalPrivateKey(priv, "")
+		if err != nil {
+			return nil, fmt.Errorf("Could not encode host key: %v", err)
+		}
+		data = pem.EncodeToMemory(block)
+		if err := ioutil.WriteFile(path, data, 0600); err != nil {
+			return nil, fmt.Errorf("Could not save host key: %v", err)
+		}
+		log.Printf("Generated a new host key in %s.", path)
+	} else if err != nil {
+		return nil, fmt.Errorf("Could not read host key: %v", err)
+	}
+
+	key, err := ssh.ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse host key %s: %v", path, err)
+	}
+	return key, nil
+}
+
+// LoadAuthorizedKeys reads a file in the format of OpenSSH's authorized_keys,
+// returning the set of keys in their wire format.
+func LoadAuthorizedKeys(path string) (map[string]bool, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read authorized keys: %v", err)
+	}
+
+	keys := map[string]bool{}
+	for len(bytes.TrimSpace(data)) > 0 {
+		key, _, _, rest, err := ssh.ParseAuthorizedKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse authorized keys %s: %v", path, err)
+		}
+		keys[string(key.Marshal())] = true
+		data = rest
+	}
+	return keys, nil
+}
+
+// serve handles a single SSH connection.
+func (s *Server) serve(config *ssh.ServerConfig, conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	sconn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		log.Printf("%s: handshake failed: %v", conn.RemoteAddr(), err)
+		return
+	}
+	conn.SetDeadline(time.Time{})
+	defer sconn.Close()
+
+	if sconn.User() != s.User {
+		log.Printf("%s: rejected user %q", conn.RemoteAddr(), sconn.User())
+		return
+	}
+	log.Printf("%s: connected", conn.RemoteAddr())
+	defer log.Printf("%s: disconnected", conn.RemoteAddr())
+
+	go ssh.DiscardRequests(reqs)
+	for nc := range chans {
+		if nc.ChannelType() != "session" {
+			nc.Reject(ssh.UnknownChannelType, "Only sessions are supported")
+			continue
+		}
+		ch, reqs, err := nc.Accept()
+		if err != nil {
+			log.Printf("%s: could not accept channel: %v", conn.RemoteAddr(), err)
+			continue
+		}
+		p := &player{s: s, conn: conn, ch: ch}
+		if sconn.Permissions != nil {
+			p.key = sconn.Permissions.Extensions["fingerprint"]
+		}
+		go p.handle(reqs)
+	}
+}
+
+// A player is a single SSH session.
+type player struct {
+	s    *Server
+	conn net.Conn    // the underlying connection, for timeouts
+	ch   ssh.Channel // the SSH session
+	key  string      // the fingerprint of the player's key, if they used one
+
+	mu     sync.Mutex
+	width  int             // the width of the terminal
+	pty    bool            // set if the client asked for a terminal
+	screen *xterm.Terminal // the terminal, once the game is running
+}
+
+// These are the payloads of the requests we handle, as given in RFC 4254.
+type ptyRequest struct {
+	Term          string
+	Columns, Rows uint32
+	Width, Height uint32
+	Modes         string
+}
+
+type windowChange struct {
+	Columns, Rows uint32
+	Width, Height uint32
+}
+
+// handle deals with the requests for a session, starting the game when the
+// client asks for a shell.
+func (p *player) handle(reqs <-chan *ssh.Request) {
+	started := false
+	for req := range reqs {
+		ok := false
+		switch req.Type {
+		case "pty-req":
+			var r ptyRequest
+			if err := ssh.Unmarshal(req.Payload, &r); err == nil {
+				p.resize(int(r.Columns), int(r.Rows))
+				p.mu.Lock()
+				p.pty = true
+				p.mu.Unlock()
+				ok = true
+			}
+		case "window-change":
+			var r windowChange
+			if err := ssh.Unmarshal(req.Payload, &r); err == nil {
+				p.resize(int(r.Columns), int(r.Rows))
+				ok = true
+			}
+		case "shell":
+			ok = !started
+			if !started {
+				started = true
+				go p.play()
+			}
+		}
+		if req.WantReply {
+			req.Reply(ok, nil)
+		}
+	}
+}
+
+// resize records a new size for the player's terminal.
+func (p *player) resize(width, height int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.width = width
+	if p.screen != nil {
+		p.screen.SetSize(width, height)
+	}
+}
+
+// Width returns the width of the player's terminal.
+func (p *player) Width() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.width <= 0 {
+		return 80
+	}
+	return p.width
+}
+
+// play runs the game menu and then the game, and ends the session.
+func (p *player) play() {
+	defer p.ch.Close()
+
+	var out io.Writer
+	var readLine, readSecret func(prompt string) (string, error)
+	p.mu.Lock()
+	if p.pty {
+		p.screen = xterm.NewTerminal(p.ch, "")
+		p.screen.SetSize(p.width, 0)
+		out = p.screen
+		readLine = func(prompt string) (string, error) {
+			p.idle()
+			p.screen.SetPrompt(prompt)
+			return p.screen.ReadLine()
+		}
+		readSecret = func(prompt string) (string, error) {
+			p.idle()
+			return p.screen.ReadPassword(prompt)
+		}
+	} else {
+		in := bufio.NewReader(p.ch)
+		out = p.ch
+		readLine = func(prompt string) (string, error) {
+			p.idle()
+			io.WriteString(p.ch, prompt)
+			line, err := in.ReadString('\n')
+			if err == io.EOF && line != "" {
+				err = nil
+			}
+			return strings.TrimRight(line, "\r\n"), err
+		}
+	}
+	p.mu.Unlock()
+
+	t := term.New(out, p.Width, readLine)
+	t.ReadSecret = readSecret
+	t.Charset = p.s.Charset
+	p.run(t)
+	p.ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+}
+
+// run picks a game and plays it.
+func (p *player) run(t *term.Terminal) {
+	m := p.s.sessions
+	games, err := m.Games()
+	if err != nil {
+		log.Printf("Could not list games: %v", err)
+	}
+	name, err := t.ChooseGame(games)
+	if err != nil || name == "" {
+		return
+	}
+
+	s, err := m.Create(name, 0)
+	if err != nil {
+		t.Printf("Could not load the game: %v\n", err)
+		return
+	}
+	defer m.Close(s.ID)
+	log.Printf("%s: playing %s", p.conn.RemoteAddr(), s.Name)
+	if p.s.Autosaves == nil {
+		t.Play(s)
+		return
+	}
+	who := p.key
+	if who == "" {
+		if who, err = t.AskPlayer(); err != nil {
+			return
+		}
+	}
+	if err := t.PlayAutosaved(s, p.s.Autosaves, who, p.s.AutosaveEvery); err != nil {
+		log.Printf("%s: %v", p.conn.RemoteAddr(), err)
+	}
+}
+
+// idle sets the deadline for the player's next input.
+func (p *player) idle() {
+	if p.s.IdleTimeout > 0 {
+		p.conn.SetReadDeadline(time.Now().Add(p.s.IdleTimeout))
+	}
+}
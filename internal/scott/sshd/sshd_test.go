@@ -0,0 +1,270 @@
+package sshd
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+)
+
+// newKey generates a new key for the tests.
+func newKey(t *testing.T) ssh.Signer {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// newServer starts a server for the bundled games on a local port, returning
+// its address.  The server is configured by |setup|, if given.
+func newServer(t *testing.T, setup func(s *Server)) (*Server, string) {
+	s := New(session.NewManager("../../../games"), newKey(t), "play")
+	if setup != nil {
+		setup(s)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go s.Serve(l)
+	return s, l.Addr().String()
+}
+
+// dial connects to the server as the given user.
+func dial(s *Server, addr, user string, auth ...ssh.AuthMethod) (*ssh.Client, error) {
+	return ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            user,
+		Auth:            auth,
+		HostKeyCallback: ssh.FixedHostKey(s.HostKey.PublicKey()),
+		Timeout:         10 * time.Second,
+	})
+}
+
+func TestAuth(t *testing.T) {
+	key, otherKey := newKey(t), newKey(t)
+	s, addr := newServer(t, func(s *Server) {
+		s.Password = "xyzzy"
+		s.AuthorizedKeys = map[string]bool{string(key.PublicKey().Marshal()): true}
+	})
+
+	for _, tc := range []struct {
+		name string
+		user string
+		auth ssh.AuthMethod
+		ok   bool
+	}{
+		{"password", "play", ssh.Password("xyzzy"), true},
+		{"wrong password", "play", ssh.Password("plugh"), false},
+		{"key", "play", ssh.PublicKeys(key), true},
+		{"unknown key", "play", ssh.PublicKeys(otherKey), false},
+		{"password for the wrong user", "root", ssh.Password("xyzzy"), false},
+		{"key for the wrong user", "root", ssh.PublicKeys(key), false},
+	} {
+		c, err := dial(s, addr, tc.user, tc.auth)
+		if err == nil {
+			c.Close()
+		}
+		if got := err == nil; got != tc.ok {
+			t.Errorf("%s: Dial returned %v", tc.name, err)
+		}
+	}
+}
+
+func TestWrongUserWithoutAuth(t *testing.T) {
+	s, addr := newServer(t, nil)
+
+	// Without a password or keys the handshake succeeds for anyone, but the
+	// connection is closed straight afterwards for the wrong user.
+	c, err := dial(s, addr, "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if sess, err := c.NewSession(); err == nil {
+		sess.Close()
+		t.Errorf("NewSession as the wrong user succeeded")
+	}
+
+	c, err = dial(s, addr, "play")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	sess, err := c.NewSession()
+	if err != nil {
+		t.Fatalf("NewSession as the right user returned %v", err)
+	}
+	sess.Close()
+}
+
+// reader collects the output of a session as it arrives.
+type reader struct {
+	t   *testing.T
+	in  chan []byte
+	buf []byte
+}
+
+func newReader(t *testing.T, r io.Reader) *reader {
+	rd := &reader{t: t, in: make(chan []byte, 100)}
+	go func() {
+		for {
+			b := make([]byte, 1024)
+			n, err := r.Read(b)
+			if n > 0 {
+				rd.in <- b[0:n]
+			}
+			if err != nil {
+				close(rd.in)
+				return
+			}
+		}
+	}()
+	return rd
+}
+
+// until reads up to and including the given text, returning everything before
+// it.
+func (rd *reader) until(want string) string {
+	rd.t.Helper()
+	timeout := time.After(10 * time.Second)
+	for !bytes.Contains(rd.buf, []byte(want)) {
+		select {
+		case b, ok := <-rd.in:
+			if !ok {
+				rd.t.Fatalf("Session ended after %q, want %q", rd.buf, want)
+			}
+			rd.buf = append(rd.buf, b...)
+		case <-timeout:
+			rd.t.Fatalf("Timed out after %q, want %q", rd.buf, want)
+		}
+	}
+	i := bytes.Index(rd.buf, []byte(want))
+	got := string(rd.buf[0:i])
+	rd.buf = rd.buf[i+len(want):]
+	return got
+}
+
+func TestPlay(t *testing.T) {
+	s, addr := newServer(t, func(s *Server) { s.Password = "xyzzy" })
+	c, err := dial(s, addr, "play", ssh.Password("xyzzy"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	sess, err := c.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sess.Close()
+
+	stdin, err := sess.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := sess.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := newReader(t, stdout)
+
+	// No terminal, but a window size all the same.  Requests are handled in
+	// order, so this takes effect before the game starts.
+	size := ssh.Marshal(windowChange{Columns: 30, Rows: 24})
+	if _, err := sess.SendRequest("window-change", false, size); err != nil {
+		t.Fatal(err)
+	}
+	if err := sess.Shell(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The heading only fits on one line at the default width.
+	menu := out.until("Which game would you like to play (or Q to quit) ? ")
+	if !strings.HasPrefix(menu, "The following games are\navailable:\n") {
+		t.Errorf("The menu was not wrapped to 30 columns: %q", menu)
+	}
+
+	io.WriteString(stdin, "adv01\n")
+	opening := out.until("Tell me what to do ? ")
+	if !strings.Contains(opening, "forest") {
+		t.Errorf("The game opened with %q", opening)
+	}
+	for _, line := range strings.Split(opening, "\n") {
+		if len(line) > 29 && strings.Contains(line, " ") {
+			t.Errorf("The line %q is wider than the window", line)
+		}
+	}
+
+	io.WriteString(stdin, "GO EAST\n")
+	if got := out.until("Tell me what to do ? "); !strings.Contains(got, "meadow") {
+		t.Errorf("GO EAST printed %q", got)
+	}
+
+	// The session ends when the input does.
+	stdin.Close()
+	done := make(chan error, 1)
+	go func() { done <- sess.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("The session ended with %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Errorf("The session didn't end after the input was closed")
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "host_key")
+
+	// The first load generates the key, and the second reads it back.
+	key, err := LoadHostKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := LoadHostKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(key.PublicKey().Marshal(), again.PublicKey().Marshal()) {
+		t.Errorf("LoadHostKey generated a new key when one was saved")
+	}
+
+	other := newKey(t)
+	keysPath := filepath.Join(dir, "authorized_keys")
+	data := append(ssh.MarshalAuthorizedKey(key.PublicKey()), "\n# A comment.\n"...)
+	data = append(data, ssh.MarshalAuthorizedKey(other.PublicKey())...)
+	if err := ioutil.WriteFile(keysPath, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := LoadAuthorizedKeys(keysPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || !keys[string(key.PublicKey().Marshal())] || !keys[string(other.PublicKey().Marshal())] {
+		t.Errorf("LoadAuthorizedKeys returned %d keys", len(keys))
+	}
+
+	if err := ioutil.WriteFile(keysPath, []byte("not a key\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAuthorizedKeys(keysPath); err == nil {
+		t.Errorf("LoadAuthorizedKeys accepted a bad file")
+	}
+}
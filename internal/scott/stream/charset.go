@@ -0,0 +1,64 @@
+package stream
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Charset identifies the character set used for the text of a game file.  The
+// original games were pure ASCII, but fan-made and translated games often use
+// the upper half of a PC code page.
+type Charset int
+
+const (
+	Raw    = Charset(iota) // leave the bytes as they are
+	Latin1                 // ISO 8859-1
+	CP437                  // the original IBM PC code page
+)
+
+// ParseCharset parses the name of a character set, as given on a command line.
+func ParseCharset(name string) (Charset, error) {
+	switch strings.ToLower(name) {
+	case "", "raw", "ascii":
+		return Raw, nil
+	case "latin1", "latin-1", "iso-8859-1":
+		return Latin1, nil
+	case "cp437", "ibm437":
+		return CP437, nil
+	default:
+		return Raw, fmt.Errorf("unknown character set %q", name)
+	}
+}
+
+// cp437 holds the characters for the upper half of code page 437.
+const cp437 = "ÇüéâäàåçêëèïîìÄÅÉæÆôöòûùÿÖÜ¢£¥₧ƒáíóúñÑªº¿⌐¬½¼¡«»" +
+	"░▒▓│┤╡╢╖╕╣║╗╝╜╛┐└┴┬├─┼╞╟╚╔╩╦╠═╬╧╨╤╥╙╘╒╓╫╪┘┌█▄▌▐▀" +
+	"αßΓπΣσµτΦΘΩδ∞φε∩≡±≥≤⌠⌡÷≈°∙·√ⁿ²■ "
+
+// cp437Runes is cp437 indexed by byte value minus 0x80.
+var cp437Runes = []rune(cp437)
+
+// ToUTF8 converts text from a game file in the given character set to UTF-8,
+// for display.  Bytes below 0x80 are plain ASCII in every character set, so
+// only the upper half is affected.  With Raw, the text is returned unchanged.
+func ToUTF8(s string, cs Charset) string {
+	if cs == Raw {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case ch < utf8.RuneSelf:
+			b.WriteByte(ch)
+		case cs == Latin1:
+			b.WriteRune(rune(ch))
+		default:
+			b.WriteRune(cp437Runes[ch-0x80])
+		}
+	}
+	return b.String()
+}
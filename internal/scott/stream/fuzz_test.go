@@ -0,0 +1,43 @@
+package stream
+
+import (
+	"bytes"
+	"testing"
+)
+
+// FuzzNew checks that tokenizing never panics, and that Stream and Reader
+// always agree.
+func FuzzNew(f *testing.F) {
+	for _, data := range corpus(f) {
+		f.Add(data)
+	}
+	f.Add([]byte("1 -2 \"a\"\"b\" 3"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		NewLenient(data)
+
+		s, err := New(data)
+		if err != nil {
+			if _, ok := err.(*Error); !ok {
+				t.Fatalf("New returned %T, not *Error: %v", err, err)
+			}
+			return
+		}
+		want, err := drain(s)
+		if err != nil {
+			t.Fatalf("reading Stream: %v", err)
+		}
+		got, err := drain(NewReader(bytes.NewReader(data)))
+		if err != nil {
+			t.Fatalf("reading Reader: %v", err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("Reader returned %d tokens, Stream returned %d", len(got), len(want))
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("token %d: Reader returned %+v, Stream returned %+v", i, got[i], want[i])
+			}
+		}
+	})
+}
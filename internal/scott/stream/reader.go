@@ -0,0 +1,292 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Reader tokenizes game data incrementally, reading only as far as it needs
+// to in order to return the next token.  This avoids holding whole files in
+// memory when loading many games at once.
+//
+// If Lenient is set, the Reader recovers from damaged input rather than
+// failing: stray characters are skipped, and a string cut off by the end of
+// the file is accepted as it stands.  Each problem is recorded in Warnings.
+type Reader struct {
+	Lenient  bool     // set to recover from damaged input
+	Warnings []*Error // the problems recovered from in lenient mode
+
+	in   *bufio.Reader
+	pos  Pos    // position of the next byte to be read
+	line []byte // the current line up to the next byte, for error messages
+	buf  []byte // the text of the token being read
+	idx  int    // index of the next token
+
+	peeked token // the next token, if it has been read ahead
+	ok     bool  // set if |peeked| is valid
+	err    error // the error that stopped the tokenizer, if any
+}
+
+// NewReader initializes a new Reader for the given input.
+func NewReader(in io.Reader) *Reader {
+	return &Reader{
+		in:  bufio.NewReader(in),
+		pos: Pos{Line: 1, Column: 1},
+	}
+}
+
+// Done checks if we're at the end of the input.  If the input can't be
+// tokenized, Done returns false so that the error is reported by the next
+// call to NextInt or NextString.
+func (r *Reader) Done() bool {
+	return r.peek() == io.EOF
+}
+
+// NextInt returns the next integer in the input.
+func (r *Reader) NextInt() (int, error) {
+	t, err := r.nextToken(typeInt)
+	if err != nil {
+		return 0, err
+	}
+	return t.num, nil
+}
+
+// NextString returns the next string in the input.
+func (r *Reader) NextString() (string, error) {
+	t, err := r.nextToken(typeStr)
+	if err != nil {
+		return "", err
+	}
+	return t.str, nil
+}
+
+// Pos returns the position of the next token, or of the end of the input if
+// there are no more tokens.
+func (r *Reader) Pos() Pos {
+	if r.peek() != nil {
+		return r.pos
+	}
+	return r.peeked.pos
+}
+
+// peek reads ahead to the next token if necessary.  The result is nil if there
+// is a token, io.EOF at the end of the input, or an error from the tokenizer.
+func (r *Reader) peek() error {
+	if r.ok {
+		return nil
+	}
+	if r.err != nil {
+		return r.err
+	}
+	r.peeked, r.err = r.scan()
+	r.ok = r.err == nil
+	return r.err
+}
+
+// nextToken returns the next token, which should be of the given type (for
+// internal use).  A token of the wrong type is left unread.
+func (r *Reader) nextToken(want typ) (token, error) {
+	switch err := r.peek(); {
+	case err == io.EOF:
+		return token{}, r.errorAt(r.pos, "Premature end of stream; expected %s", want)
+	case err != nil:
+		return token{}, err
+	}
+
+	t := r.peeked
+	if t.typ != want {
+		return token{}, r.errorAt(t.pos, "Expected %s, found %s", want, describe(t))
+	}
+	r.ok = false
+	r.idx++
+	return t, nil
+}
+
+// These states are used by the FSM in scan for parsing the input data.  The
+// individual states are documented inline.
+const (
+	stateInit = iota
+	stateSign
+	stateNum
+	stateQuote
+)
+
+// scan reads the next token from the input, returning io.EOF if there are no
+// more tokens.
+func (r *Reader) scan() (token, error) {
+	st := stateInit
+	r.buf = r.buf[0:0]
+	var start Pos // position of the current token
+	var num int   // value of the current integer
+	neg := false  // set if the current integer is negative
+
+	for {
+		pos := r.pos
+		ch, err := r.in.ReadByte()
+		if err == io.EOF {
+			switch st {
+			case stateQuote:
+				if !r.Lenient {
+					return token{}, r.errorAt(start, "Unterminated string")
+				}
+				r.warn(start, "Unterminated string at end of file")
+				return token{typ: typeStr, str: string(r.buf), pos: start}, nil
+			case stateNum:
+				// ScottFree is happy with a final integer that isn't followed
+				// by a newline, so we are too.
+				if r.Lenient {
+					r.warn(pos, "Missing newline at end of file")
+				}
+				if neg {
+					num = -num
+				}
+				return token{typ: typeInt, num: num, pos: start}, nil
+			case stateSign:
+				if !r.Lenient {
+					return token{}, r.errorAt(start, "Unexpected end of file after '-'; expected a digit")
+				}
+				r.warn(start, "Skipping '-' at end of file")
+				return token{}, io.EOF
+			default:
+				return token{}, io.EOF
+			}
+		}
+		if err != nil {
+			return token{}, err
+		}
+		r.advance(ch)
+
+		switch st {
+		// Init state: Not currently reading any token.
+		case stateInit:
+			switch {
+			case isSpace(ch):
+				// pass
+			case ch == '-':
+				neg = true
+				start, st = pos, stateSign
+			case isDigit(ch):
+				num = int(ch - '0')
+				start, st = pos, stateNum
+			case ch == '"':
+				start, st = pos, stateQuote
+			case r.Lenient:
+				r.warn(pos, "Skipping unexpected character %q between tokens", ch)
+			default:
+				return token{}, r.errorAt(pos, "Unexpected character %q between tokens; expected an integer or a string", ch)
+			}
+
+		// Sign state: Read the initial '-' of a negative integer.
+		case stateSign:
+			switch {
+			case isDigit(ch):
+				num = int(ch - '0')
+				st = stateNum
+			case r.Lenient:
+				r.warn(start, "Skipping '-' not followed by a digit")
+				neg, st = false, stateInit
+				if ch == '"' {
+					start, st = pos, stateQuote
+				}
+			default:
+				return token{}, r.errorAt(pos, "Unexpected character %q after '-'; expected a digit", ch)
+			}
+
+		// Num state: Now reading an integer.
+		case stateNum:
+			switch {
+			case isSpace(ch):
+				if neg {
+					num = -num
+				}
+				return token{typ: typeInt, num: num, pos: start}, nil
+			case isDigit(ch):
+				if num > (math.MaxInt32-int(ch-'0'))/10 {
+					if !r.Lenient {
+						return token{}, r.errorAt(start, "Integer is too large")
+					}
+					r.warn(pos, "Ignoring extra digit %q in integer that is too large", ch)
+					break
+				}
+				num = num*10 + int(ch-'0')
+			case r.Lenient:
+				r.warn(pos, "Ignoring unexpected character %q in integer", ch)
+			default:
+				return token{}, r.errorAt(pos, "Unexpected character %q in integer; expected a digit or whitespace", ch)
+			}
+
+		// Quote state: Read the initial '"' of a string.  A doubled '"' stands
+		// for a single one within the string.
+		case stateQuote:
+			if ch != '"' {
+				r.buf = append(r.buf, ch)
+				break
+			}
+			if next, err := r.in.Peek(1); err == nil && next[0] == '"' {
+				r.in.ReadByte()
+				r.advance('"')
+				r.buf = append(r.buf, '"')
+				break
+			}
+			return token{typ: typeStr, str: string(r.buf), pos: start}, nil
+
+		default:
+			return token{}, r.errorAt(pos, "Internal error: unknown state %d", st)
+		}
+	}
+}
+
+// advance moves past a byte that has been read.
+func (r *Reader) advance(ch byte) {
+	r.pos.advance(ch)
+	if ch == '\n' {
+		r.line = r.line[0:0]
+	} else {
+		r.line = append(r.line, ch)
+	}
+}
+
+// errorAt makes an *Error for a problem with the next token at the given
+// position.
+func (r *Reader) errorAt(p Pos, format string, args ...interface{}) *Error {
+	return &Error{
+		Pos:     p,
+		Token:   r.idx,
+		Msg:     fmt.Sprintf(format, args...),
+		Context: r.context(),
+	}
+}
+
+// warn records a problem that has been recovered from in lenient mode.
+func (r *Reader) warn(p Pos, format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, r.errorAt(p, format, args...))
+}
+
+// context returns the text of the current line near the next byte: up to 40
+// bytes on either side, without reading any further into the input.
+func (r *Reader) context() string {
+	const width = 40
+	before := r.line
+	if len(before) > width {
+		before = before[len(before)-width:]
+	}
+	after, _ := r.in.Peek(width)
+	if i := bytes.IndexAny(after, "\r\n"); i >= 0 {
+		after = after[0:i]
+	}
+	return string(before) + string(after)
+}
+
+// isDigit checks if this byte is a digit in the traditional ASCII code.
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+// isSpace checks if this byte is whitespace in the traditional ASCII code.  We
+// ignore '\v' because seriously, nobody ever used that.
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
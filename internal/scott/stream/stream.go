@@ -5,14 +5,22 @@
 // surrounding whitespace) and quote-delimited strings (possibly with internal
 // newlines).
 //
+// The quoting scheme for strings is the one ScottFree uses: a string runs from
+// one '"' to the next, except that a doubled '""' within a string stands for a
+// single '"'.  Every other byte, including '\', stands for itself.  (Game
+// files usually write quotations with '`' instead, which ScottFree shows as
+// '"'; we leave that to the drivers.)
+//
 // We don't pay the slightest bit of attention to Unicode or processing the data
-// as runes, since this file format is from the 8-bit days.
+// as runes, since this file format is from the 8-bit days.  See ToUTF8 for
+// converting text for display.
 package stream
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
-	"strconv"
+	"io"
 )
 
 // typ represents the type of a token, either Int or String
@@ -23,119 +31,127 @@ const (
 	typeStr
 )
 
+// String returns a description of the token type for use in error messages.
+func (t typ) String() string {
+	if t == typeInt {
+		return "an integer"
+	}
+	return "a string"
+}
+
 // A token is the basic unit of data we parse from the input.  We do not expose
 // this type externally because the file format always allows us to know in
 // advance whether the next token should be an integer or a string.
 type token struct {
-	typ   typ
-	value interface{}
+	typ typ
+	num int    // the value, for typeInt
+	str string // the value, for typeStr
+	pos Pos    // where the token starts
 }
 
 // Stream is the actual type exposed through this package and contains a fully
 // parsed sequences of tokens and a current-position marker.
 type Stream struct {
+	data   []byte
 	tokens []token
 	next   int
 }
 
-// These states are used by the FSM in New for parsing the input data.  The
-// individual states are documented inline.
-const (
-	stateInit = iota
-	stateSign
-	stateNum
-	stateQuote
-	stateEscape
-)
+// Pos is a position within the input.  Lines and columns are numbered from 1,
+// as editors do, and columns count bytes.
+type Pos struct {
+	Offset int // byte offset from the start of the input
+	Line   int // line number
+	Column int // column number
+}
 
-// New initializes a new Stream from the given game data.  The files are small
-// and we never read them partially, so we do all of the parsing up front.
-func New(data []byte) (*Stream, error) {
-	s := &Stream{}
-	st := stateInit
-	b := &bytes.Buffer{}
-
-	for o := 0; o < len(data); o++ {
-		ch := data[o]
-		switch st {
-		// Init state: Not currently reading any token.
-		case stateInit:
-			switch {
-			case isSpace(ch):
-				// pass
-			case ch == '-':
-				b.WriteByte(ch)
-				st = stateSign
-			case isDigit(ch):
-				b.WriteByte(ch)
-				st = stateNum
-			case ch == '"':
-				st = stateQuote
-			default:
-				return nil, fmt.Errorf("Unexpected character '%c' at offset %d (state Init)", ch, o)
-			}
-
-		// Sign state: Read the initial '-' of a negative integer.
-		case stateSign:
-			switch {
-			case isDigit(ch):
-				b.WriteByte(ch)
-				st = stateNum
-			default:
-				return nil, fmt.Errorf("Unexpected character '%c' at offset %d (state Sign)", ch, o)
-			}
-
-		// Num state: Now reading an integer.
-		case stateNum:
-			switch {
-			case isSpace(ch):
-				val, err := strconv.Atoi(b.String())
-				if err != nil {
-					return nil, fmt.Errorf("Internal error converting '%s' to int at offset %d (state Num)", b.String(), o)
-				}
-				s.tokens = append(s.tokens, token{typeInt, val})
-				b.Reset()
-				st = stateInit
-			case isDigit(ch):
-				b.WriteByte(ch)
-			default:
-				return nil, fmt.Errorf("Unexpected character '%c' at offset %d (state Num)", ch, o)
-			}
-
-		// Quote state: Read the initial '"' of a string.
-		case stateQuote:
-			switch {
-			case ch == '\\':
-				st = stateEscape
-			case ch == '"':
-				s.tokens = append(s.tokens, token{typeStr, b.String()})
-				b.Reset()
-				st = stateInit
-			default:
-				b.WriteByte(ch)
-			}
-
-		// Escape state: Read the next character in a string unconditionally.
-		case stateEscape:
-			b.WriteByte(ch)
-
-		default:
-			return nil, fmt.Errorf("Internal error: unknown state %d", st)
-		}
+// String formats the position as "line:column".
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// advance moves the position past the given byte.
+func (p *Pos) advance(ch byte) {
+	p.Offset++
+	if ch == '\n' {
+		p.Line++
+		p.Column = 1
+	} else {
+		p.Column++
 	}
+}
+
+// Error describes a problem with the input, along with where it was found.
+// Callers that know the name of the file can prefix it to get the usual
+// "file:line:column: message" form.
+type Error struct {
+	Pos     Pos    // where the problem was found
+	Token   int    // index of the token involved
+	Msg     string // what went wrong
+	Context string // the text surrounding the problem
+}
 
-	return s, nil
+// Error formats the error as "line:column: message", followed by the token
+// index and the surrounding text.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s (token %d, near %q)", e.Pos, e.Msg, e.Token, e.Context)
+}
+
+// Annotate adds some context to the front of the message of an error.  If the
+// error came from a Stream, the result is still an *Error at the same position.
+func Annotate(err error, format string, args ...interface{}) error {
+	var se *Error
+	if errors.As(err, &se) {
+		annotated := *se
+		annotated.Msg = fmt.Sprintf(format, args...) + ": " + se.Msg
+		return &annotated
+	}
+	return fmt.Errorf("%s: %v", fmt.Sprintf(format, args...), err)
+}
+
+// A Source produces the tokens of a game file in order.  It is implemented by
+// both Stream and Reader.
+type Source interface {
+	Done() bool
+	NextInt() (int, error)
+	NextString() (string, error)
+	Pos() Pos
+}
+
+// New initializes a new Stream from the given game data.  The files are small,
+// so we do all of the tokenizing up front; use NewReader instead to tokenize
+// incrementally.
+func New(data []byte) (*Stream, error) {
+	s, _, err := tokenize(data, false)
+	return s, err
 }
 
-// isDigit checks if this byte is a digit in the traditional ASCII code.
-func isDigit(ch byte) bool {
-	return ch >= '0' && ch <= '9'
+// NewLenient is like New, but recovers from damaged input as described for
+// Reader, returning the problems found as warnings.  It never fails.
+func NewLenient(data []byte) (*Stream, []*Error) {
+	s, warnings, _ := tokenize(data, true)
+	return s, warnings
 }
 
-// isSpace checks if this byte is whitespace in the traditional ASCII code.  We
-// ignore '\v' because seriously, nobody ever used that.
-func isSpace(ch byte) bool {
-	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+// tokenize does the work for New and NewLenient.
+func tokenize(data []byte, lenient bool) (*Stream, []*Error, error) {
+	// Real game files average a token for every seven or so bytes, so this
+	// is nearly always enough.
+	s := &Stream{data: data, tokens: make([]token, 0, len(data)/6)}
+	r := NewReader(bytes.NewReader(data))
+	r.Lenient = lenient
+	for {
+		t, err := r.scan()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, r.Warnings, err
+		}
+		s.tokens = append(s.tokens, t)
+		r.idx++
+	}
+	return s, r.Warnings, nil
 }
 
 // Done checks if we're at the end of the stream.
@@ -145,34 +161,87 @@ func (s *Stream) Done() bool {
 
 // NextInt returns the next integer in the stream.
 func (s *Stream) NextInt() (int, error) {
-	switch t, err := s.nextToken(); {
-	case err != nil:
+	t, err := s.nextToken(typeInt)
+	if err != nil {
 		return 0, err
-	case t.typ != typeInt:
-		return 0, fmt.Errorf("Next token was a string, expected an integer")
-	default:
-		return t.value.(int), nil
 	}
+	return t.num, nil
 }
 
 // NextString returns the next string in the stream.
 func (s *Stream) NextString() (string, error) {
-	switch t, err := s.nextToken(); {
-	case err != nil:
+	t, err := s.nextToken(typeStr)
+	if err != nil {
 		return "", err
-	case t.typ != typeStr:
-		return "", fmt.Errorf("Next token was an integer, expected a string")
-	default:
-		return t.value.(string), nil
 	}
+	return t.str, nil
 }
 
-// nextToken returns the next token (for internal use).
-func (s *Stream) nextToken() (token, error) {
+// Pos returns the position of the next token, or of the end of the input if
+// there are no more tokens.
+func (s *Stream) Pos() Pos {
 	if s.Done() {
-		return token{}, fmt.Errorf("Premature end of stream")
+		return s.endPos()
+	}
+	return s.tokens[s.next].pos
+}
+
+// nextToken returns the next token, which should be of the given type (for
+// internal use).
+func (s *Stream) nextToken(want typ) (token, error) {
+	if s.Done() {
+		return token{}, s.errorAt(s.endPos(), s.next, "Premature end of stream; expected %s", want)
 	}
 	t := s.tokens[s.next]
+	if t.typ != want {
+		return token{}, s.errorAt(t.pos, s.next, "Expected %s, found %s", want, describe(t))
+	}
 	s.next++
 	return t, nil
 }
+
+// describe describes a token for use in error messages.
+func describe(t token) string {
+	if t.typ == typeInt {
+		return fmt.Sprintf("the integer %d", t.num)
+	}
+	str := t.str
+	if len(str) > 20 {
+		str = str[0:17] + "..."
+	}
+	return fmt.Sprintf("the string %q", str)
+}
+
+// endPos returns the position of the end of the input.
+func (s *Stream) endPos() Pos {
+	p := Pos{Line: 1, Column: 1}
+	for _, ch := range s.data {
+		p.advance(ch)
+	}
+	return p
+}
+
+// errorAt makes an *Error for a problem with the given token at the given
+// position.
+func (s *Stream) errorAt(p Pos, idx int, format string, args ...interface{}) *Error {
+	return &Error{
+		Pos:     p,
+		Token:   idx,
+		Msg:     fmt.Sprintf(format, args...),
+		Context: context(s.data, p),
+	}
+}
+
+// context returns the text around the given position, limited to the line
+// it's on and at most 40 bytes either side.
+func context(data []byte, p Pos) string {
+	const width = 40
+	lo, hi := p.Offset, p.Offset
+	for lo > 0 && data[lo-1] != '\n' && p.Offset-lo < width {
+		lo--
+	}
+	for hi < len(data) && data[hi] != '\n' && data[hi] != '\r' && hi-p.Offset < width {
+		hi++
+	}
+	return string(data[lo:hi])
+}
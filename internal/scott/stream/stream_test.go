@@ -0,0 +1,360 @@
+package stream
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// corpus loads all of the bundled game files.
+func corpus(tb testing.TB) map[string][]byte {
+	paths, err := filepath.Glob("../../../games/*.dat")
+	if err != nil || len(paths) == 0 {
+		tb.Fatalf("Could not find the game files: %v", err)
+	}
+
+	files := map[string][]byte{}
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			tb.Fatalf("Could not read %q: %v", path, err)
+		}
+		files[filepath.Base(path)] = data
+	}
+	return files
+}
+
+// drain reads every token from a Source, without needing to know the types in
+// advance.
+func drain(s Source) ([]token, error) {
+	var ts []token
+	for !s.Done() {
+		if n, err := s.NextInt(); err == nil {
+			ts = append(ts, token{typ: typeInt, num: n})
+			continue
+		}
+		str, err := s.NextString()
+		if err != nil {
+			return ts, err
+		}
+		ts = append(ts, token{typ: typeStr, str: str})
+	}
+	return ts, nil
+}
+
+func TestReaderMatchesStream(t *testing.T) {
+	for name, data := range corpus(t) {
+		s, err := New(data)
+		if err != nil {
+			t.Errorf("%s: New: %v", name, err)
+			continue
+		}
+		want, err := drain(s)
+		if err != nil {
+			t.Errorf("%s: reading Stream: %v", name, err)
+			continue
+		}
+		got, err := drain(NewReader(bytes.NewReader(data)))
+		if err != nil {
+			t.Errorf("%s: reading Reader: %v", name, err)
+			continue
+		}
+
+		if len(got) != len(want) {
+			t.Errorf("%s: Reader returned %d tokens, Stream returned %d", name, len(got), len(want))
+			continue
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("%s: token %d: Reader returned %+v, Stream returned %+v", name, i, got[i], want[i])
+				break
+			}
+		}
+	}
+}
+
+func TestErrorPosition(t *testing.T) {
+	for _, tc := range []struct {
+		data string
+		want string
+	}{
+		{"1 2\n 3x \n", "2:3"},
+		{"1 2\n\"abc\n", "2:1"},
+		{"1 -x", "1:4"},
+	} {
+		_, err := drain(NewReader(bytes.NewReader([]byte(tc.data))))
+		se, ok := err.(*Error)
+		if !ok {
+			t.Errorf("Reader(%q): got error %v, want *Error", tc.data, err)
+			continue
+		}
+		if got := se.Pos.String(); got != tc.want {
+			t.Errorf("Reader(%q): got error at %s, want %s", tc.data, got, tc.want)
+		}
+
+		_, err = New([]byte(tc.data))
+		if se, ok := err.(*Error); !ok || se.Pos.String() != tc.want {
+			t.Errorf("New(%q): got error %v, want one at %s", tc.data, err, tc.want)
+		}
+	}
+}
+
+// oldToken is a token as the tokenizer that Stream replaced made them, with
+// the value boxed in an interface.
+type oldToken struct {
+	typ   typ
+	value interface{}
+	pos   Pos
+}
+
+// The states of the old tokenizer, which had one for escapes.
+const (
+	oldInit = iota
+	oldSign
+	oldNum
+	oldQuote
+	oldEscape
+)
+
+// oldTokenize is the tokenizer that Stream replaced, kept to check it against
+// and to measure it by.  It builds each token in a bytes.Buffer and boxes its
+// value, which is what the typed tokens were meant to save.
+func oldTokenize(data []byte) ([]oldToken, error) {
+	var ts []oldToken
+	st := oldInit
+	b := &bytes.Buffer{}
+	pos := Pos{Line: 1, Column: 1}
+	start := pos
+
+	for _, ch := range data {
+		switch st {
+		case oldInit:
+			switch {
+			case isSpace(ch):
+			case ch == '-':
+				b.WriteByte(ch)
+				start, st = pos, oldSign
+			case isDigit(ch):
+				b.WriteByte(ch)
+				start, st = pos, oldNum
+			case ch == '"':
+				start, st = pos, oldQuote
+			default:
+				return nil, fmt.Errorf("%s: unexpected character %q", pos, ch)
+			}
+		case oldSign:
+			if !isDigit(ch) {
+				return nil, fmt.Errorf("%s: unexpected character %q", pos, ch)
+			}
+			b.WriteByte(ch)
+			st = oldNum
+		case oldNum:
+			switch {
+			case isSpace(ch):
+				val, err := strconv.Atoi(b.String())
+				if err != nil {
+					return nil, err
+				}
+				ts = append(ts, oldToken{typeInt, val, start})
+				b.Reset()
+				st = oldInit
+			case isDigit(ch):
+				b.WriteByte(ch)
+			default:
+				return nil, fmt.Errorf("%s: unexpected character %q", pos, ch)
+			}
+		case oldQuote:
+			switch {
+			case ch == '\\':
+				st = oldEscape
+			case ch == '"':
+				ts = append(ts, oldToken{typeStr, b.String(), start})
+				b.Reset()
+				st = oldInit
+			default:
+				b.WriteByte(ch)
+			}
+		case oldEscape:
+			b.WriteByte(ch)
+		}
+		pos.advance(ch)
+	}
+	if st == oldQuote || st == oldEscape {
+		return nil, fmt.Errorf("%s: unterminated string", start)
+	}
+	return ts, nil
+}
+
+func TestStreamMatchesOldTokenizer(t *testing.T) {
+	for name, data := range corpus(t) {
+		want, err := oldTokenize(data)
+		if err != nil {
+			t.Errorf("%s: oldTokenize: %v", name, err)
+			continue
+		}
+		s, err := New(data)
+		if err != nil {
+			t.Errorf("%s: New: %v", name, err)
+			continue
+		}
+
+		if len(s.tokens) != len(want) {
+			t.Errorf("%s: New returned %d tokens, the old tokenizer %d", name, len(s.tokens), len(want))
+			continue
+		}
+		for i, got := range s.tokens {
+			w := want[i]
+			same := got.typ == w.typ && got.pos == w.pos
+			if got.typ == typeInt {
+				same = same && got.num == w.value.(int)
+			} else {
+				same = same && got.str == w.value.(string)
+			}
+			if !same {
+				t.Errorf("%s: token %d: New returned %+v, the old tokenizer %+v", name, i, got, w)
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkOldTokenize(b *testing.B) {
+	files := corpus(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, data := range files {
+			ts, err := oldTokenize(data)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for _, t := range ts {
+				_ = t
+			}
+		}
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	files := corpus(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, data := range files {
+			s, err := New(data)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for _, t := range s.tokens {
+				_ = t
+			}
+		}
+	}
+}
+
+func BenchmarkNewReader(b *testing.B) {
+	files := corpus(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, data := range files {
+			r := NewReader(bytes.NewReader(data))
+			for r.peek() == nil {
+				r.ok = false
+			}
+			if r.err != io.EOF {
+				b.Fatal(r.err)
+			}
+		}
+	}
+}
+
+func TestFinalIntegerWithoutNewline(t *testing.T) {
+	s, err := New([]byte("1 2 3"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	got, err := drain(s)
+	if err != nil || len(got) != 3 || got[2].num != 3 {
+		t.Errorf("got tokens %+v (error %v), want 1, 2, 3", got, err)
+	}
+}
+
+func TestLenient(t *testing.T) {
+	for _, tc := range []struct {
+		data     string
+		want     []token
+		warnings int
+	}{
+		{"1 2x3 \"a\"\n", []token{{typ: typeInt, num: 1}, {typ: typeInt, num: 23}, {typ: typeStr, str: "a"}}, 1},
+		{"1 ? -\"b\"\n", []token{{typ: typeInt, num: 1}, {typ: typeStr, str: "b"}}, 2},
+		{"1\n\"cut off", []token{{typ: typeInt, num: 1}, {typ: typeStr, str: "cut off"}}, 1},
+		{"1 2", []token{{typ: typeInt, num: 1}, {typ: typeInt, num: 2}}, 1},
+	} {
+		s, warnings := NewLenient([]byte(tc.data))
+		got, err := drain(s)
+		if err != nil {
+			t.Errorf("NewLenient(%q): %v", tc.data, err)
+			continue
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("NewLenient(%q): got tokens %+v, want %+v", tc.data, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("NewLenient(%q): got tokens %+v, want %+v", tc.data, got, tc.want)
+				break
+			}
+		}
+		if len(warnings) != tc.warnings {
+			t.Errorf("NewLenient(%q): got warnings %v, want %d of them", tc.data, warnings, tc.warnings)
+		}
+	}
+}
+
+func TestQuoting(t *testing.T) {
+	for _, tc := range []struct {
+		data string
+		want string
+	}{
+		{`"plain"`, `plain`},
+		{`"say ""hi"""`, `say "hi"`},
+		{`""""`, `"`},
+		{`"back\slash"`, `back\slash`},
+		{`"\"`, `\`},
+		{"\"two\nlines\"", "two\nlines"},
+	} {
+		s, err := New([]byte(tc.data + "\n"))
+		if err != nil {
+			t.Errorf("New(%q): %v", tc.data, err)
+			continue
+		}
+		got, err := s.NextString()
+		if err != nil || got != tc.want || !s.Done() {
+			t.Errorf("New(%q): got %q (error %v), want %q", tc.data, got, err, tc.want)
+		}
+	}
+}
+
+func TestToUTF8(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		cs   Charset
+		want string
+	}{
+		{"caf\xe9", Raw, "caf\xe9"},
+		{"caf\xe9", Latin1, "café"},
+		{"caf\x82", CP437, "café"},
+		{"\xb0\xff", CP437, "░\u00a0"},
+		{"plain", CP437, "plain"},
+	} {
+		if got := ToUTF8(tc.s, tc.cs); got != tc.want {
+			t.Errorf("ToUTF8(%q, %d) = %q, want %q", tc.s, tc.cs, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package term
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/autosave"
+	"github.com/chaosotter/golang-adventures/internal/scott/catalog"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+)
+
+// Play runs a session until the game ends, returning an error if the player's
+// input can't be read.
+func (t *Terminal) Play(s *session.Session) error {
+	over := false
+	s.Run(func(g *game.Game) {
+		g.Start()
+		t.Render(g.Events())
+		over = g.IsOver()
+	})
+	for !over {
+		line, err := t.Input("Tell me what to do ? ")
+		if err != nil {
+			return err
+		}
+		s.Run(func(g *game.Game) {
+			g.Command(line)
+			t.Render(g.Events())
+			over = g.IsOver()
+		})
+	}
+	return nil
+}
+
+// PlayAutosaved is like Play, but keeps an autosave of the game for the given
+// player in |store|, saving it every |interval| and when play stops.  If there
+// is already an autosave, the player is offered the chance to resume it.  The
+// player is turned away if they are already playing the same game elsewhere.
+func (t *Terminal) PlayAutosaved(s *session.Session, store *autosave.Store, player string, interval time.Duration) error {
+	id := autosave.GameID(s.DB)
+	a, err := store.Start(id, player, interval, s.Run)
+	if err == autosave.ErrInUse {
+		t.Print("You are already playing this game elsewhere.  Finish that game first.\n")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := a.Stop(); err != nil {
+			log.Print(err)
+		}
+	}()
+
+	if err := t.Resume(s.Run, store, id, player); err != nil {
+		return err
+	}
+	return t.Play(s)
+}
+
+// Resume offers to restore the player's autosave, if there is one, into the
+// game reached through |run| (see autosave.Store.Start).  An error is returned
+// only if the player's input can't be read.
+func (t *Terminal) Resume(run func(f func(g *game.Game)), store *autosave.Store, id, player string) error {
+	st, saved, err := store.Load(id, player)
+	if err != nil {
+		t.Printf("Your autosave can't be read: %v\n", err)
+		return nil
+	}
+	if st == nil {
+		return nil
+	}
+
+	for {
+		line, err := t.Input("Resume the game autosaved " + saved.Format("Jan 2 15:04") + " (Y/N) ? ")
+		if err != nil {
+			return err
+		}
+		switch strings.ToUpper(strings.TrimSpace(line)) {
+		case "Y", "YES":
+			run(func(g *game.Game) {
+				err = g.RestoreState(st)
+			})
+			if err != nil {
+				t.Printf("Your autosave can't be restored: %v\n", err)
+			}
+			return nil
+		case "N", "NO":
+			return nil
+		}
+	}
+}
+
+// AskPlayer asks for the player's name and a password, until they give both,
+// and returns the key for their autosaves (see autosave.PlayerKey).  There is
+// no account to check the password against: a player who gets it wrong just
+// finds no autosave.
+func (t *Terminal) AskPlayer() (string, error) {
+	var name, password string
+	for name == "" {
+		line, err := t.Input("What is your name ? ")
+		if err != nil {
+			return "", err
+		}
+		name = strings.TrimSpace(line)
+	}
+	t.Print("Choose a password to keep your autosaves to yourself, and give the same one next time.\n")
+	for password == "" {
+		line, err := t.InputSecret("Password ? ")
+		if err != nil {
+			return "", err
+		}
+		password = line
+	}
+	return autosave.PlayerKey(name, password), nil
+}
+
+// ChooseGame lists the games and asks the player to pick one by number or
+// file name, returning "" if the player gives up.  Games that can't be read
+// aren't offered.
+func (t *Terminal) ChooseGame(entries []*catalog.Entry) (string, error) {
+	var names []string
+	for _, e := range entries {
+		if e.Error == "" {
+			names = append(names, e.File)
+		}
+	}
+	if len(names) == 0 {
+		t.Print("There are no games to play.\n")
+		return "", nil
+	}
+
+	t.Print("The following games are available:\n\n")
+	for i, name := range names {
+		e := catalog.Find(entries, name)
+		t.Printf("%3d. %-40s %s\n", i+1, e.Title, strings.TrimSuffix(name, catalog.Extension))
+	}
+	for {
+		line, err := t.Input("Which game would you like to play (or Q to quit) ? ")
+		if err != nil {
+			return "", err
+		}
+		line = strings.TrimSpace(line)
+		if strings.EqualFold(line, "q") {
+			return "", nil
+		}
+		if i, err := strconv.Atoi(line); err == nil && i >= 1 && i <= len(names) {
+			return names[i-1], nil
+		}
+		for _, name := range names {
+			if strings.EqualFold(line, name) || strings.EqualFold(line+catalog.Extension, name) {
+				return name, nil
+			}
+		}
+		t.Printf("There is no game %q.\n", line)
+	}
+}
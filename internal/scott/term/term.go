@@ -0,0 +1,145 @@
+// Package term renders the output of the engine for a plain text terminal,
+// such as a telnet or SSH client, word-wrapping it to the width of the
+// terminal.
+package term
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+	"github.com/chaosotter/golang-adventures/internal/scott/stream"
+)
+
+// A Terminal renders events to an output stream.
+type Terminal struct {
+	Out     io.Writer      // where the output goes
+	Width   func() int     // the width to wrap to, or nil for no wrapping
+	Charset stream.Charset // the character set of the game text
+
+	// ReadLine shows the prompt and reads a line of input from the player.
+	ReadLine func(prompt string) (string, error)
+
+	// ReadSecret is like ReadLine, but doesn't echo the input, for reading
+	// passwords; it still ends the line once the input is read.  If it's
+	// nil, ReadLine is used.
+	ReadSecret func(prompt string) (string, error)
+
+	// Save is called for a SaveEvent.  If it's nil, the player is told that
+	// saving isn't supported.
+	Save func()
+
+	col    int    // the current column
+	spaces int    // spaces waiting to be written before the next word
+	word   []byte // the word being written
+}
+
+// New initializes a new Terminal writing to the given output and reading
+// with the given function.
+func New(out io.Writer, width func() int, readLine func(prompt string) (string, error)) *Terminal {
+	return &Terminal{Out: out, Width: width, ReadLine: readLine}
+}
+
+// Render prints out a sequence of events.
+func (t *Terminal) Render(evs []*game.Event) {
+	for _, ev := range evs {
+		switch ev.Type {
+		case game.TextEvent:
+			t.Print(ev.Text)
+		case game.LookEvent:
+			t.Print("\n")
+			t.Look(ev.Look)
+		case game.ClearScreenEvent:
+			t.Print("\n\n")
+		case game.DelayEvent:
+			time.Sleep(2 * time.Second)
+		case game.SaveEvent:
+			if t.Save != nil {
+				t.Save()
+			} else {
+				t.Print("Saving isn't supported here.\n")
+			}
+		}
+	}
+}
+
+// Look prints out a room description.
+func (t *Terminal) Look(ld *game.LookData) {
+	t.Print(ld.String() + "\n")
+}
+
+// Input starts a new line and reads a line of input from the player with the
+// given prompt.  The input is assumed to end with a newline.
+func (t *Terminal) Input(prompt string) (string, error) {
+	t.Print("\n")
+	line, err := t.ReadLine(prompt)
+	t.col, t.spaces = 0, 0
+	return line, err
+}
+
+// InputSecret is like Input, but reads the line with ReadSecret.
+func (t *Terminal) InputSecret(prompt string) (string, error) {
+	if t.ReadSecret == nil {
+		return t.Input(prompt)
+	}
+	t.Print("\n")
+	line, err := t.ReadSecret(prompt)
+	t.col, t.spaces = 0, 0
+	return line, err
+}
+
+// Printf prints formatted text, word-wrapped.
+func (t *Terminal) Printf(format string, args ...interface{}) {
+	t.Print(fmt.Sprintf(format, args...))
+}
+
+// Print prints some text, word-wrapped.  Words are never broken, even if they're
+// too long for a line of their own, and spaces at the end of a line are
+// dropped.
+func (t *Terminal) Print(s string) {
+	s = stream.ToUTF8(s, t.Charset)
+	var out []byte
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; ch {
+		case '\n':
+			out = t.flush(out)
+			out = append(out, '\n')
+			t.col, t.spaces = 0, 0
+		case ' ':
+			out = t.flush(out)
+			t.spaces++
+		default:
+			t.word = append(t.word, ch)
+		}
+	}
+	out = t.flush(out)
+	t.Out.Write(out)
+}
+
+// flush appends the word being written to |out|, starting a new line first
+// if it doesn't fit on this one.
+func (t *Terminal) flush(out []byte) []byte {
+	if len(t.word) == 0 {
+		return out
+	}
+	width := 0
+	if t.Width != nil {
+		// Keep clear of the last column, which makes some terminals wrap.
+		width = t.Width() - 1
+	}
+
+	n := len([]rune(string(t.word)))
+	if width > 0 && t.col > 0 && t.col+t.spaces+n > width {
+		out = append(out, '\n')
+		t.col, t.spaces = 0, 0
+	}
+	for ; t.spaces > 0; t.spaces-- {
+		out = append(out, ' ')
+		t.col++
+	}
+	out = append(out, t.word...)
+	t.col += n
+	t.word = t.word[0:0]
+	return out
+}
@@ -0,0 +1,494 @@
+// Package validator performs static checks on a Scott Adams adventure game
+// beyond what the parser is able to verify.
+//
+// The parser accepts any file that is numerically well-formed, so it is quite
+// possible for a game to contain exits into nonexistent rooms, actions that
+// print messages that don't exist, and so on.  Validate reports these problems
+// as a list of diagnostics rather than failing outright, since many of the
+// classic game files contain a few harmless oddities.
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// Severity indicates how serious a diagnostic is.
+type Severity int
+
+const (
+	Error   = Severity(iota) // the game will misbehave at runtime
+	Warning                  // the game is suspicious but will probably work
+)
+
+// String returns a human-readable name for the severity.
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// Diagnostic describes a single problem found in a game.
+type Diagnostic struct {
+	Severity Severity // how serious the problem is
+	Section  string   // the section of the game file ("action", "room", ...)
+	Index    int      // the index within the section, or -1 if not applicable
+	Message  string   // a description of the problem
+}
+
+// String formats the diagnostic for display.
+func (d Diagnostic) String() string {
+	if d.Index < 0 {
+		return fmt.Sprintf("%s: %s: %s", d.Severity, d.Section, d.Message)
+	}
+	return fmt.Sprintf("%s: %s %d: %s", d.Severity, d.Section, d.Index, d.Message)
+}
+
+// Validate runs all of the static checks against the given game and returns
+// the problems found, in the order the sections appear in the game file.
+func Validate(pb *scottpb.Game) []Diagnostic {
+	v := &validator{pb: pb}
+	if !v.checkHeader() {
+		return v.diags
+	}
+	v.checkActions()
+	v.checkRooms()
+	v.checkItems()
+	v.checkReachability()
+	v.checkMessageUsage()
+	v.checkWordUsage()
+	v.checkTreasures()
+	return v.diags
+}
+
+// HasErrors checks if any of the given diagnostics has Error severity.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// validator holds the game under inspection and the diagnostics found so far.
+type validator struct {
+	pb    *scottpb.Game
+	diags []Diagnostic
+}
+
+// errorf records a diagnostic with Error severity.
+func (v *validator) errorf(section string, index int, format string, args ...interface{}) {
+	v.diags = append(v.diags, Diagnostic{Error, section, index, fmt.Sprintf(format, args...)})
+}
+
+// warnf records a diagnostic with Warning severity.
+func (v *validator) warnf(section string, index int, format string, args ...interface{}) {
+	v.diags = append(v.diags, Diagnostic{Warning, section, index, fmt.Sprintf(format, args...)})
+}
+
+// checkHeader verifies that the header agrees with the rest of the game data.
+// If it does not, none of the other checks can be trusted, so we return false
+// to stop early.
+func (v *validator) checkHeader() bool {
+	h := v.pb.Header
+	if h == nil {
+		v.errorf("header", -1, "missing header")
+		return false
+	}
+
+	ok := true
+	for _, c := range []struct {
+		name string
+		want int32
+		got  int
+	}{
+		{"items", h.NumItems, len(v.pb.Items)},
+		{"actions", h.NumActions, len(v.pb.Actions)},
+		{"verbs", h.NumWords, len(v.pb.Verbs)},
+		{"nouns", h.NumWords, len(v.pb.Nouns)},
+		{"rooms", h.NumRooms, len(v.pb.Rooms)},
+		{"messages", h.NumMessages, len(v.pb.Messages)},
+	} {
+		if int(c.want) != c.got {
+			v.errorf("header", -1, "header declares %d %s but %d are present", c.want, c.name, c.got)
+			ok = false
+		}
+	}
+	if !ok {
+		return false
+	}
+
+	if !v.isRoom(h.StartingRoom) {
+		v.errorf("header", -1, "starting room %d does not exist", h.StartingRoom)
+	}
+	if !v.isRoom(h.TreasureRoom) {
+		v.errorf("header", -1, "treasure room %d does not exist", h.TreasureRoom)
+	}
+	if h.WordLength < 1 {
+		v.errorf("header", -1, "word length %d is not positive", h.WordLength)
+	}
+	if h.NumItems <= game.LightItem {
+		v.warnf("header", -1, "only %d items, so there is no light source (item %d)", h.NumItems, game.LightItem)
+	}
+	if h.NumWords <= 6 {
+		v.warnf("header", -1, "only %d nouns, so the six directions are not all defined", h.NumWords)
+	}
+	return true
+}
+
+// checkActions verifies the words, conditions and commands of every action.
+func (v *validator) checkActions() {
+	for i, a := range v.pb.Actions {
+		if len(a.Conditions) != game.NumConditions || len(a.Actions) != game.NumCommands {
+			v.errorf("action", i, "has %d conditions and %d commands", len(a.Conditions), len(a.Actions))
+			continue
+		}
+
+		if !v.isWord(a.VerbIndex) {
+			v.errorf("action", i, "verb %d does not exist", a.VerbIndex)
+		}
+		// For automatic actions (verb 0), the noun is a percentage chance.
+		if a.VerbIndex != game.AutoVerb && !v.isWord(a.NounIndex) {
+			v.errorf("action", i, "noun %d does not exist", a.NounIndex)
+		}
+		if a.VerbIndex == game.AutoVerb && a.NounIndex > 100 {
+			v.warnf("action", i, "automatic action has a %d%% chance", a.NounIndex)
+		}
+
+		for j, c := range a.Conditions {
+			v.checkCondition(i, j, c)
+		}
+
+		params := game.Params(a)
+		for j, t := range a.Actions {
+			v.checkCommand(i, j, t, &params)
+		}
+	}
+}
+
+// checkCondition verifies the value of a single condition.
+func (v *validator) checkCondition(i, j int, c *scottpb.Condition) {
+	switch c.Type {
+	case scottpb.ConditionType_PARAMETER,
+		scottpb.ConditionType_INVENTORY_NOT_EMPTY,
+		scottpb.ConditionType_INVENTORY_EMPTY,
+		scottpb.ConditionType_COUNTER_LE,
+		scottpb.ConditionType_COUNTER_GE,
+		scottpb.ConditionType_COUNTER_EQ:
+		// any value is acceptable
+
+	case scottpb.ConditionType_ITEM_CARRIED,
+		scottpb.ConditionType_ITEM_IN_ROOM,
+		scottpb.ConditionType_ITEM_PRESENT,
+		scottpb.ConditionType_ITEM_NOT_IN_ROOM,
+		scottpb.ConditionType_ITEM_NOT_CARRIED,
+		scottpb.ConditionType_ITEM_NOT_PRESENT,
+		scottpb.ConditionType_ITEM_IN_GAME,
+		scottpb.ConditionType_ITEM_NOT_IN_GAME,
+		scottpb.ConditionType_ITEM_MOVED,
+		scottpb.ConditionType_ITEM_NOT_MOVED:
+		if !v.isItem(c.Value) {
+			v.errorf("action", i, "condition %d (%s) refers to item %d, which does not exist", j, c.Type, c.Value)
+		}
+
+	case scottpb.ConditionType_PLAYER_IN_ROOM,
+		scottpb.ConditionType_PLAYER_NOT_IN_ROOM:
+		if !v.isRoom(c.Value) {
+			v.errorf("action", i, "condition %d (%s) refers to room %d, which does not exist", j, c.Type, c.Value)
+		}
+
+	case scottpb.ConditionType_BIT_SET,
+		scottpb.ConditionType_BIT_CLEAR:
+		if !isFlag(c.Value) {
+			v.errorf("action", i, "condition %d (%s) refers to flag %d, which does not exist", j, c.Type, c.Value)
+		}
+
+	default:
+		v.errorf("action", i, "condition %d has unknown type %d", j, c.Type)
+	}
+}
+
+// checkCommand verifies a single command, consuming its parameters from the
+// front of |params|.
+func (v *validator) checkCommand(i, j int, t scottpb.ActionType, params *[]int32) {
+	if m, ok := game.MessageIndex(t); ok {
+		if m >= len(v.pb.Messages) {
+			v.errorf("action", i, "command %d prints message %d, which does not exist", j, m)
+		}
+		return
+	}
+	if _, ok := scottpb.ActionType_name[int32(t)]; !ok {
+		v.errorf("action", i, "command %d has unknown type %d", j, t)
+		return
+	}
+
+	n := game.NumParams(t)
+	if len(*params) < n {
+		v.errorf("action", i, "command %d (%s) needs %d parameters but only %d remain", j, t, n, len(*params))
+		*params = nil
+		return
+	}
+	ps := (*params)[0:n]
+	*params = (*params)[n:]
+
+	switch t {
+	case scottpb.ActionType_GET_ITEM,
+		scottpb.ActionType_DROP_ITEM,
+		scottpb.ActionType_REMOVE_ITEM,
+		scottpb.ActionType_REMOVE_ITEM2,
+		scottpb.ActionType_TAKE_ITEM:
+		v.checkItemParam(i, j, t, ps[0])
+
+	case scottpb.ActionType_SWAP_ITEMS,
+		scottpb.ActionType_MOVE_ITEM_TO_ITEM:
+		v.checkItemParam(i, j, t, ps[0])
+		v.checkItemParam(i, j, t, ps[1])
+
+	case scottpb.ActionType_PUT_ITEM:
+		v.checkItemParam(i, j, t, ps[0])
+		v.checkRoomParam(i, j, t, ps[1])
+
+	case scottpb.ActionType_MOVE_PLAYER:
+		v.checkRoomParam(i, j, t, ps[0])
+
+	case scottpb.ActionType_SET_BIT,
+		scottpb.ActionType_CLEAR_BIT:
+		if !isFlag(ps[0]) {
+			v.errorf("action", i, "command %d (%s) refers to flag %d, which does not exist", j, t, ps[0])
+		}
+
+	case scottpb.ActionType_SELECT_COUNTER,
+		scottpb.ActionType_SWAP_LOCATION_N:
+		if ps[0] < 0 || ps[0] >= game.NumCounters {
+			v.errorf("action", i, "command %d (%s) refers to register %d, which does not exist", j, t, ps[0])
+		}
+	}
+}
+
+// checkItemParam verifies that a command parameter names an item.
+func (v *validator) checkItemParam(i, j int, t scottpb.ActionType, p int32) {
+	if !v.isItem(p) {
+		v.errorf("action", i, "command %d (%s) refers to item %d, which does not exist", j, t, p)
+	}
+}
+
+// checkRoomParam verifies that a command parameter names a room.
+func (v *validator) checkRoomParam(i, j int, t scottpb.ActionType, p int32) {
+	if !v.isRoom(p) {
+		v.errorf("action", i, "command %d (%s) refers to room %d, which does not exist", j, t, p)
+	}
+}
+
+// checkRooms verifies that every exit leads to a real room.
+func (v *validator) checkRooms() {
+	for i, r := range v.pb.Rooms {
+		if len(r.Exits) != 6 {
+			v.errorf("room", i, "has %d exits instead of 6", len(r.Exits))
+			continue
+		}
+		for j, dest := range r.Exits {
+			if dest != 0 && !v.isRoom(dest) {
+				v.errorf("room", i, "exit %s leads to room %d, which does not exist", directions[j], dest)
+			}
+		}
+	}
+}
+
+// checkItems verifies that every item starts in a real location.
+func (v *validator) checkItems() {
+	for i, it := range v.pb.Items {
+		if it.Location != game.Inventory && it.Location != game.Inventory255 && !v.isRoom(it.Location) {
+			v.errorf("item", i, "starts in room %d, which does not exist", it.Location)
+		}
+	}
+}
+
+// checkReachability looks for rooms that the player can never get to, either
+// by walking or by being moved by an action.  Room 0 is the "nowhere" room and
+// is never reachable, so we skip it, along with any placeholders.
+func (v *validator) checkReachability() {
+	reached := make([]bool, len(v.pb.Rooms))
+	var queue []int32
+	visit := func(r int32) {
+		if v.isRoom(r) && !reached[r] {
+			reached[r] = true
+			queue = append(queue, r)
+		}
+	}
+
+	// Actions can move the player to a room from anywhere, so we treat their
+	// destinations as roots.  This is generous, but errs on the side of fewer
+	// false alarms.
+	visit(v.pb.Header.StartingRoom)
+	for _, a := range v.pb.Actions {
+		params := game.Params(a)
+		for _, t := range a.Actions {
+			n := game.NumParams(t)
+			if len(params) < n {
+				break
+			}
+			switch t {
+			case scottpb.ActionType_MOVE_PLAYER:
+				visit(params[0])
+			case scottpb.ActionType_DEATH:
+				visit(v.pb.Header.NumRooms - 1)
+			}
+			params = params[n:]
+		}
+	}
+
+	for len(queue) > 0 {
+		r := queue[0]
+		queue = queue[1:]
+		if len(v.pb.Rooms[r].Exits) != 6 {
+			continue
+		}
+		for _, dest := range v.pb.Rooms[r].Exits {
+			visit(dest)
+		}
+	}
+
+	for i := 1; i < len(reached); i++ {
+		if !reached[i] && !isPlaceholder(v.pb.Rooms[i].Description) {
+			v.warnf("room", i, "is unreachable (%q)", v.pb.Rooms[i].Description)
+		}
+	}
+}
+
+// checkMessageUsage looks for messages that no action ever prints.  Message 0
+// can never be printed, and is conventionally empty, so we skip it, along with
+// any placeholders.
+func (v *validator) checkMessageUsage() {
+	used := make([]bool, len(v.pb.Messages))
+	for _, a := range v.pb.Actions {
+		for _, t := range a.Actions {
+			if m, ok := game.MessageIndex(t); ok && m < len(used) {
+				used[m] = true
+			}
+		}
+	}
+
+	for i := 1; i < len(used); i++ {
+		if !used[i] && !isPlaceholder(v.pb.Messages[i]) {
+			v.warnf("message", i, "is never printed (%q)", v.pb.Messages[i])
+		}
+	}
+}
+
+// checkWordUsage looks for verbs and nouns that no action refers to.  Synonyms
+// are considered used if the word they are a synonym for is used.  Some words
+// are handled by the interpreter itself and are always considered used.
+func (v *validator) checkWordUsage() {
+	verbs := make([]bool, len(v.pb.Verbs))
+	nouns := make([]bool, len(v.pb.Nouns))
+	for _, w := range []int{game.AutoVerb, game.GoVerb, game.GetVerb, game.DropVerb} {
+		if w < len(verbs) {
+			verbs[w] = true
+		}
+	}
+	for w := 0; w <= 6 && w < len(nouns); w++ { // noun 0 and the six directions
+		nouns[w] = true
+	}
+
+	for _, a := range v.pb.Actions {
+		if v.isWord(a.VerbIndex) {
+			verbs[a.VerbIndex] = true
+		}
+		if a.VerbIndex != game.AutoVerb && v.isWord(a.NounIndex) {
+			nouns[a.NounIndex] = true
+		}
+	}
+
+	v.reportUnusedWords("verb", v.pb.Verbs, verbs, nil)
+	v.reportUnusedWords("noun", v.pb.Nouns, nouns, v.autograbs())
+}
+
+// autograbs returns the set of words used for automatic GET and DROP.
+func (v *validator) autograbs() map[string]bool {
+	m := map[string]bool{}
+	for _, it := range v.pb.Items {
+		if it.Autograb != "" {
+			m[truncate(it.Autograb, v.pb.Header.WordLength)] = true
+		}
+	}
+	return m
+}
+
+// reportUnusedWords warns about every word in |ws| that is not marked in
+// |used| (or, for synonyms, whose base word is not marked) and is not in
+// |extra|.
+func (v *validator) reportUnusedWords(section string, ws []*scottpb.Word, used []bool, extra map[string]bool) {
+	base := 0
+	for i, w := range ws {
+		if !w.Synonym {
+			base = i
+		}
+		if isPlaceholder(w.Word) || used[base] || extra[truncate(w.Word, v.pb.Header.WordLength)] {
+			continue
+		}
+		if !w.Synonym {
+			v.warnf(section, i, "is never used (%q)", w.Word)
+		}
+	}
+}
+
+// checkTreasures verifies that the number of treasures in the header matches
+// the number of items marked as treasures.
+func (v *validator) checkTreasures() {
+	n := 0
+	for _, it := range v.pb.Items {
+		if it.IsTreasure {
+			n++
+		}
+	}
+	if int32(n) != v.pb.Header.NumTreasures {
+		v.warnf("header", -1, "header declares %d treasures but %d items are marked as treasures", v.pb.Header.NumTreasures, n)
+	}
+}
+
+// directions gives the names of the six exits, in order.
+var directions = []string{"north", "south", "east", "west", "up", "down"}
+
+// isRoom checks if |r| is a valid room index.
+func (v *validator) isRoom(r int32) bool {
+	return r >= 0 && int(r) < len(v.pb.Rooms)
+}
+
+// isItem checks if |i| is a valid item index.
+func (v *validator) isItem(i int32) bool {
+	return i >= 0 && int(i) < len(v.pb.Items)
+}
+
+// isWord checks if |w| is a valid word index.
+func (v *validator) isWord(w int32) bool {
+	return w >= 0 && int(w) < len(v.pb.Verbs) && int(w) < len(v.pb.Nouns)
+}
+
+// isFlag checks if |f| is a valid flag number.
+func isFlag(f int32) bool {
+	return f >= 0 && f < game.NumFlags
+}
+
+// isPlaceholder checks if a string is one of the blank or "." fillers that the
+// game files use for unused slots.
+func isPlaceholder(s string) bool {
+	s = strings.TrimSpace(s)
+	return s == "" || s == "."
+}
+
+// truncate shortens a word to the significant length for the game.
+func truncate(w string, n int32) string {
+	if n > 0 && len(w) > int(n) {
+		return w[0:n]
+	}
+	return w
+}
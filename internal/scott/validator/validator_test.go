@@ -0,0 +1,137 @@
+package validator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/parser"
+)
+
+// loadGame parses one of the bundled games.
+func loadGame(t *testing.T, name string) *scottpb.Game {
+	data, err := ioutil.ReadFile(filepath.Join("../../../games", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb, err := parser.Parse(data)
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return pb
+}
+
+// errorsIn returns the diagnostics with Error severity, formatted.
+func errorsIn(diags []Diagnostic) []string {
+	var errs []string
+	for _, d := range diags {
+		if d.Severity == Error {
+			errs = append(errs, d.String())
+		}
+	}
+	return errs
+}
+
+func TestBundledGames(t *testing.T) {
+	paths, err := filepath.Glob("../../../games/*.dat")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("Could not find the game files: %v", err)
+	}
+	// The one known fault in the bundled games: an item that starts in a
+	// room past the end of the map.
+	known := map[string]string{
+		"adv14b.dat": "error: item 50: starts in room 50, which does not exist",
+	}
+
+	for _, path := range paths {
+		name := filepath.Base(path)
+		diags := Validate(loadGame(t, name))
+		got := strings.Join(errorsIn(diags), "; ")
+		if got != known[name] {
+			t.Errorf("%s: got errors %q, want %q", name, got, known[name])
+		}
+		if HasErrors(diags) != (known[name] != "") {
+			t.Errorf("%s: HasErrors is %v", name, HasErrors(diags))
+		}
+	}
+}
+
+func TestValidateFindsProblems(t *testing.T) {
+	base := loadGame(t, "adv01.dat")
+	for _, tc := range []struct {
+		name   string
+		damage func(pb *scottpb.Game)
+		want   string
+	}{
+		{
+			"header count",
+			func(pb *scottpb.Game) { pb.Header.NumRooms++ },
+			"error: header: header declares 35 rooms but 34 are present",
+		},
+		{
+			"bad exit",
+			func(pb *scottpb.Game) { pb.Rooms[1].Exits[2] = 99 },
+			"error: room 1: exit east leads to room 99, which does not exist",
+		},
+		{
+			"bad item location",
+			func(pb *scottpb.Game) { pb.Items[3].Location = 40 },
+			"error: item 3: starts in room 40, which does not exist",
+		},
+		{
+			"bad condition",
+			func(pb *scottpb.Game) {
+				pb.Actions[0].Conditions[0] = &scottpb.Condition{Type: scottpb.ConditionType_ITEM_CARRIED, Value: 500}
+			},
+			"error: action 0: condition 0 (ITEM_CARRIED) refers to item 500, which does not exist",
+		},
+		{
+			"bad message",
+			func(pb *scottpb.Game) { pb.Actions[0].Actions[0] = scottpb.ActionType(150) },
+			"error: action 0: command 0 prints message 100, which does not exist",
+		},
+		{
+			"bad flag",
+			func(pb *scottpb.Game) {
+				pb.Actions[0].Conditions[0] = &scottpb.Condition{Type: scottpb.ConditionType_BIT_SET, Value: 32}
+			},
+			"error: action 0: condition 0 (BIT_SET) refers to flag 32, which does not exist",
+		},
+	} {
+		pb := proto.Clone(base).(*scottpb.Game)
+		tc.damage(pb)
+		errs := errorsIn(Validate(pb))
+		found := false
+		for _, e := range errs {
+			found = found || e == tc.want
+		}
+		if !found {
+			t.Errorf("%s: got errors %q, want one of them to be %q", tc.name, errs, tc.want)
+		}
+	}
+}
+
+func TestValidateWarnings(t *testing.T) {
+	pb := proto.Clone(loadGame(t, "adv01.dat")).(*scottpb.Game)
+	pb.Header.NumTreasures++
+
+	var warnings []string
+	for _, d := range Validate(pb) {
+		if d.Severity == Warning {
+			warnings = append(warnings, d.String())
+		}
+	}
+	all := strings.Join(warnings, "\n")
+	for _, want := range []string{
+		"warning: header: header declares 14 treasures but 13 items are marked as treasures",
+		"warning: noun 24: is never used (\"ROC\")",
+	} {
+		if !strings.Contains(all, want) {
+			t.Errorf("Warnings don't include %q; got:\n%s", want, all)
+		}
+	}
+}
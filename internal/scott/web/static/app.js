@@ -0,0 +1,129 @@
+// A browser client for Scott Adams adventures, talking to the server over a
+// WebSocket.  See the web package for the protocol.
+(function() {
+  "use strict";
+
+  const games = document.getElementById("games");
+  const start = document.getElementById("start");
+  const room = document.getElementById("room");
+  const log = document.getElementById("log");
+  const prompt = document.getElementById("prompt");
+  const input = document.getElementById("input");
+
+  let ws = null;        // the connection to the server
+  let queue = [];       // events waiting to be rendered
+  let waiting = false;  // set while pausing for a delay event
+  let over = false;     // set once the game has ended
+
+  // print adds some text to the scrolling part of the screen.
+  function print(text, cls) {
+    const span = document.createElement("span");
+    span.textContent = text;
+    if (cls) {
+      span.className = cls;
+    }
+    log.appendChild(span);
+    log.scrollTop = log.scrollHeight;
+  }
+
+  // look redraws the room description at the top of the screen.  The server
+  // formats it, following the options of the game.
+  function look(ld) {
+    room.textContent = ld.text;
+  }
+
+  // render works through the queued events, pausing for any delays.
+  function render() {
+    while (queue.length && !waiting) {
+      const ev = queue.shift();
+      switch (ev.type) {
+      case "text":
+        print(ev.text);
+        break;
+      case "look":
+        look(ev.look);
+        break;
+      case "clear_screen":
+        log.textContent = "";
+        break;
+      case "delay":
+        waiting = true;
+        setTimeout(function() {
+          waiting = false;
+          render();
+        }, 2000);
+        break;
+      case "save":
+        print("Saving isn't supported in the browser.\n", "error");
+        break;
+      }
+    }
+    if (!queue.length && !waiting) {
+      input.disabled = over;
+      if (!over) {
+        input.focus();
+      }
+    }
+  }
+
+  // connect starts a new game.
+  function connect() {
+    if (ws) {
+      ws.onclose = null;
+      ws.close();
+    }
+    room.textContent = "";
+    log.textContent = "";
+    queue = [];
+    over = false;
+    input.disabled = true;
+
+    const scheme = location.protocol === "https:" ? "wss:" : "ws:";
+    ws = new WebSocket(scheme + "//" + location.host + "/ws?game=" + encodeURIComponent(games.value));
+    ws.onmessage = function(e) {
+      const msg = JSON.parse(e.data);
+      if (msg.error) {
+        print(msg.error + "\n", "error");
+        return;
+      }
+      over = !!msg.game_over;
+      queue = queue.concat(msg.events || []);
+      render();
+    };
+    ws.onclose = function() {
+      over = true;
+      input.disabled = true;
+      print("\nThe connection to the server was lost.\n", "error");
+    };
+  }
+
+  prompt.addEventListener("submit", function(e) {
+    e.preventDefault();
+    if (!ws || ws.readyState !== WebSocket.OPEN || input.disabled) {
+      return;
+    }
+    const text = input.value.trim();
+    input.value = "";
+    print("\n> " + text.toUpperCase() + "\n", "command");
+    input.disabled = true;
+    ws.send(JSON.stringify({input: text}));
+  });
+
+  start.addEventListener("click", connect);
+
+  fetch("games").then(function(resp) {
+    return resp.json();
+  }).then(function(data) {
+    data.games.forEach(function(e) {
+      if (e.error) {
+        return;
+      }
+      const opt = document.createElement("option");
+      opt.value = e.file;
+      opt.textContent = e.title + (e.version ? " (v" + e.version + ")" : "");
+      games.appendChild(opt);
+    });
+  }).catch(function(err) {
+    print("Could not list the games: " + err + "\n", "error");
+  });
+})();
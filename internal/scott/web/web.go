@@ -0,0 +1,140 @@
+// Package web serves a browser client for Scott Adams adventures.  The client
+// is a single page, embedded in the binary, that talks to the server over a
+// WebSocket:
+//
+//	GET /            the client itself, along with its scripts and styles
+//	GET /games       list the games that can be played, as for the rest package
+//	GET /ws?game=... play a game; see below
+//
+// Each WebSocket connection plays a single session, which ends when the
+// connection is closed.  The client sends each command as a message of the
+// form {"input": "GET LAMP"}, and the server replies with the resulting
+// events, in the same form as the rest package: {"events": [...],
+// "game_over": false}.  The opening events are sent as soon as the connection
+// is made.  Problems are reported as {"error": "..."}.
+package web
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/catalog"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+	"github.com/chaosotter/golang-adventures/internal/scott/rest"
+	"github.com/chaosotter/golang-adventures/internal/scott/session"
+	"github.com/chaosotter/golang-adventures/internal/websocket"
+)
+
+//go:embed static
+var static embed.FS
+
+// A message is sent from the server to the client.
+type message struct {
+	Events   []*rest.Event `json:"events,omitempty"`
+	GameOver bool          `json:"game_over,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Handler serves the client and the games it plays.
+type Handler struct {
+	sessions *session.Manager
+	mux      *http.ServeMux
+}
+
+// New initializes a new Handler for the sessions held by the given manager.
+func New(m *session.Manager) *Handler {
+	h := &Handler{sessions: m, mux: http.NewServeMux()}
+
+	files, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err) // the directory is embedded above
+	}
+	h.mux.Handle("/", http.FileServer(http.FS(files)))
+	h.mux.HandleFunc("/games", h.games)
+	h.mux.HandleFunc("/ws", h.play)
+	return h
+}
+
+// ServeHTTP dispatches a request according to its path.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// games lists the games that can be played.
+func (h *Handler) games(w http.ResponseWriter, r *http.Request) {
+	games, err := h.sessions.Games()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if games == nil {
+		games = []*catalog.Entry{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"games": games})
+}
+
+// play runs a session over a WebSocket.
+func (h *Handler) play(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Upgrade(w, r)
+	if err != nil {
+		log.Printf("%s: %v", r.RemoteAddr, err)
+		return
+	}
+	defer conn.Close()
+
+	seed, _ := strconv.ParseInt(r.FormValue("seed"), 10, 64)
+	s, err := h.sessions.Create(r.FormValue("game"), seed)
+	if err != nil {
+		send(conn, &message{Error: err.Error()})
+		return
+	}
+	defer h.sessions.Close(s.ID)
+
+	var msg *message
+	s.Run(func(g *game.Game) {
+		g.Start()
+		msg = &message{Events: rest.Events(g.Events()), GameOver: g.IsOver()}
+	})
+	if err := send(conn, msg); err != nil {
+		return
+	}
+
+	for {
+		text, err := conn.ReadMessage()
+		if err != nil {
+			if err != websocket.ErrClosed {
+				log.Printf("%s: %v", r.RemoteAddr, err)
+			}
+			return
+		}
+
+		var req struct {
+			Input string `json:"input"`
+		}
+		if err := json.Unmarshal([]byte(text), &req); err != nil {
+			msg = &message{Error: "Bad message: " + err.Error()}
+		} else {
+			s.Run(func(g *game.Game) {
+				g.Command(req.Input)
+				msg = &message{Events: rest.Events(g.Events()), GameOver: g.IsOver()}
+			})
+		}
+		if err := send(conn, msg); err != nil {
+			return
+		}
+	}
+}
+
+// send sends a message to the client.
+func send(conn *websocket.Conn, msg *message) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(string(b))
+}
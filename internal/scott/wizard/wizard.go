@@ -0,0 +1,318 @@
+// Package wizard implements a console of "#" commands for poking at the state
+// of a game while testing it: moving the player and the items around, setting
+// flags and counters, and so on.  None of them take up a turn.
+//
+// Type "#help" for the list of commands.
+package wizard
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// Prefix marks a line of input as a wizard command.
+const Prefix = "#"
+
+// IsCommand checks if a line of input is a wizard command.
+func IsCommand(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), Prefix)
+}
+
+// A command is a single wizard command.
+type command struct {
+	usage string
+	help  string
+	run   func(c *Console, args []string) (string, error)
+}
+
+// commands are the wizard commands, by name.
+var commands map[string]*command
+
+func init() {
+	commands = map[string]*command{
+		"help":    {"", "list the commands", (*Console).help},
+		"look":    {"", "describe the current room", (*Console).look},
+		"room":    {"[N]", "show the current room, or go to room N", (*Console).room},
+		"rooms":   {"[TEXT]", "list the rooms, or those containing TEXT", (*Console).rooms},
+		"items":   {"[TEXT]", "list the items, or those containing TEXT", (*Console).items},
+		"item":    {"N [ROOM|here|carried]", "show item N, or move it", (*Console).item},
+		"flags":   {"", "list the flags that are set", (*Console).flags},
+		"flag":    {"N [on|off]", "show flag N, or set it", (*Console).flag},
+		"counter": {"[N] [VALUE]", "show the current counter or counter N, or set it", (*Console).counter},
+		"regs":    {"", "show the counters and the saved rooms", (*Console).regs},
+		"light":   {"[TURNS]", "show the light time, or set it", (*Console).light},
+		"state":   {"", "dump the state as a text proto", (*Console).state},
+	}
+}
+
+// A Console runs wizard commands against a game.
+type Console struct {
+	Game *game.Game
+}
+
+// Exec runs a single wizard command, returning the output, which ends with a
+// newline.
+func (c *Console) Exec(line string) string {
+	fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), Prefix))
+	if len(fields) == 0 {
+		return "Type #help for the list of wizard commands.\n"
+	}
+	name := strings.ToLower(fields[0])
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Sprintf("Unknown wizard command %q; type #help for the list.\n", name)
+	}
+
+	out, err := cmd.run(c, fields[1:])
+	if err != nil {
+		return fmt.Sprintf("%v\nUsage: #%s %s\n", err, name, cmd.usage)
+	}
+	return out
+}
+
+func (c *Console) help(args []string) (string, error) {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		cmd := commands[name]
+		fmt.Fprintf(&b, "  %-30s %s\n", "#"+name+" "+cmd.usage, cmd.help)
+	}
+	return b.String(), nil
+}
+
+func (c *Console) look(args []string) (string, error) {
+	return c.Game.Look().String(), nil
+}
+
+func (c *Console) room(args []string) (string, error) {
+	st := c.Game.State
+	if len(args) == 0 {
+		return fmt.Sprintf("Room %d: %s\n", st.Location, c.roomName(st.Location)), nil
+	}
+	n, err := c.number(args[0], 0, len(c.Game.DB.Game.Rooms)-1)
+	if err != nil {
+		return "", err
+	}
+	st.Location = int32(n)
+	return c.Game.Look().String(), nil
+}
+
+func (c *Console) rooms(args []string) (string, error) {
+	var b strings.Builder
+	for i, r := range c.Game.DB.Game.Rooms {
+		if matches(r.Description, args) {
+			fmt.Fprintf(&b, "%4d. %s\n", i, oneLine(r.Description))
+		}
+	}
+	return b.String(), nil
+}
+
+func (c *Console) items(args []string) (string, error) {
+	var b strings.Builder
+	for i, it := range c.Game.DB.Game.Items {
+		if matches(it.Description, args) || matches(it.Autograb, args) {
+			fmt.Fprintf(&b, "%4d. %-40s %s\n", i, oneLine(it.Description), c.where(c.Game.State.ItemLocations[i]))
+		}
+	}
+	return b.String(), nil
+}
+
+func (c *Console) item(args []string) (string, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return "", fmt.Errorf("Wrong number of arguments")
+	}
+	locs := c.Game.State.ItemLocations
+	n, err := c.number(args[0], 0, len(locs)-1)
+	if err != nil {
+		return "", err
+	}
+
+	if len(args) == 2 {
+		switch strings.ToLower(args[1]) {
+		case "here":
+			locs[n] = c.Game.State.Location
+		case "carried", "inventory":
+			locs[n] = game.Inventory
+		default:
+			loc, err := c.number(args[1], 0, len(c.Game.DB.Game.Rooms)-1)
+			if err != nil {
+				return "", err
+			}
+			locs[n] = int32(loc)
+		}
+	}
+	return fmt.Sprintf("Item %d: %s, %s\n", n, oneLine(c.Game.DB.Game.Items[n].Description), c.where(locs[n])), nil
+}
+
+func (c *Console) flags(args []string) (string, error) {
+	var set []string
+	for i, f := range c.Game.State.Flags {
+		if f {
+			set = append(set, strconv.Itoa(i))
+		}
+	}
+	if len(set) == 0 {
+		return "No flags are set.\n", nil
+	}
+	return "Flags set: " + strings.Join(set, " ") + "\n", nil
+}
+
+func (c *Console) flag(args []string) (string, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return "", fmt.Errorf("Wrong number of arguments")
+	}
+	flags := c.Game.State.Flags
+	n, err := c.number(args[0], 0, len(flags)-1)
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 2 {
+		switch strings.ToLower(args[1]) {
+		case "on", "1", "true", "set":
+			flags[n] = true
+		case "off", "0", "false", "clear":
+			flags[n] = false
+		default:
+			return "", fmt.Errorf("Bad flag value %q", args[1])
+		}
+	}
+	return fmt.Sprintf("Flag %d is %s.\n", n, onOff(flags[n])), nil
+}
+
+func (c *Console) counter(args []string) (string, error) {
+	st := c.Game.State
+	switch len(args) {
+	case 0:
+		return fmt.Sprintf("The current counter is %d.\n", st.Counter), nil
+	case 1:
+		v, err := c.number(args[0], -1<<31, 1<<31-1)
+		if err != nil {
+			return "", err
+		}
+		st.Counter = int32(v)
+		return fmt.Sprintf("The current counter is %d.\n", st.Counter), nil
+	case 2:
+		n, err := c.number(args[0], 0, len(st.Counters)-1)
+		if err != nil {
+			return "", err
+		}
+		v, err := c.number(args[1], -1<<31, 1<<31-1)
+		if err != nil {
+			return "", err
+		}
+		st.Counters[n] = int32(v)
+		return fmt.Sprintf("Counter %d is %d.\n", n, st.Counters[n]), nil
+	default:
+		return "", fmt.Errorf("Wrong number of arguments")
+	}
+}
+
+func (c *Console) regs(args []string) (string, error) {
+	st := c.Game.State
+	var b strings.Builder
+	fmt.Fprintf(&b, "Current counter: %d\n", st.Counter)
+	fmt.Fprintf(&b, "Saved room:      %d\n", st.SavedRoom)
+	fmt.Fprintf(&b, "Counters:   ")
+	for _, v := range st.Counters {
+		fmt.Fprintf(&b, " %d", v)
+	}
+	fmt.Fprintf(&b, "\nSaved rooms:")
+	for _, v := range st.SavedRooms {
+		fmt.Fprintf(&b, " %d", v)
+	}
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+func (c *Console) light(args []string) (string, error) {
+	st := c.Game.State
+	if len(args) > 1 {
+		return "", fmt.Errorf("Wrong number of arguments")
+	}
+	if len(args) == 1 {
+		v, err := c.number(args[0], -1, 1<<31-1)
+		if err != nil {
+			return "", err
+		}
+		st.LightTime = int32(v)
+		if v > 0 {
+			st.Flags[game.LightOutFlag] = false
+		}
+	}
+	if st.LightTime == -1 {
+		return "The light never runs out.\n", nil
+	}
+	return fmt.Sprintf("The light has %d turns left.\n", st.LightTime), nil
+}
+
+func (c *Console) state(args []string) (string, error) {
+	return prototext.MarshalOptions{Multiline: true}.Format(c.Game.SaveState()), nil
+}
+
+// number parses a number, which must be within the given range.
+func (c *Console) number(s string, min, max int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("Bad number %q", s)
+	}
+	if n < min || n > max {
+		return 0, fmt.Errorf("%d is out of range (%d to %d)", n, min, max)
+	}
+	return n, nil
+}
+
+// roomName returns the description of a room.
+func (c *Console) roomName(n int32) string {
+	rooms := c.Game.DB.Game.Rooms
+	if n < 0 || int(n) >= len(rooms) {
+		return "?"
+	}
+	return oneLine(rooms[n].Description)
+}
+
+// where describes the location of an item.
+func (c *Console) where(loc int32) string {
+	switch loc {
+	case game.Inventory:
+		return "carried"
+	case 0:
+		return "not in play"
+	default:
+		return fmt.Sprintf("in room %d (%s)", loc, c.roomName(loc))
+	}
+}
+
+// matches checks if the text contains all of the given words, ignoring case.
+func matches(text string, words []string) bool {
+	text = strings.ToLower(text)
+	for _, w := range words {
+		if !strings.Contains(text, strings.ToLower(w)) {
+			return false
+		}
+	}
+	return true
+}
+
+// oneLine squashes multi-line text onto a single line.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// onOff describes the value of a flag.
+func onOff(f bool) string {
+	if f {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,104 @@
+package wizard
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/game"
+)
+
+// newConsole makes a console for a fresh game of Adventureland.
+func newConsole(t *testing.T) *Console {
+	g, err := game.LoadFromFile("../../../games/adv01.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Console{Game: g}
+}
+
+func TestIsCommand(t *testing.T) {
+	for line, want := range map[string]bool{
+		"#room":      true,
+		"  # room 3": true,
+		"GO NORTH":   false,
+		"":           false,
+	} {
+		if got := IsCommand(line); got != want {
+			t.Errorf("IsCommand(%q) = %v, want %v", line, got, want)
+		}
+	}
+}
+
+func TestExec(t *testing.T) {
+	c := newConsole(t)
+	// Each command runs against the state left by the ones before it.
+	for _, tc := range []struct {
+		line string
+		want string
+	}{
+		{"#", "Type #help for the list of wizard commands.\n"},
+		{"#bogus", "Unknown wizard command \"bogus\"; type #help for the list.\n"},
+		{"#room", "Room 11: forest\n"},
+		{"#room 99", "99 is out of range (0 to 33)\nUsage: #room [N]\n"},
+		{"#ROOM 2", "I'm in a top of a tall cypress tree\nObvious exits: Down\n\nI can also see: Spider web with writing on it - Ring of skeleton keys\n"},
+		{"#rooms lake", "  10. I'm on the shore of a lake\n  28. top of an oak. To the East I see a meadow, beyond that a lake.\n"},
+		{"#items key", "  14. Ring of skeleton keys                    in room 2 (top of a tall cypress tree)\n"},
+		{"#item 9", "Item 9: Lit brass lamp, not in play\n"},
+		{"#item 9 here", "Item 9: Lit brass lamp, in room 2 (top of a tall cypress tree)\n"},
+		{"#item 9 carried", "Item 9: Lit brass lamp, carried\n"},
+		{"#item", "Wrong number of arguments\nUsage: #item N [ROOM|here|carried]\n"},
+		{"#flags", "No flags are set.\n"},
+		{"#flag 3 on", "Flag 3 is on.\n"},
+		{"#flag 3 maybe", "Bad flag value \"maybe\"\nUsage: #flag N [on|off]\n"},
+		{"#flags", "Flags set: 3\n"},
+		{"#counter 5", "The current counter is 5.\n"},
+		{"#counter 2 7", "Counter 2 is 7.\n"},
+		{"#regs", "Current counter: 5\nSaved room:      0\nCounters:    0 0 7 0 0 0 0 0 0 0 0 0 0 0 0 0\nSaved rooms: 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0\n"},
+		{"#light", "The light has 125 turns left.\n"},
+		{"#light x", "Bad number \"x\"\nUsage: #light [TURNS]\n"},
+		{"#light -1", "The light never runs out.\n"},
+	} {
+		if got := c.Exec(tc.line); got != tc.want {
+			t.Errorf("Exec(%q):\ngot  %q\nwant %q", tc.line, got, tc.want)
+		}
+	}
+
+	st := c.Game.State
+	if st.Location != 2 || st.ItemLocations[9] != game.Inventory || !st.Flags[3] || st.Counter != 5 || st.Counters[2] != 7 || st.LightTime != -1 {
+		t.Errorf("Commands left the state as %v", st)
+	}
+}
+
+func TestLightClearsLightOut(t *testing.T) {
+	c := newConsole(t)
+	c.Game.State.Flags[game.LightOutFlag] = true
+	c.Exec("#light 10")
+	if c.Game.State.Flags[game.LightOutFlag] {
+		t.Errorf("Setting the light time left the light out")
+	}
+}
+
+func TestHelpListsCommands(t *testing.T) {
+	help := newConsole(t).Exec("#help")
+	for name, cmd := range commands {
+		if !strings.Contains(help, "#"+name+" "+cmd.usage) {
+			t.Errorf("#help doesn't mention #%s", name)
+		}
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	c := newConsole(t)
+	c.Exec("#room 5")
+	st := &scottpb.State{}
+	if err := prototext.Unmarshal([]byte(c.Exec("#state")), st); err != nil {
+		t.Fatalf("#state doesn't give a text proto: %v", err)
+	}
+	if !proto.Equal(st, c.Game.State) {
+		t.Errorf("#state gave %v, want %v", st, c.Game.State)
+	}
+}
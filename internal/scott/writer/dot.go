@@ -0,0 +1,113 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/graph"
+)
+
+// WriteDOT writes out a map of the game in the Graphviz DOT language.  Each
+// room is a node labelled with its description and the items initially found
+// there, and each exit is an edge labelled with its direction.  One-way exits
+// are drawn in red, dark rooms are shaded, and rooms holding treasures are
+// outlined in gold.  Passages made by actions are drawn as dashed blue edges
+// labelled with the triggering command; those that work from anywhere start at
+// a separate "anywhere" node.
+func WriteDOT(out io.Writer, pb *scottpb.Game) {
+	g := graph.New(pb)
+
+	fmt.Fprintf(out, "digraph %s {\n", dotQuote(fmt.Sprintf("Adventure %d", pb.Footer.GetAdventure())))
+	fmt.Fprintf(out, "  node [shape=box, style=rounded, fontname=Helvetica, fontsize=10];\n")
+	fmt.Fprintf(out, "  edge [fontname=Helvetica, fontsize=8];\n")
+
+	for _, n := range g.Nodes {
+		writeDOTNode(out, g, n)
+	}
+	for _, e := range g.Edges {
+		writeDOTEdge(out, e)
+	}
+	for _, p := range g.Passages {
+		if p.From == graph.Anywhere {
+			fmt.Fprintf(out, "  anywhere [label=\"(anywhere)\", shape=ellipse, style=dashed];\n")
+			break
+		}
+	}
+	for _, p := range g.Passages {
+		writeDOTPassage(out, p)
+	}
+
+	fmt.Fprintf(out, "}\n")
+}
+
+// writeDOTNode writes out a single room.
+func writeDOTNode(out io.Writer, g *graph.Graph, n *graph.Node) {
+	lines := []string{fmt.Sprintf("%d: %s", n.Room, n.Description)}
+	for _, it := range n.Treasures {
+		lines = append(lines, "$ "+it)
+	}
+	for _, it := range n.Items {
+		lines = append(lines, "- "+it)
+	}
+
+	attrs := []string{"label=" + dotQuote(strings.Join(lines, "\n"))}
+	style := []string{"rounded"}
+	if n.Dark {
+		style = append(style, "filled")
+		attrs = append(attrs, "fillcolor=gray75")
+	}
+	if n.Room == g.Start {
+		style = append(style, "bold")
+	}
+	attrs = append(attrs, "style="+dotQuote(strings.Join(style, ",")))
+	if len(n.Treasures) > 0 {
+		attrs = append(attrs, "color=goldenrod")
+	}
+	if n.Room == g.Treasure {
+		attrs = append(attrs, "peripheries=2")
+	}
+
+	fmt.Fprintf(out, "  r%d [%s];\n", n.Room, strings.Join(attrs, ", "))
+}
+
+// writeDOTEdge writes out a single connection between rooms.
+func writeDOTEdge(out io.Writer, e *graph.Edge) {
+	label := strings.Join(e.Forward, ", ")
+	if len(e.Backward) > 0 {
+		label += " / " + strings.Join(e.Backward, ", ")
+	}
+
+	attrs := []string{"label=" + dotQuote(label)}
+	switch {
+	case e.OneWay():
+		attrs = append(attrs, "color=red", "fontcolor=red")
+	case e.From != e.To:
+		attrs = append(attrs, "dir=both")
+	}
+
+	fmt.Fprintf(out, "  r%d -> r%d [%s];\n", e.From, e.To, strings.Join(attrs, ", "))
+}
+
+// writeDOTPassage writes out a single connection made by an action.
+func writeDOTPassage(out io.Writer, p *graph.Passage) {
+	from := "anywhere"
+	if p.From != graph.Anywhere {
+		from = fmt.Sprintf("r%d", p.From)
+	}
+
+	label := p.Label
+	if p.Swap {
+		label += " (swap)"
+	}
+	fmt.Fprintf(out, "  %s -> r%d [label=%s, style=dashed, color=blue, fontcolor=blue];\n", from, p.To, dotQuote(label))
+}
+
+// dotQuote makes a quoted DOT string from arbitrary text.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,144 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+)
+
+// mapGame makes a small world to draw: a forest and a "cave" joined both ways,
+// with darkness falling in the cave and lifting everywhere else, a one-way exit from the cave to a treasure room with a gold bar in it,
+// and a magic word that leads back to the forest from anywhere.
+func mapGame() *scottpb.Game {
+	return &scottpb.Game{
+		Header: &scottpb.Header{
+			NumItems:     2,
+			NumRooms:     4,
+			StartingRoom: 1,
+			TreasureRoom: 3,
+		},
+		Actions: []*scottpb.Action{
+			{
+				Conditions: []*scottpb.Condition{{Type: scottpb.ConditionType_PLAYER_IN_ROOM, Value: 2}},
+				Actions:    []scottpb.ActionType{scottpb.ActionType_SET_DARKNESS},
+			},
+			{
+				Conditions: []*scottpb.Condition{{Type: scottpb.ConditionType_PLAYER_IN_ROOM, Value: 1}},
+				Actions:    []scottpb.ActionType{scottpb.ActionType_CLEAR_DARKNESS},
+			},
+			{
+				Conditions: []*scottpb.Condition{{Type: scottpb.ConditionType_PLAYER_IN_ROOM, Value: 3}},
+				Actions:    []scottpb.ActionType{scottpb.ActionType_CLEAR_DARKNESS},
+			},
+			{
+				VerbIndex:  1,
+				NounIndex:  1,
+				Conditions: []*scottpb.Condition{{Type: scottpb.ConditionType_PARAMETER, Value: 1}},
+				Actions:    []scottpb.ActionType{scottpb.ActionType_MOVE_PLAYER},
+			},
+		},
+		Verbs: []*scottpb.Word{{Word: "AUTO"}, {Word: "SAY"}},
+		Nouns: []*scottpb.Word{{Word: "ANY"}, {Word: "XYZZY"}},
+		Rooms: []*scottpb.Room{
+			{Exits: make([]int32, 6)},
+			{Description: "forest", Exits: []int32{0, 0, 2, 0, 0, 0}},
+			{Description: `"cave" & <tunnel>`, Exits: []int32{0, 3, 0, 1, 0, 0}},
+			{Description: "vault", Exits: []int32{0, 0, 0, 0, 0, 0}},
+		},
+		Items: []*scottpb.Item{
+			{Description: "Lamp", Location: 1},
+			{Description: "*Gold bar*", Location: 3, IsTreasure: true},
+		},
+		Footer: &scottpb.Footer{Adventure: 7},
+	}
+}
+
+const mapGameDOT = `digraph "Adventure 7" {
+  node [shape=box, style=rounded, fontname=Helvetica, fontsize=10];
+  edge [fontname=Helvetica, fontsize=8];
+  r1 [label="1: forest\n- Lamp", style="rounded,bold"];
+  r2 [label="2: \"cave\" & <tunnel>", fillcolor=gray75, style="rounded,filled"];
+  r3 [label="3: vault\n$ *Gold bar*", style="rounded", color=goldenrod, peripheries=2];
+  r1 -> r2 [label="East / West", dir=both];
+  r2 -> r3 [label="South", color=red, fontcolor=red];
+  anywhere [label="(anywhere)", shape=ellipse, style=dashed];
+  anywhere -> r1 [label="SAY XYZZY", style=dashed, color=blue, fontcolor=blue];
+}
+`
+
+func TestWriteDOT(t *testing.T) {
+	b := &bytes.Buffer{}
+	WriteDOT(b, mapGame())
+	if got := b.String(); got != mapGameDOT {
+		t.Errorf("WriteDOT wrote:\n%s\nwant:\n%s", got, mapGameDOT)
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	b := &bytes.Buffer{}
+	WriteSVG(b, mapGame())
+
+	// The whole image must be well-formed XML, with the text escaped.
+	var texts []string
+	d := xml.NewDecoder(b)
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("WriteSVG wrote malformed XML: %v", err)
+		}
+		if cd, ok := tok.(xml.CharData); ok {
+			if s := strings.TrimSpace(string(cd)); s != "" {
+				texts = append(texts, s)
+			}
+		}
+	}
+
+	for _, want := range []string{
+		"1: forest",
+		"- Lamp",
+		`2: "cave" & <tunnel>`,
+		"$ *Gold bar*",
+		"East / West",
+		"South",
+	} {
+		found := false
+		for _, s := range texts {
+			found = found || s == want
+		}
+		if !found {
+			t.Errorf("WriteSVG didn't label anything %q; labels are %q", want, texts)
+		}
+	}
+	// Passages from anywhere are left out of the SVG.
+	for _, s := range texts {
+		if strings.Contains(s, "XYZZY") {
+			t.Errorf("WriteSVG drew the passage from anywhere: %q", s)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	for _, tc := range []struct {
+		s     string
+		width int
+		want  []string
+	}{
+		{"", 10, nil},
+		{"short", 10, []string{"short"}},
+		{"one two three four", 9, []string{"one two", "three", "four"}},
+		{"two\nlines", 20, []string{"two lines"}},
+		{"abcdefghijkl", 5, []string{"abcde", "fghij", "kl"}},
+	} {
+		got := wrap(tc.s, tc.width)
+		if strings.Join(got, "|") != strings.Join(tc.want, "|") || len(got) != len(tc.want) {
+			t.Errorf("wrap(%q, %d) = %q, want %q", tc.s, tc.width, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package writer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/graph"
+)
+
+// These constants control the geometry of the SVG map, in pixels.
+const (
+	svgBoxWidth   = 170 // width of each room box
+	svgGapX       = 70  // horizontal space between boxes
+	svgGapY       = 50  // vertical space between boxes
+	svgMargin     = 20  // space around the whole map
+	svgLineHeight = 12  // height of each line of text
+	svgWrap       = 28  // maximum characters per line of text
+	svgMaxLines   = 4   // maximum lines of room description
+)
+
+// WriteSVG writes out a map of the game as a self-contained SVG image, with
+// the same conventions as WriteDOT, except that passages that work from
+// anywhere are left out.  The layout is done here rather than by Graphviz, so
+// that no external tools are needed: rooms are placed on a grid according to
+// the compass directions of their exits.
+func WriteSVG(out io.Writer, pb *scottpb.Game) {
+	g := graph.New(pb)
+	pos := g.Layout()
+
+	// Every cell is the same size, big enough for the tallest box.
+	text := map[int][]string{}
+	cellH := 0
+	cols, rows := 0, 0
+	for _, n := range g.Nodes {
+		text[n.Room] = svgNodeText(n)
+		if h := svgBoxHeight(text[n.Room]); h > cellH {
+			cellH = h
+		}
+		if p := pos[n.Room]; p.X+1 > cols {
+			cols = p.X + 1
+		}
+		if p := pos[n.Room]; p.Y+1 > rows {
+			rows = p.Y + 1
+		}
+	}
+
+	center := func(room int) (float64, float64) {
+		p := pos[room]
+		x := svgMargin + p.X*(svgBoxWidth+svgGapX) + svgBoxWidth/2
+		y := svgMargin + p.Y*(cellH+svgGapY) + cellH/2
+		return float64(x), float64(y)
+	}
+
+	width := 2*svgMargin + cols*(svgBoxWidth+svgGapX) - svgGapX
+	height := 2*svgMargin + rows*(cellH+svgGapY) - svgGapY
+	fmt.Fprintf(out, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"Helvetica, sans-serif\" font-size=\"10\">\n", width, height)
+	fmt.Fprintf(out, "<defs><marker id=\"arrow\" viewBox=\"0 0 10 10\" refX=\"10\" refY=\"5\" markerWidth=\"6\" markerHeight=\"6\" orient=\"auto\"><path d=\"M0,0 L10,5 L0,10 z\" fill=\"red\"/></marker>")
+	fmt.Fprintf(out, "<marker id=\"passage\" viewBox=\"0 0 10 10\" refX=\"10\" refY=\"5\" markerWidth=\"6\" markerHeight=\"6\" orient=\"auto\"><path d=\"M0,0 L10,5 L0,10 z\" fill=\"blue\"/></marker></defs>\n")
+	fmt.Fprintf(out, "<rect width=\"100%%\" height=\"100%%\" fill=\"white\"/>\n")
+
+	// Edges go first, so that the boxes are drawn over them.
+	for _, e := range g.Edges {
+		x1, y1 := center(e.From)
+		x2, y2 := center(e.To)
+		hw, hh := float64(svgBoxWidth)/2, float64(svgBoxHeight(text[e.From]))/2
+		if e.From == e.To {
+			fmt.Fprintf(out, "<path d=\"M%.0f,%.0f a14,14 0 1,1 14,-14\" fill=\"none\" stroke=\"gray\"/>\n", x1+hw-14, y1-hh)
+			fmt.Fprintf(out, "<text x=\"%.0f\" y=\"%.0f\" font-size=\"8\">%s</text>\n", x1+hw+4, y1-hh-18, svgEscape(strings.Join(e.Forward, ", ")))
+			continue
+		}
+		x1, y1 = svgClip(x1, y1, x2, y2, hw, hh)
+		x2, y2 = svgClip(x2, y2, x1, y1, hw, float64(svgBoxHeight(text[e.To]))/2)
+
+		label := strings.Join(e.Forward, ", ")
+		if len(e.Backward) > 0 {
+			label += " / " + strings.Join(e.Backward, ", ")
+		}
+		color, extra := "gray", ""
+		if e.OneWay() {
+			color, extra = "red", " marker-end=\"url(#arrow)\""
+		}
+		fmt.Fprintf(out, "<line x1=\"%.0f\" y1=\"%.0f\" x2=\"%.0f\" y2=\"%.0f\" stroke=\"%s\"%s/>\n", x1, y1, x2, y2, color, extra)
+		fmt.Fprintf(out, "<text x=\"%.0f\" y=\"%.0f\" font-size=\"8\" fill=\"%s\" text-anchor=\"middle\">%s</text>\n", (x1+x2)/2, (y1+y2)/2-2, color, svgEscape(label))
+	}
+	for _, p := range g.Passages {
+		if p.From == graph.Anywhere {
+			continue
+		}
+		x1, y1 := center(p.From)
+		x2, y2 := center(p.To)
+		x1, y1 = svgClip(x1, y1, x2, y2, float64(svgBoxWidth)/2, float64(svgBoxHeight(text[p.From]))/2)
+		x2, y2 = svgClip(x2, y2, x1, y1, float64(svgBoxWidth)/2, float64(svgBoxHeight(text[p.To]))/2)
+
+		label := p.Label
+		if p.Swap {
+			label += " (swap)"
+		}
+		fmt.Fprintf(out, "<line x1=\"%.0f\" y1=\"%.0f\" x2=\"%.0f\" y2=\"%.0f\" stroke=\"blue\" stroke-dasharray=\"4,3\" marker-end=\"url(#passage)\"/>\n", x1, y1, x2, y2)
+		fmt.Fprintf(out, "<text x=\"%.0f\" y=\"%.0f\" font-size=\"8\" fill=\"blue\" text-anchor=\"middle\">%s</text>\n", (x1+x2)/2, (y1+y2)/2+9, svgEscape(label))
+	}
+
+	for _, n := range g.Nodes {
+		cx, cy := center(n.Room)
+		lines := text[n.Room]
+		h := svgBoxHeight(lines)
+		x, y := int(cx)-svgBoxWidth/2, int(cy)-h/2
+
+		fill, stroke, strokeW := "white", "black", 1
+		if n.Dark {
+			fill = "#bfbfbf"
+		}
+		if len(n.Treasures) > 0 {
+			stroke = "goldenrod"
+		}
+		if n.Room == g.Start {
+			strokeW = 3
+		}
+		fmt.Fprintf(out, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"6\" fill=\"%s\" stroke=\"%s\" stroke-width=\"%d\"/>\n", x, y, svgBoxWidth, h, fill, stroke, strokeW)
+		if n.Room == g.Treasure {
+			fmt.Fprintf(out, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"8\" fill=\"none\" stroke=\"%s\"/>\n", x-3, y-3, svgBoxWidth+6, h+6, stroke)
+		}
+
+		for i, l := range lines {
+			color := "black"
+			if strings.HasPrefix(l, "$ ") {
+				color = "darkgoldenrod"
+			}
+			fmt.Fprintf(out, "<text x=\"%d\" y=\"%d\" fill=\"%s\">%s</text>\n", x+5, y+(i+1)*svgLineHeight, color, svgEscape(l))
+		}
+	}
+
+	fmt.Fprintf(out, "</svg>\n")
+}
+
+// svgNodeText returns the lines of text to put in a room box: the wrapped
+// description followed by one line per treasure and item.
+func svgNodeText(n *graph.Node) []string {
+	lines := wrap(fmt.Sprintf("%d: %s", n.Room, n.Description), svgWrap)
+	if len(lines) > svgMaxLines {
+		lines = append(lines[0:svgMaxLines-1], lines[svgMaxLines-1]+"...")
+	}
+	for _, it := range n.Treasures {
+		lines = append(lines, truncateText("$ "+it, svgWrap))
+	}
+	for _, it := range n.Items {
+		lines = append(lines, truncateText("- "+it, svgWrap))
+	}
+	return lines
+}
+
+// svgBoxHeight returns the height of a box holding the given lines of text.
+func svgBoxHeight(lines []string) int {
+	return (len(lines)+1)*svgLineHeight - svgLineHeight/2
+}
+
+// svgClip moves the point (x1, y1), the center of a box with the given half
+// width and height, to where the line towards (x2, y2) leaves the box.
+func svgClip(x1, y1, x2, y2, hw, hh float64) (float64, float64) {
+	dx, dy := x2-x1, y2-y1
+	t := math.Inf(1)
+	if dx != 0 {
+		t = math.Min(t, hw/math.Abs(dx))
+	}
+	if dy != 0 {
+		t = math.Min(t, hh/math.Abs(dy))
+	}
+	if math.IsInf(t, 1) {
+		return x1, y1
+	}
+	return x1 + t*dx, y1 + t*dy
+}
+
+// svgEscape escapes text for inclusion in an SVG document.
+func svgEscape(s string) string {
+	return html.EscapeString(s)
+}
+
+// wrap splits text into lines of at most |width| characters, breaking at
+// spaces where possible.  Embedded newlines are treated as spaces.
+func wrap(s string, width int) []string {
+	var lines []string
+	line := ""
+	for _, w := range strings.Fields(s) {
+		switch {
+		case line == "":
+			line = w
+		case len(line)+1+len(w) <= width:
+			line += " " + w
+		default:
+			lines = append(lines, line)
+			line = w
+		}
+		for len(line) > width {
+			lines = append(lines, line[0:width])
+			line = line[width:]
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// truncateText shortens text to at most |width| characters.
+func truncateText(s string, width int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if len(s) > width {
+		return s[0:width-3] + "..."
+	}
+	return s
+}
@@ -5,13 +5,21 @@ package writer
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/chaosotter/golang-adventures/api/scottpb"
 )
 
 // WriteTRS80 writes out the game data in the "TRS-80 format" used by ScottFree.
-func WriteTRS80(out io.Writer, pb *scottpb.Game) {
-	w := &trs80{out, pb}
+// Strings are quoted as described in the stream package, so that reading the
+// output back in gives exactly the same game.  An error is returned if the
+// game is inconsistent with its header or the output can't be written; in the
+// latter case, some of the game may already have been written.
+func WriteTRS80(out io.Writer, pb *scottpb.Game) error {
+	if err := checkCounts(pb); err != nil {
+		return err
+	}
+	w := &trs80{out: out, pb: pb}
 	w.writeHeader()
 	w.writeActions()
 	w.writeWords()
@@ -20,6 +28,42 @@ func WriteTRS80(out io.Writer, pb *scottpb.Game) {
 	w.writeItems()
 	w.writeComments()
 	w.writeFooter()
+	return w.err
+}
+
+// checkCounts makes sure that the game has everything that its header promises,
+// so that writing it out won't run off the end of anything.
+func checkCounts(pb *scottpb.Game) error {
+	if pb.Header == nil || pb.Footer == nil {
+		return fmt.Errorf("Game has no header or footer")
+	}
+	h := pb.Header
+	for _, c := range []struct {
+		what       string
+		have, want int
+	}{
+		{"actions", len(pb.Actions), int(h.NumActions)},
+		{"verbs", len(pb.Verbs), int(h.NumWords)},
+		{"nouns", len(pb.Nouns), int(h.NumWords)},
+		{"rooms", len(pb.Rooms), int(h.NumRooms)},
+		{"messages", len(pb.Messages), int(h.NumMessages)},
+		{"items", len(pb.Items), int(h.NumItems)},
+	} {
+		if c.have < c.want {
+			return fmt.Errorf("Game has %d %s, but the header says %d", c.have, c.what, c.want)
+		}
+	}
+	for i := 0; i < int(h.NumActions); i++ {
+		if a := pb.Actions[i]; len(a.Conditions) < 5 || len(a.Actions) < 4 {
+			return fmt.Errorf("Action %d has %d conditions and %d commands, want 5 and 4", i, len(a.Conditions), len(a.Actions))
+		}
+	}
+	for i := 0; i < int(h.NumRooms); i++ {
+		if r := pb.Rooms[i]; len(r.Exits) < 6 {
+			return fmt.Errorf("Room %d has %d exits, want 6", i, len(r.Exits))
+		}
+	}
+	return nil
 }
 
 // trs80 escapulates the Game proto and output writer, just to make for simplier
@@ -27,6 +71,14 @@ func WriteTRS80(out io.Writer, pb *scottpb.Game) {
 type trs80 struct {
 	out io.Writer
 	pb  *scottpb.Game
+	err error // the first error writing the output, if any
+}
+
+// printf writes formatted output, unless an earlier write has failed.
+func (t *trs80) printf(format string, args ...interface{}) {
+	if t.err == nil {
+		_, t.err = fmt.Fprintf(t.out, format, args...)
+	}
 }
 
 // writeHeader writes out the game header.
@@ -70,9 +122,9 @@ func (t *trs80) writeWords() {
 // writeWord writes out a single word.
 func (t *trs80) writeWord(w *scottpb.Word) {
 	if w.Synonym {
-		fmt.Fprintf(t.out, "\"*%s\"\n", w.Word)
+		t.writeStringLn("*" + w.Word)
 	} else {
-		fmt.Fprintf(t.out, "\"%s\"\n", w.Word)
+		t.writeStringLn(w.Word)
 	}
 }
 
@@ -90,16 +142,16 @@ func (t *trs80) writeRooms() {
 // writeRoomDescription writes out a single room description.
 func (t *trs80) writeRoomDescription(r *scottpb.Room) {
 	if r.Literal {
-		fmt.Fprintf(t.out, "\"*%s\"\n", r.Description)
+		t.writeStringLn("*" + r.Description)
 	} else {
-		fmt.Fprintf(t.out, "\"%s\"\n", r.Description)
+		t.writeStringLn(r.Description)
 	}
 }
 
 // writeMessages writes out the messages.
 func (t *trs80) writeMessages() {
 	for i := 0; i < int(t.pb.Header.NumMessages); i++ {
-		fmt.Fprintf(t.out, "\"%s\"\n", t.pb.Messages[i])
+		t.writeStringLn(t.pb.Messages[i])
 	}
 }
 
@@ -107,10 +159,15 @@ func (t *trs80) writeMessages() {
 func (t *trs80) writeItems() {
 	for i := 0; i < int(t.pb.Header.NumItems); i++ {
 		it := t.pb.Items[i]
+		desc := it.Description
 		if it.Autograb != "" {
-			fmt.Fprintf(t.out, "\"%s/%s/\" %d \n", it.Description, it.Autograb, it.Location)
+			desc += "/" + it.Autograb + "/"
+		}
+		if t.pb.Layout.GetItemLocationOnOwnLine() {
+			t.writeStringLn(desc)
+			t.writeIntLn(it.Location)
 		} else {
-			fmt.Fprintf(t.out, "\"%s\" %d \n", it.Description, it.Location)
+			t.printf("%s %d \n", quote(desc), it.Location)
 		}
 	}
 }
@@ -118,7 +175,7 @@ func (t *trs80) writeItems() {
 // writeComments writes out the action comments.
 func (t *trs80) writeComments() {
 	for i := 0; i < int(t.pb.Header.NumActions); i++ {
-		fmt.Fprintf(t.out, "\"%s\"\n", t.pb.Actions[i].Comment)
+		t.writeStringLn(t.pb.Actions[i].Comment)
 	}
 }
 
@@ -130,11 +187,22 @@ func (t *trs80) writeFooter() {
 	t.writeIntLn(f.Magic)
 }
 
+// writeStringLn writes out a string value, with a trailing newline.
+func (t *trs80) writeStringLn(v string) {
+	t.printf("%s\n", quote(v))
+}
+
+// quote makes a quoted string in the scheme understood by the stream package,
+// which is the same as ScottFree's: any '"' within the string is doubled.
+func quote(v string) string {
+	return `"` + strings.ReplaceAll(v, `"`, `""`) + `"`
+}
+
 // writeIntLn writes out an integer value, with a trailing newline.
 func (t *trs80) writeIntLn(v int32) {
 	if v >= 0 {
-		fmt.Fprintf(t.out, " %d \n", v)
+		t.printf(" %d \n", v)
 	} else {
-		fmt.Fprintf(t.out, "%d \n", v)
+		t.printf("%d \n", v)
 	}
 }
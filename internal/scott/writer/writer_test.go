@@ -0,0 +1,163 @@
+package writer
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chaosotter/golang-adventures/api/scottpb"
+	"github.com/chaosotter/golang-adventures/internal/scott/parser"
+)
+
+// gameFiles returns the paths of all of the bundled game files.
+func gameFiles(t *testing.T) []string {
+	paths, err := filepath.Glob("../../../games/*.dat")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("Could not find the game files: %v", err)
+	}
+	return paths
+}
+
+func TestWriteTRS80RoundTrip(t *testing.T) {
+	for _, path := range gameFiles(t) {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Could not read %q: %v", path, err)
+		}
+		pb, err := parser.Parse(data)
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+
+		b := &bytes.Buffer{}
+		if err := WriteTRS80(b, pb); err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+		if bytes.Equal(b.Bytes(), data) {
+			continue
+		}
+
+		got := strings.Split(b.String(), "\n")
+		want := strings.Split(string(data), "\n")
+		for i := range want {
+			if i >= len(got) || got[i] != want[i] {
+				line := ""
+				if i < len(got) {
+					line = got[i]
+				}
+				t.Errorf("%s:%d: wrote %q, want %q", path, i+1, line, want[i])
+				break
+			}
+		}
+	}
+}
+
+func TestWriteTRS80Quoting(t *testing.T) {
+	data, err := ioutil.ReadFile(gameFiles(t)[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb, err := parser.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{
+		`He said "hello"`,
+		`"`,
+		`""`,
+		`back\slash`,
+		`\"`,
+		"two\nlines",
+		"\xe9t\xe9 \x80",
+	} {
+		pb.Messages[0] = s
+		pb.Actions[0].Comment = s
+		pb.Rooms[0].Description = s
+
+		b := &bytes.Buffer{}
+		if err := WriteTRS80(b, pb); err != nil {
+			t.Fatal(err)
+		}
+		out := b.Bytes()
+		got, err := parser.Parse(out)
+		if err != nil {
+			t.Errorf("%q: could not parse output: %v", s, err)
+			continue
+		}
+		if !proto.Equal(got, pb) {
+			t.Errorf("%q: game changed in round trip; message is now %q", s, got.Messages[0])
+			continue
+		}
+
+		b.Reset()
+		if err := WriteTRS80(b, got); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b.Bytes(), out) {
+			t.Errorf("%q: output changed on second round trip", s)
+		}
+	}
+}
+
+// failWriter fails after a given number of bytes.
+type failWriter struct {
+	left int
+}
+
+func (w *failWriter) Write(p []byte) (int, error) {
+	if len(p) > w.left {
+		n := w.left
+		w.left = 0
+		return n, errors.New("disk full")
+	}
+	w.left -= len(p)
+	return len(p), nil
+}
+
+func TestWriteTRS80Errors(t *testing.T) {
+	data, err := ioutil.ReadFile(gameFiles(t)[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		mangle func(pb *scottpb.Game)
+		want   string
+	}{
+		{"no header", func(pb *scottpb.Game) { pb.Header = nil }, "Game has no header or footer"},
+		{"missing items", func(pb *scottpb.Game) { pb.Items = pb.Items[1:] }, "items, but the header says"},
+		{"short action", func(pb *scottpb.Game) { pb.Actions[3].Conditions = nil }, "Action 3 has 0 conditions"},
+		{"short room", func(pb *scottpb.Game) { pb.Rooms[2].Exits = pb.Rooms[2].Exits[0:5] }, "Room 2 has 5 exits"},
+	} {
+		pb, err := parser.Parse(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tc.mangle(pb)
+		b := &bytes.Buffer{}
+		if err := WriteTRS80(b, pb); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: WriteTRS80 returned %v, want %q", tc.name, err, tc.want)
+		}
+		if b.Len() != 0 {
+			t.Errorf("%s: WriteTRS80 wrote %d bytes of a bad game", tc.name, b.Len())
+		}
+	}
+
+	pb, err := parser.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := &failWriter{left: 100}
+	if err := WriteTRS80(w, pb); err == nil || err.Error() != "disk full" {
+		t.Errorf("WriteTRS80 to a full disk returned %v", err)
+	}
+}
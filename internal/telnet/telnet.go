@@ -0,0 +1,398 @@
+// Package telnet implements enough of the telnet protocol (RFC 854) to serve
+// text games to real MUD clients.  A Conn wraps a network connection, strips
+// out the protocol commands as it reads, and escapes data as it writes.
+//
+// The following options are negotiated:
+//
+//	ECHO   (RFC 857)  offered by the server to stop the client echoing input,
+//	                  for passwords; see SetEcho
+//	TTYPE  (RFC 1091) to find out the client's terminal type
+//	NAWS   (RFC 1073) to find out the client's window size, for word wrapping
+//
+// Every other option is refused.  The client is otherwise left in its default
+// line-at-a-time mode.
+package telnet
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Telnet commands.
+const (
+	SE   = 240 // end of subnegotiation
+	NOP  = 241 // no operation
+	GA   = 249 // go ahead
+	SB   = 250 // start of subnegotiation
+	WILL = 251 // sender wants to enable an option on its side
+	WONT = 252 // sender refuses to enable an option on its side
+	DO   = 253 // sender wants the receiver to enable an option
+	DONT = 254 // sender wants the receiver to disable an option
+	IAC  = 255 // interpret as command
+)
+
+// Telnet options.
+const (
+	OptEcho  = 1  // echo
+	OptSGA   = 3  // suppress go ahead
+	OptTType = 24 // terminal type
+	OptNAWS  = 31 // negotiate about window size
+)
+
+// Subnegotiation commands for TTYPE.
+const (
+	ttypeIs   = 0
+	ttypeSend = 1
+)
+
+// These are used if the client doesn't tell us its window size.
+const (
+	DefaultWidth  = 80
+	DefaultHeight = 24
+)
+
+// maxSub is the longest subnegotiation we'll keep.
+const maxSub = 256
+
+// These states are used by the FSM in Read for parsing the input.
+const (
+	stateData   = iota // reading ordinary data
+	stateIAC           // read IAC
+	stateOpt           // read IAC followed by WILL, WONT, DO or DONT
+	stateSub           // within a subnegotiation
+	stateSubIAC        // read IAC within a subnegotiation
+	stateCR            // read a carriage return in ordinary data
+)
+
+// A Conn is a telnet connection.  Reads must all be done from a single
+// goroutine, but the other methods are safe to call from any goroutine.
+type Conn struct {
+	conn net.Conn
+	in   *bufio.Reader
+
+	st  int    // state of the input FSM
+	cmd byte   // the command being read, in stateOpt
+	sub []byte // the subnegotiation being read
+
+	mu     sync.Mutex // guards everything below, as well as writes
+	width  int        // the width of the client's window
+	height int        // the height of the client's window
+	ttype  string     // the client's terminal type
+	echo   bool       // set if we have offered to echo
+	asked  [256]bool  // set for the options we have asked the client about
+	resize func(width, height int)
+}
+
+// NewConn starts the telnet protocol on a network connection, asking the client
+// about its terminal type and window size.
+func NewConn(conn net.Conn) *Conn {
+	c := &Conn{
+		conn:   conn,
+		in:     bufio.NewReader(conn),
+		width:  DefaultWidth,
+		height: DefaultHeight,
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ask(DO, OptNAWS)
+	c.ask(DO, OptTType)
+	return c
+}
+
+// Read reads ordinary data from the client, handling any telnet commands along
+// the way.  Line endings are turned into a plain '\n'.
+func (c *Conn) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	n := 0
+	for n == 0 {
+		// Block for the first byte, but then only read what's buffered.
+		for n < len(p) && (n == 0 || c.in.Buffered() > 0) {
+			ch, err := c.in.ReadByte()
+			if err != nil {
+				if n > 0 {
+					return n, nil
+				}
+				return 0, err
+			}
+			if b, ok := c.input(ch); ok {
+				p[n] = b
+				n++
+			}
+		}
+	}
+	return n, nil
+}
+
+// ReadLine reads a line of input from the client, without the line ending.
+func (c *Conn) ReadLine() (string, error) {
+	var line []byte
+	var buf [1]byte
+	for {
+		if _, err := c.Read(buf[:]); err != nil {
+			if err == io.EOF && len(line) > 0 {
+				return string(line), nil
+			}
+			return "", err
+		}
+		switch ch := buf[0]; ch {
+		case '\n':
+			return string(line), nil
+		case '\b', 0x7f:
+			// Some clients send these along, even in line mode.
+			if len(line) > 0 {
+				line = line[0 : len(line)-1]
+			}
+		default:
+			if ch >= ' ' || ch == '\t' {
+				line = append(line, ch)
+			}
+		}
+	}
+}
+
+// Write sends data to the client, escaping IAC and turning '\n' into "\r\n".
+func (c *Conn) Write(p []byte) (int, error) {
+	var b bytes.Buffer
+	for _, ch := range p {
+		switch ch {
+		case IAC:
+			b.Write([]byte{IAC, IAC})
+		case '\n':
+			b.WriteString("\r\n")
+		default:
+			b.WriteByte(ch)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.conn.Write(b.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// SetEcho controls whether the client echoes what the user types.  Turning it
+// off is meant for passwords: we offer to do the echoing ourselves, and then
+// don't.
+func (c *Conn) SetEcho(on bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.echo == !on {
+		return nil
+	}
+	c.echo = !on
+	if on {
+		return c.send(IAC, WONT, OptEcho)
+	}
+	return c.send(IAC, WILL, OptEcho)
+}
+
+// Size returns the size of the client's window, or the defaults if the client
+// hasn't told us.
+func (c *Conn) Size() (width, height int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.width, c.height
+}
+
+// Width returns the width of the client's window.
+func (c *Conn) Width() int {
+	w, _ := c.Size()
+	return w
+}
+
+// TerminalType returns the client's terminal type, or "" if the client hasn't
+// told us.
+func (c *Conn) TerminalType() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ttype
+}
+
+// OnResize sets a function to be called (from the reading goroutine) whenever
+// the client reports a new window size.
+func (c *Conn) OnResize(f func(width, height int)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resize = f
+}
+
+// Close closes the underlying connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// RemoteAddr returns the address of the client.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// input runs a single byte of input through the FSM, returning the byte and
+// true if it is ordinary data.
+func (c *Conn) input(ch byte) (byte, bool) {
+	switch c.st {
+	// Data state: Reading ordinary data.
+	case stateData:
+		switch ch {
+		case IAC:
+			c.st = stateIAC
+		case '\r':
+			c.st = stateCR
+			return '\n', true
+		case 0:
+			// NUL is ignored.
+		default:
+			return ch, true
+		}
+
+	// CR state: Read a carriage return, which may be followed by a line feed
+	// or NUL that's part of the same line ending.
+	case stateCR:
+		c.st = stateData
+		if ch == '\n' || ch == 0 {
+			break
+		}
+		return c.input(ch)
+
+	// IAC state: Read IAC, so this is a command.
+	case stateIAC:
+		switch ch {
+		case IAC:
+			c.st = stateData
+			return IAC, true
+		case WILL, WONT, DO, DONT:
+			c.cmd, c.st = ch, stateOpt
+		case SB:
+			c.sub, c.st = c.sub[0:0], stateSub
+		default:
+			// NOP, GA and the like need no response.
+			c.st = stateData
+		}
+
+	// Opt state: Read IAC and a negotiation command; this is the option.
+	case stateOpt:
+		c.st = stateData
+		c.negotiate(c.cmd, ch)
+
+	// Sub state: Within a subnegotiation, which runs until IAC SE.
+	case stateSub:
+		if ch == IAC {
+			c.st = stateSubIAC
+		} else if len(c.sub) < maxSub {
+			c.sub = append(c.sub, ch)
+		}
+
+	// SubIAC state: Read IAC within a subnegotiation.
+	case stateSubIAC:
+		switch ch {
+		case SE:
+			c.st = stateData
+			c.subnegotiate(c.sub)
+		case IAC:
+			c.st = stateSub
+			if len(c.sub) < maxSub {
+				c.sub = append(c.sub, IAC)
+			}
+		default:
+			// The client forgot to finish the subnegotiation.
+			c.st = stateIAC
+			return c.input(ch)
+		}
+	}
+	return 0, false
+}
+
+// negotiate responds to a request from the client about an option.
+func (c *Conn) negotiate(cmd, opt byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch cmd {
+	case WILL:
+		switch {
+		case opt == OptTType:
+			c.asked[opt] = true
+			c.send(IAC, SB, OptTType, ttypeSend, IAC, SE)
+		case opt == OptNAWS:
+			c.asked[opt] = true
+		default:
+			c.send(IAC, DONT, opt)
+		}
+	case WONT:
+		// Only acknowledge a refusal of something we asked for, to avoid
+		// loops.
+		if c.asked[opt] {
+			c.asked[opt] = false
+			c.send(IAC, DONT, opt)
+		}
+	case DO:
+		switch {
+		case opt == OptEcho && c.echo:
+			// This is the answer to our offer.
+		case opt == OptSGA:
+			// We never send GA anyway.
+			c.send(IAC, WILL, opt)
+		default:
+			c.send(IAC, WONT, opt)
+		}
+	case DONT:
+		if opt == OptEcho && c.echo {
+			c.echo = false
+			c.send(IAC, WONT, opt)
+		}
+	}
+}
+
+// subnegotiate handles a complete subnegotiation from the client.
+func (c *Conn) subnegotiate(sub []byte) {
+	if len(sub) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	switch sub[0] {
+	case OptNAWS:
+		if len(sub) != 5 {
+			break
+		}
+		w := int(sub[1])<<8 | int(sub[2])
+		h := int(sub[3])<<8 | int(sub[4])
+		// Zero means the client doesn't know.
+		if w == 0 {
+			w = DefaultWidth
+		}
+		if h == 0 {
+			h = DefaultHeight
+		}
+		c.width, c.height = w, h
+		if f := c.resize; f != nil {
+			c.mu.Unlock()
+			f(w, h)
+			return
+		}
+	case OptTType:
+		if len(sub) > 1 && sub[1] == ttypeIs {
+			c.ttype = strings.ToUpper(string(sub[2:]))
+		}
+	}
+	c.mu.Unlock()
+}
+
+// ask asks the client about an option (for internal use, with the lock held).
+func (c *Conn) ask(cmd, opt byte) {
+	c.asked[opt] = true
+	c.send(IAC, cmd, opt)
+}
+
+// send sends a command to the client (for internal use, with the lock held).
+func (c *Conn) send(b ...byte) error {
+	_, err := c.conn.Write(b)
+	return err
+}
@@ -0,0 +1,246 @@
+package telnet
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+)
+
+// client is the other end of a Conn, playing the part of a MUD client.
+type client struct {
+	t    *testing.T
+	conn net.Conn
+	in   chan []byte // what the server has sent, as it arrives
+	buf  []byte      // what has arrived but not yet been checked
+}
+
+// newConn makes a Conn talking to a fake client over a pipe, and checks that
+// it opens by asking about the window size and terminal type.
+func newConn(t *testing.T) (*Conn, *client) {
+	server, conn := net.Pipe()
+	cl := &client{t: t, conn: conn, in: make(chan []byte, 100)}
+	go func() {
+		for {
+			b := make([]byte, 256)
+			n, err := conn.Read(b)
+			if err != nil {
+				close(cl.in)
+				return
+			}
+			cl.in <- b[0:n]
+		}
+	}()
+
+	c := NewConn(server)
+	t.Cleanup(func() {
+		c.Close()
+		conn.Close()
+	})
+	cl.expect(IAC, DO, OptNAWS, IAC, DO, OptTType)
+	return c, cl
+}
+
+// send sends bytes to the server.  The pipe is synchronous, so this happens in
+// the background, to be picked up by the next read.
+func (cl *client) send(b ...byte) {
+	go cl.conn.Write(b)
+}
+
+// expect checks that the server sends exactly the given bytes next.
+func (cl *client) expect(want ...byte) {
+	cl.t.Helper()
+	timeout := time.After(5 * time.Second)
+	for len(cl.buf) < len(want) {
+		select {
+		case b, ok := <-cl.in:
+			if !ok {
+				cl.t.Fatalf("Connection closed after %q, want %q", cl.buf, want)
+			}
+			cl.buf = append(cl.buf, b...)
+		case <-timeout:
+			cl.t.Fatalf("Timed out after %q, want %q", cl.buf, want)
+		}
+	}
+	if got := cl.buf[0:len(want)]; !bytes.Equal(got, want) {
+		cl.t.Errorf("Server sent %v, want %v", got, want)
+	}
+	cl.buf = cl.buf[len(want):]
+}
+
+// readLine reads a line on the server, checking that it's the given one.
+func readLine(t *testing.T, c *Conn, want string) {
+	t.Helper()
+	got, err := c.ReadLine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("ReadLine returned %q, want %q", got, want)
+	}
+}
+
+func TestWriteEscapes(t *testing.T) {
+	c, cl := newConn(t)
+	go c.Write([]byte{'a', IAC, 'b', '\n', 'c'})
+	cl.expect('a', IAC, IAC, 'b', '\r', '\n', 'c')
+}
+
+func TestReadUnescapes(t *testing.T) {
+	c, cl := newConn(t)
+	cl.send('a', IAC, IAC, 'b', '\r', '\n')
+	readLine(t, c, "a\xffb")
+
+	// Commands that need no answer are dropped.
+	cl.send('c', IAC, NOP, 'd', IAC, GA, '\r', '\n')
+	readLine(t, c, "cd")
+}
+
+func TestLineEndings(t *testing.T) {
+	c, cl := newConn(t)
+	cl.send([]byte("CR LF\r\nCR NUL\r\x00LF\nCR\rNUL\x00\r\n")...)
+	for _, want := range []string{"CR LF", "CR NUL", "LF", "CR", "NUL"} {
+		readLine(t, c, want)
+	}
+
+	// Backspaces are applied, and control characters dropped.
+	cl.send([]byte("GET LAMQ\bP\x7f\x7fMP\x01\tX\r\n")...)
+	readLine(t, c, "GET LAMP\tX")
+}
+
+func TestNAWS(t *testing.T) {
+	c, cl := newConn(t)
+	if w, h := c.Size(); w != DefaultWidth || h != DefaultHeight {
+		t.Errorf("Before NAWS, the size is %dx%d", w, h)
+	}
+
+	var sizes [][2]int
+	c.OnResize(func(w, h int) { sizes = append(sizes, [2]int{w, h}) })
+
+	// WILL NAWS is the answer to our question, so there's no reply.
+	cl.send(IAC, WILL, OptNAWS, IAC, SB, OptNAWS, 0, 100, 0, 40, IAC, SE, '\r', '\n')
+	readLine(t, c, "")
+	if w, h := c.Size(); w != 100 || h != 40 {
+		t.Errorf("After NAWS, the size is %dx%d, want 100x40", w, h)
+	}
+
+	// A width of 255 has to be escaped within the subnegotiation.
+	cl.send(IAC, SB, OptNAWS, 0, IAC, IAC, 1, 0, IAC, SE, '\r', '\n')
+	readLine(t, c, "")
+	if w := c.Width(); w != 255 {
+		t.Errorf("After NAWS, the width is %d, want 255", w)
+	}
+
+	// Zero means that the client doesn't know.
+	cl.send(IAC, SB, OptNAWS, 0, 0, 0, 0, IAC, SE, '\r', '\n')
+	readLine(t, c, "")
+	if w, h := c.Size(); w != DefaultWidth || h != DefaultHeight {
+		t.Errorf("After NAWS with zeroes, the size is %dx%d", w, h)
+	}
+
+	// Malformed sizes are ignored.
+	cl.send(IAC, SB, OptNAWS, 0, 50, IAC, SE, '\r', '\n')
+	readLine(t, c, "")
+
+	want := [][2]int{{100, 40}, {255, 256}, {DefaultWidth, DefaultHeight}}
+	if len(sizes) != len(want) {
+		t.Fatalf("OnResize was called with %v, want %v", sizes, want)
+	}
+	for i := range want {
+		if sizes[i] != want[i] {
+			t.Errorf("OnResize was called with %v, want %v", sizes, want)
+			break
+		}
+	}
+}
+
+func TestTType(t *testing.T) {
+	c, cl := newConn(t)
+	if tt := c.TerminalType(); tt != "" {
+		t.Errorf("Before TTYPE, the terminal type is %q", tt)
+	}
+
+	cl.send(IAC, WILL, OptTType, '\r', '\n')
+	readLine(t, c, "")
+	cl.expect(IAC, SB, OptTType, ttypeSend, IAC, SE)
+
+	cl.send(append(append([]byte{IAC, SB, OptTType, ttypeIs}, "xterm-256color"...), IAC, SE, '\r', '\n')...)
+	readLine(t, c, "")
+	if tt := c.TerminalType(); tt != "XTERM-256COLOR" {
+		t.Errorf("After TTYPE, the terminal type is %q", tt)
+	}
+}
+
+func TestUnterminatedSubnegotiation(t *testing.T) {
+	c, cl := newConn(t)
+
+	// The client starts a subnegotiation but then sends another command
+	// instead of finishing it.  The command is still obeyed, and the
+	// subnegotiation is dropped.
+	cl.send(IAC, SB, OptNAWS, 0, 50, IAC, DO, 99, 'o', 'k', '\r', '\n')
+	readLine(t, c, "ok")
+	cl.expect(IAC, WONT, 99)
+	if w := c.Width(); w != DefaultWidth {
+		t.Errorf("After a broken subnegotiation, the width is %d", w)
+	}
+
+	// Overlong subnegotiations are cut short rather than kept in full.
+	long := []byte{IAC, SB, OptTType, ttypeIs}
+	for i := 0; i < 1000; i++ {
+		long = append(long, 'x')
+	}
+	cl.send(append(long, IAC, SE, 'o', 'k', '\r', '\n')...)
+	readLine(t, c, "ok")
+	if tt := c.TerminalType(); len(tt) != maxSub-2 {
+		t.Errorf("After an overlong subnegotiation, the terminal type is %d characters, want %d", len(tt), maxSub-2)
+	}
+}
+
+func TestRefuseOptions(t *testing.T) {
+	c, cl := newConn(t)
+	for _, tc := range []struct {
+		send, want []byte
+	}{
+		{[]byte{IAC, DO, 99}, []byte{IAC, WONT, 99}},
+		{[]byte{IAC, WILL, 99}, []byte{IAC, DONT, 99}},
+		{[]byte{IAC, DO, OptTType}, []byte{IAC, WONT, OptTType}},
+		{[]byte{IAC, DO, OptEcho}, []byte{IAC, WONT, OptEcho}},
+		// We never send GA, so we're happy to agree not to.
+		{[]byte{IAC, DO, OptSGA}, []byte{IAC, WILL, OptSGA}},
+	} {
+		cl.send(append(tc.send, '\r', '\n')...)
+		readLine(t, c, "")
+		cl.expect(tc.want...)
+	}
+}
+
+func TestWontLoop(t *testing.T) {
+	c, cl := newConn(t)
+
+	// A refusal of something we never asked about gets no reply, nor does a
+	// second refusal of something we did ask about.  The DO at the end
+	// checks that nothing else was sent in between.
+	cl.send(IAC, WONT, 99, IAC, WONT, OptNAWS, IAC, WONT, OptNAWS, IAC, DONT, 98, IAC, DO, 97, '\r', '\n')
+	readLine(t, c, "")
+	cl.expect(IAC, DONT, OptNAWS, IAC, WONT, 97)
+}
+
+func TestSetEcho(t *testing.T) {
+	c, cl := newConn(t)
+
+	go c.SetEcho(false)
+	cl.expect(IAC, WILL, OptEcho)
+	// The client agreeing needs no reply.
+	cl.send(IAC, DO, OptEcho, '\r', '\n')
+	readLine(t, c, "")
+
+	go c.SetEcho(true)
+	cl.expect(IAC, WONT, OptEcho)
+
+	// The client may also turn it back on itself.
+	go c.SetEcho(false)
+	cl.expect(IAC, WILL, OptEcho)
+	cl.send(IAC, DONT, OptEcho, IAC, DO, 99, '\r', '\n')
+	readLine(t, c, "")
+	cl.expect(IAC, WONT, OptEcho, IAC, WONT, 99)
+}
@@ -0,0 +1,272 @@
+// Package websocket is a minimal server-side implementation of the WebSocket
+// protocol (RFC 6455), just enough for exchanging text messages with a
+// browser.  Binary messages, extensions and subprotocols aren't supported.
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// guid is the magic value from the RFC used to compute the accept key.
+const guid = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// MaxMessage is the largest message we'll accept from a client.
+const MaxMessage = 64 << 10
+
+// Opcodes for the various frame types.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// ErrClosed is returned when reading from a connection that the other side has
+// closed.
+var ErrClosed = errors.New("WebSocket closed")
+
+// A Conn is a WebSocket connection.  Reads must all be done from a single
+// goroutine, but writes may be done from any number of them.
+type Conn struct {
+	conn net.Conn
+	in   *bufio.Reader
+
+	mu     sync.Mutex // guards writes
+	closed bool       // set once a close frame has been sent
+}
+
+// Upgrade turns an HTTP request into a WebSocket connection.  If the request
+// isn't a valid WebSocket handshake, an error response is sent and an error is
+// returned.  Requests from other origins are rejected, so that other websites
+// can't drive the connection on the user's behalf.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	fail := func(code int, format string, args ...interface{}) (*Conn, error) {
+		msg := fmt.Sprintf(format, args...)
+		http.Error(w, msg, code)
+		return nil, errors.New(msg)
+	}
+
+	if r.Method != http.MethodGet {
+		return fail(http.StatusMethodNotAllowed, "WebSocket handshake must use GET")
+	}
+	if !headerHas(r.Header, "Connection", "upgrade") || !headerHas(r.Header, "Upgrade", "websocket") {
+		return fail(http.StatusBadRequest, "Not a WebSocket handshake")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		return fail(http.StatusUpgradeRequired, "Unsupported WebSocket version")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		return fail(http.StatusBadRequest, "Missing Sec-WebSocket-Key")
+	}
+	if origin := r.Header.Get("Origin"); origin != "" && !sameOrigin(origin, r.Host) {
+		return fail(http.StatusForbidden, "Cross-origin WebSocket request from %s", origin)
+	}
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		return fail(http.StatusInternalServerError, "Connection can't be hijacked")
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return fail(http.StatusInternalServerError, "Could not hijack connection: %v", err)
+	}
+	// Clear any deadlines set by the HTTP server.
+	conn.SetDeadline(time.Time{})
+
+	h := sha1.Sum([]byte(key + guid))
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\n")
+	fmt.Fprintf(rw, "Upgrade: websocket\r\n")
+	fmt.Fprintf(rw, "Connection: Upgrade\r\n")
+	fmt.Fprintf(rw, "Sec-WebSocket-Accept: %s\r\n\r\n", base64.StdEncoding.EncodeToString(h[:]))
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &Conn{conn: conn, in: rw.Reader}, nil
+}
+
+// ReadMessage returns the next text message from the client.  Pings are
+// answered along the way.  ErrClosed is returned once the client closes the
+// connection.
+func (c *Conn) ReadMessage() (string, error) {
+	var msg []byte
+	started := false
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return "", err
+		}
+
+		switch op {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return "", err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			// Echo the status code back, as the RFC asks.
+			if len(payload) > 2 {
+				payload = payload[0:2]
+			}
+			c.close(payload)
+			return "", ErrClosed
+		case opText:
+			if started {
+				return "", c.fail("New message before the last one finished")
+			}
+			started = true
+		case opContinuation:
+			if !started {
+				return "", c.fail("Continuation frame with no message")
+			}
+		case opBinary:
+			return "", c.fail("Binary messages aren't supported")
+		default:
+			return "", c.fail("Unknown opcode %#x", op)
+		}
+
+		if len(msg)+len(payload) > MaxMessage {
+			return "", c.fail("Message is too large")
+		}
+		msg = append(msg, payload...)
+		if fin {
+			return string(msg), nil
+		}
+	}
+}
+
+// WriteMessage sends a text message to the client.
+func (c *Conn) WriteMessage(msg string) error {
+	return c.writeFrame(opText, []byte(msg))
+}
+
+// Close closes the connection, telling the client first if possible.
+func (c *Conn) Close() error {
+	c.close([]byte{0x03, 0xe8}) // 1000: normal closure
+	return c.conn.Close()
+}
+
+// readFrame reads a single frame from the client, unmasking the payload.
+func (c *Conn) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var hdr [2]byte
+	if _, err := io.ReadFull(c.in, hdr[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin = hdr[0]&0x80 != 0
+	op = hdr[0] & 0x0f
+	if hdr[0]&0x70 != 0 {
+		return false, 0, nil, c.fail("Reserved bits set without an extension")
+	}
+	if hdr[1]&0x80 == 0 {
+		return false, 0, nil, c.fail("Frame from client isn't masked")
+	}
+
+	n := uint64(hdr[1] & 0x7f)
+	switch n {
+	case 126:
+		var b [2]byte
+		if _, err := io.ReadFull(c.in, b[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(b[:]))
+	case 127:
+		var b [8]byte
+		if _, err := io.ReadFull(c.in, b[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(b[:])
+	}
+	if op >= opClose && (n > 125 || !fin) {
+		return false, 0, nil, c.fail("Bad control frame")
+	}
+	if n > MaxMessage {
+		return false, 0, nil, c.fail("Frame is too large")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.in, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload = make([]byte, n)
+	if _, err := io.ReadFull(c.in, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, op, payload, nil
+}
+
+// writeFrame sends a single unfragmented frame to the client.
+func (c *Conn) writeFrame(op byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+
+	hdr := []byte{0x80 | op}
+	switch n := len(payload); {
+	case n < 126:
+		hdr = append(hdr, byte(n))
+	case n <= 0xffff:
+		hdr = append(hdr, 126, byte(n>>8), byte(n))
+	default:
+		hdr = append(hdr, 127, 0, 0, 0, 0, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	if op == opClose {
+		c.closed = true
+	}
+	if _, err := c.conn.Write(append(hdr, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// close sends a close frame with the given payload, unless one has already
+// been sent.
+func (c *Conn) close(payload []byte) {
+	c.writeFrame(opClose, payload)
+}
+
+// fail closes the connection because of a protocol error from the client.
+func (c *Conn) fail(format string, args ...interface{}) error {
+	c.close([]byte{0x03, 0xea}) // 1002: protocol error
+	c.conn.Close()
+	return fmt.Errorf("WebSocket protocol error: "+format, args...)
+}
+
+// headerHas checks if the given header contains the token, ignoring case.
+func headerHas(h http.Header, name, token string) bool {
+	for _, v := range h[http.CanonicalHeaderKey(name)] {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sameOrigin checks if the origin refers to the host the request was sent to.
+func sameOrigin(origin, host string) bool {
+	i := strings.Index(origin, "://")
+	return i >= 0 && strings.EqualFold(origin[i+3:], host)
+}
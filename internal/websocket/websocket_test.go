@@ -0,0 +1,321 @@
+package websocket
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// key is the sample key from the RFC, with its expected accept key.
+const (
+	key       = "dGhlIHNhbXBsZSBub25jZQ=="
+	acceptKey = "s3pPLMBiTxaQ9kYGzzhZRbK+xOo="
+)
+
+// echoServer starts a server that echoes every message back to the client.
+// The error that ends each connection is sent on the returned channel.
+func echoServer(t *testing.T) (*httptest.Server, chan error) {
+	errs := make(chan error, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		for {
+			msg, err := c.ReadMessage()
+			if err != nil {
+				errs <- err
+				return
+			}
+			if msg == "big" {
+				msg = strings.Repeat("x", 70000)
+			}
+			if err := c.WriteMessage(msg); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, errs
+}
+
+// client is the client end of a connection to a test server.
+type client struct {
+	t    *testing.T
+	conn net.Conn
+	in   *bufio.Reader
+}
+
+// dial connects to the server and performs the handshake.
+func dial(t *testing.T, srv *httptest.Server) *client {
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	fmt.Fprintf(conn, "GET / HTTP/1.1\r\nHost: %s\r\nConnection: keep-alive, Upgrade\r\nUpgrade: websocket\r\n", srv.Listener.Addr())
+	fmt.Fprintf(conn, "Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: %s\r\nOrigin: http://%s\r\n\r\n", key, srv.Listener.Addr())
+
+	c := &client{t: t, conn: conn, in: bufio.NewReader(conn)}
+	resp, err := http.ReadResponse(c.in, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("Handshake returned %s", resp.Status)
+	}
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != acceptKey {
+		t.Errorf("Handshake returned accept key %q, want %q", got, acceptKey)
+	}
+	return c
+}
+
+// send sends a single frame, masked unless |unmasked| is set.
+func (c *client) send(fin bool, op byte, payload string, unmasked bool) {
+	var b bytes.Buffer
+	first := op
+	if fin {
+		first |= 0x80
+	}
+	b.WriteByte(first)
+
+	maskBit := byte(0x80)
+	if unmasked {
+		maskBit = 0
+	}
+	switch n := len(payload); {
+	case n < 126:
+		b.WriteByte(maskBit | byte(n))
+	case n <= 0xffff:
+		b.WriteByte(maskBit | 126)
+		binary.Write(&b, binary.BigEndian, uint16(n))
+	default:
+		b.WriteByte(maskBit | 127)
+		binary.Write(&b, binary.BigEndian, uint64(n))
+	}
+
+	data := []byte(payload)
+	if !unmasked {
+		mask := []byte{0x37, 0xfa, 0x21, 0x3d}
+		b.Write(mask)
+		for i := range data {
+			data[i] ^= mask[i%4]
+		}
+	}
+	b.Write(data)
+	if _, err := c.conn.Write(b.Bytes()); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// recv reads a single frame, which must not be masked.
+func (c *client) recv() (fin bool, op byte, payload string) {
+	var hdr [2]byte
+	if _, err := io.ReadFull(c.in, hdr[:]); err != nil {
+		c.t.Fatal(err)
+	}
+	if hdr[1]&0x80 != 0 {
+		c.t.Fatal("Frame from server is masked")
+	}
+	n := uint64(hdr[1] & 0x7f)
+	switch n {
+	case 126:
+		var l uint16
+		binary.Read(c.in, binary.BigEndian, &l)
+		n = uint64(l)
+	case 127:
+		binary.Read(c.in, binary.BigEndian, &n)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(c.in, b); err != nil {
+		c.t.Fatal(err)
+	}
+	return hdr[0]&0x80 != 0, hdr[0] & 0x0f, string(b)
+}
+
+// expect reads a frame and checks that it's the given one.
+func (c *client) expect(op byte, payload string) {
+	c.t.Helper()
+	fin, gotOp, got := c.recv()
+	if !fin || gotOp != op || got != payload {
+		if len(got) > 20 {
+			got = got[0:20] + "..."
+		}
+		c.t.Errorf("Got frame fin=%v op=%#x %q (%d bytes), want op=%#x (%d bytes)", fin, gotOp, got, len(got), op, len(payload))
+	}
+}
+
+// status returns the payload of a close frame with the given status code.
+func status(code uint16) string {
+	return string([]byte{byte(code >> 8), byte(code)})
+}
+
+func TestEcho(t *testing.T) {
+	srv, _ := echoServer(t)
+	c := dial(t, srv)
+
+	for _, msg := range []string{"", "hello", strings.Repeat("a", 125), strings.Repeat("b", 126), strings.Repeat("c", 0xffff)} {
+		c.send(true, opText, msg, false)
+		c.expect(opText, msg)
+	}
+
+	// Messages from the server may be too big for two bytes of length.
+	c.send(true, opText, "big", false)
+	c.expect(opText, strings.Repeat("x", 70000))
+}
+
+func TestFragmentation(t *testing.T) {
+	srv, _ := echoServer(t)
+	c := dial(t, srv)
+
+	// Pings may come between the fragments, and are answered straight away.
+	c.send(false, opText, "GET ", false)
+	c.send(true, opPing, "are you there?", false)
+	c.expect(opPong, "are you there?")
+	c.send(false, opContinuation, "BRASS ", false)
+	c.send(true, opPong, "unsolicited", false)
+	c.send(true, opContinuation, "LAMP", false)
+	c.expect(opText, "GET BRASS LAMP")
+}
+
+func TestCloseHandshake(t *testing.T) {
+	srv, errs := echoServer(t)
+	c := dial(t, srv)
+
+	c.send(true, opClose, status(1001)+"going away", false)
+	c.expect(opClose, status(1001))
+	if err := <-errs; err != ErrClosed {
+		t.Errorf("ReadMessage returned %v, want ErrClosed", err)
+	}
+	// The server doesn't send a second close frame when it closes the
+	// connection.
+	if _, err := c.in.ReadByte(); err != io.EOF {
+		t.Errorf("Reading after the close returned %v, want EOF", err)
+	}
+}
+
+func TestWriteAfterClose(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	c := &Conn{conn: server, in: bufio.NewReader(server)}
+	go io.Copy(io.Discard, client)
+
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.WriteMessage("too late"); err != ErrClosed {
+		t.Errorf("WriteMessage after Close returned %v, want ErrClosed", err)
+	}
+}
+
+func TestProtocolErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		send func(c *client)
+		want string
+	}{
+		{"unmasked", func(c *client) { c.send(true, opText, "hello", true) }, "isn't masked"},
+		{"binary", func(c *client) { c.send(true, opBinary, "\x00\x01", false) }, "Binary messages"},
+		{"unknown opcode", func(c *client) { c.send(true, 0x3, "", false) }, "Unknown opcode"},
+		{"stray continuation", func(c *client) { c.send(true, opContinuation, "lamp", false) }, "Continuation frame"},
+		{"interleaved", func(c *client) {
+			c.send(false, opText, "GET ", false)
+			c.send(true, opText, "LAMP", false)
+		}, "New message"},
+		{"fragmented ping", func(c *client) { c.send(false, opPing, "", false) }, "Bad control frame"},
+		{"long ping", func(c *client) { c.send(true, opPing, strings.Repeat("p", 126), false) }, "Bad control frame"},
+		{"reserved bits", func(c *client) { c.send(true, 0x40|opText, "", false) }, "Reserved bits"},
+		{"huge frame", func(c *client) {
+			// Just the header, since the server won't read any further.
+			c.conn.Write([]byte{0x80 | opText, 0x80 | 127, 0, 0, 0, 0, 0, 1, 0, 1})
+		}, "Frame is too large"},
+		{"huge message", func(c *client) {
+			c.send(false, opText, strings.Repeat("h", MaxMessage), false)
+			c.send(true, opContinuation, "h", false)
+		}, "Message is too large"},
+	} {
+		srv, errs := echoServer(t)
+		c := dial(t, srv)
+		tc.send(c)
+		c.expect(opClose, status(1002))
+		if err := <-errs; err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: ReadMessage returned %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestHandshakeErrors(t *testing.T) {
+	srv, _ := echoServer(t)
+	good := http.Header{
+		"Connection":            {"Upgrade"},
+		"Upgrade":               {"websocket"},
+		"Sec-Websocket-Version": {"13"},
+		"Sec-Websocket-Key":     {key},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		header string // the header to change
+		value  string // the new value, or "" to remove it
+		code   int
+	}{
+		{"POST", "POST", "", "", http.StatusMethodNotAllowed},
+		{"no upgrade", "GET", "Upgrade", "", http.StatusBadRequest},
+		{"no connection", "GET", "Connection", "keep-alive", http.StatusBadRequest},
+		{"old version", "GET", "Sec-Websocket-Version", "8", http.StatusUpgradeRequired},
+		{"no key", "GET", "Sec-Websocket-Key", "", http.StatusBadRequest},
+		{"other origin", "GET", "Origin", "http://evil.example.com", http.StatusForbidden},
+	} {
+		req, err := http.NewRequest(tc.method, srv.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range good {
+			req.Header[k] = v
+		}
+		if tc.header != "" {
+			req.Header.Del(tc.header)
+			if tc.value != "" {
+				req.Header.Set(tc.header, tc.value)
+			}
+		}
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.code {
+			t.Errorf("%s: handshake returned %s, want %d", tc.name, resp.Status, tc.code)
+		}
+	}
+}
+
+func TestSameOrigin(t *testing.T) {
+	for _, tc := range []struct {
+		origin, host string
+		want         bool
+	}{
+		{"http://localhost:8080", "localhost:8080", true},
+		{"https://Example.COM", "example.com", true},
+		{"http://localhost:8081", "localhost:8080", false},
+		{"http://evil.com/localhost:8080", "localhost:8080", false},
+		{"null", "localhost:8080", false},
+	} {
+		if got := sameOrigin(tc.origin, tc.host); got != tc.want {
+			t.Errorf("sameOrigin(%q, %q) = %v, want %v", tc.origin, tc.host, got, tc.want)
+		}
+	}
+}